@@ -0,0 +1,327 @@
+package cmd
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"outbil/db"
+	"outbil/i18n"
+	"outbil/models"
+	"outbil/utils"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/manifoldco/promptui"
+	"github.com/pdfcpu/pdfcpu/pkg/api"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	rootCmd.AddCommand(cgvCmd)
+	cgvCmd.AddCommand(cgvListCmd)
+	cgvCmd.AddCommand(cgvAddCmd)
+	cgvCmd.AddCommand(cgvExportCmd)
+	cgvCmd.AddCommand(cgvDeleteCmd)
+
+	cgvCmd.PersistentFlags().StringP("company", "c", "", "Entreprise concernée (ID ou nom), par défaut l'entreprise par défaut")
+
+	cgvAddCmd.Flags().StringP("title", "t", "Conditions générales de vente", "Titre du document")
+	cgvAddCmd.Flags().StringP("version", "v", "", "Numéro de version (ex: 2024.1)")
+	cgvAddCmd.Flags().String("type", "all", "Type de document: quote, invoice ou all")
+	cgvAddCmd.Flags().String("category", "all", "Catégorie de client: b2b, b2c ou all")
+	cgvAddCmd.Flags().String("from", "", "Date d'effet, au format de l'entreprise ou AAAA-MM-JJ (par défaut aujourd'hui)")
+}
+
+var cgvCmd = &cobra.Command{
+	Use:   "cgv",
+	Short: "Gérer les conditions générales de vente",
+	Long: `Les CGV sont enregistrées dans la base, versionnées et datées. À la génération d'un PDF,
+la version en vigueur à la date du document est choisie selon le type de document (devis,
+facture) et la catégorie du client (B2B, B2C), puis jointe au PDF et enregistrée dans
+l'historique du document.`,
+}
+
+var cgvListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "Lister les versions des CGV",
+	Run: func(cmd *cobra.Command, args []string) {
+		database, err := db.New(utils.GetDatabasePath())
+		if err != nil {
+			utils.Error("Erreur d'ouverture de la base: %v", err)
+			return
+		}
+		defer database.Close()
+
+		selector, _ := cmd.Flags().GetString("company")
+		company, err := requireCompany(database, selector)
+		if err != nil {
+			utils.Error("%v", err)
+			return
+		}
+
+		documents, err := database.ListTermsDocuments(company.ID)
+		if err != nil {
+			utils.Error("Erreur lors de la récupération des CGV: %v", err)
+			return
+		}
+
+		if len(documents) == 0 {
+			utils.Info("Aucune CGV enregistrée. Utilisez 'outbil cgv add <fichier.pdf> --version <version>'")
+			return
+		}
+
+		table := utils.CreateTable()
+		table.Header("ID", i18n.T("Titre"), "Version", "Documents", "Clients", i18n.T("En vigueur le"), "Pages", i18n.T("Utilisations"))
+
+		for _, terms := range documents {
+			usage, _ := database.CountTermsUsage(terms.ID)
+			table.Append([]string{
+				strconv.Itoa(terms.ID),
+				terms.Title,
+				terms.Version,
+				getTermsDocumentTypeLabel(terms.DocumentType),
+				getTermsCategoryLabel(terms.ClientCategory),
+				terms.EffectiveFrom.Format("02/01/2006"),
+				strconv.Itoa(terms.Pages),
+				strconv.Itoa(usage),
+			})
+		}
+
+		table.Render()
+	},
+}
+
+var cgvAddCmd = &cobra.Command{
+	Use:   "add [FICHIER.pdf]",
+	Short: "Enregistrer une nouvelle version des CGV",
+	Long: `Enregistrer un PDF comme nouvelle version des CGV. Les versions existantes ne sont
+jamais modifiées : une version remplace la précédente à partir de sa date d'effet.
+
+Exemples:
+  outbil cgv add cgv-2025.pdf --version 2025.1 --from 01/01/2025
+  outbil cgv add cgv-particuliers.pdf --version 3 --category b2c --type quote`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		title, _ := cmd.Flags().GetString("title")
+		version, _ := cmd.Flags().GetString("version")
+		docType, _ := cmd.Flags().GetString("type")
+		category, _ := cmd.Flags().GetString("category")
+		from, _ := cmd.Flags().GetString("from")
+
+		documentType, err := parseTermsDocumentType(docType)
+		if err != nil {
+			utils.Error("%v", err)
+			return
+		}
+		clientCategory, err := parseTermsCategory(category)
+		if err != nil {
+			utils.Error("%v", err)
+			return
+		}
+
+		content, err := os.ReadFile(args[0])
+		if err != nil {
+			utils.Error("Impossible de lire le fichier: %v", err)
+			return
+		}
+
+		pages, err := api.PageCount(bytes.NewReader(content), nil)
+		if err != nil {
+			utils.Error("Le fichier n'est pas un PDF valide: %v", err)
+			return
+		}
+
+		if strings.TrimSpace(version) == "" {
+			prompt := promptui.Prompt{
+				Label: i18n.T("Numéro de version"),
+				Validate: func(input string) error {
+					if strings.TrimSpace(input) == "" {
+						return errors.New(i18n.T("la version est obligatoire"))
+					}
+					return nil
+				},
+			}
+			version, _ = prompt.Run()
+		}
+
+		database, err := db.New(utils.GetDatabasePath())
+		if err != nil {
+			utils.Error("Erreur d'ouverture de la base: %v", err)
+			return
+		}
+		defer database.Close()
+
+		selector, _ := cmd.Flags().GetString("company")
+		company, err := requireCompany(database, selector)
+		if err != nil {
+			utils.Error("%v", err)
+			return
+		}
+		useCompanyLocale(company)
+
+		effectiveFrom := time.Now()
+		if from != "" {
+			effectiveFrom, err = utils.ParseDate(from)
+			if err != nil {
+				utils.Error("%v", err)
+				return
+			}
+		}
+
+		sum := sha256.Sum256(content)
+		terms := &models.TermsDocument{
+			CompanyID:      company.ID,
+			Title:          strings.TrimSpace(title),
+			Version:        strings.TrimSpace(version),
+			DocumentType:   documentType,
+			ClientCategory: clientCategory,
+			EffectiveFrom:  effectiveFrom,
+			Content:        content,
+			Pages:          pages,
+			SHA256:         hex.EncodeToString(sum[:]),
+		}
+
+		if err := database.CreateTermsDocument(terms); err != nil {
+			utils.Error("Erreur lors de l'enregistrement des CGV: %v", err)
+			return
+		}
+
+		utils.Success("CGV %s v%s enregistrées (ID: %d, %d page(s))", terms.Title, terms.Version, terms.ID, terms.Pages)
+		utils.Info("Documents: %s - Clients: %s - En vigueur à partir du %s",
+			getTermsDocumentTypeLabel(documentType),
+			getTermsCategoryLabel(clientCategory),
+			effectiveFrom.Format("02/01/2006"))
+	},
+}
+
+var cgvExportCmd = &cobra.Command{
+	Use:   "export [ID] [FICHIER.pdf]",
+	Short: "Extraire une version des CGV",
+	Long:  "Extraire le PDF exact d'une version des CGV, par exemple pour prouver la version remise à un client",
+	Args:  cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		id, err := strconv.Atoi(args[0])
+		if err != nil {
+			utils.Error("ID invalide: %v", err)
+			return
+		}
+
+		database, err := db.New(utils.GetDatabasePath())
+		if err != nil {
+			utils.Error("Erreur d'ouverture de la base: %v", err)
+			return
+		}
+		defer database.Close()
+
+		terms, err := database.GetTermsDocument(id)
+		if err != nil {
+			utils.Error("CGV non trouvées: %v", err)
+			return
+		}
+
+		if err := os.WriteFile(args[1], terms.Content, 0644); err != nil {
+			utils.Error("Erreur lors de l'écriture du fichier: %v", err)
+			return
+		}
+
+		utils.Success("CGV %s v%s exportées dans %s", terms.Title, terms.Version, args[1])
+		utils.Info("Empreinte SHA-256: %s", terms.SHA256)
+	},
+}
+
+var cgvDeleteCmd = &cobra.Command{
+	Use:   "delete [ID]",
+	Short: "Supprimer une version des CGV jamais utilisée",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		id, err := strconv.Atoi(args[0])
+		if err != nil {
+			utils.Error("ID invalide: %v", err)
+			return
+		}
+
+		database, err := db.New(utils.GetDatabasePath())
+		if err != nil {
+			utils.Error("Erreur d'ouverture de la base: %v", err)
+			return
+		}
+		defer database.Close()
+
+		terms, err := database.GetTermsDocument(id)
+		if err != nil {
+			utils.Error("CGV non trouvées: %v", err)
+			return
+		}
+
+		utils.Warning("CGV à supprimer: %s v%s", terms.Title, terms.Version)
+
+		confirm := promptui.Prompt{
+			Label:     i18n.T("Confirmer la suppression"),
+			IsConfirm: true,
+		}
+		result, _ := confirm.Run()
+
+		if result == "y" {
+			if err := database.DeleteTermsDocument(id); err != nil {
+				utils.Error("Suppression impossible: %v", err)
+				return
+			}
+			utils.Success("CGV supprimées")
+		} else {
+			utils.Info("Suppression annulée")
+		}
+	},
+}
+
+func parseTermsDocumentType(value string) (string, error) {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "", "all", "tous":
+		return "", nil
+	case "quote", "devis":
+		return models.DocumentQuote, nil
+	case "invoice", "facture":
+		return models.DocumentInvoice, nil
+	}
+	return "", fmt.Errorf(i18n.T("type de document invalide %q (quote, invoice ou all)"), value)
+}
+
+func parseTermsCategory(value string) (string, error) {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "", "all", "tous":
+		return "", nil
+	}
+	if category := parseClientCategory(value); category != "" {
+		return category, nil
+	}
+	return "", fmt.Errorf(i18n.T("catégorie de client invalide %q (b2b, b2c ou all)"), value)
+}
+
+func getTermsDocumentTypeLabel(documentType string) string {
+	switch documentType {
+	case models.DocumentQuote:
+		return i18n.T("Devis")
+	case models.DocumentInvoice:
+		return i18n.T("Factures")
+	default:
+		return i18n.T("Tous documents")
+	}
+}
+
+func getTermsCategoryLabel(category string) string {
+	if category == "" {
+		return i18n.T("Tous")
+	}
+	return getClientCategoryLabel(category)
+}
+
+// warnLegacyTermsFile signale un ancien fichier cgv.pdf du dossier courant, qui
+// n'est plus joint automatiquement depuis que les CGV sont enregistrées en base
+func warnLegacyTermsFile() {
+	if _, err := os.Stat("cgv.pdf"); err == nil {
+		utils.Warning("Le fichier cgv.pdf n'est plus joint automatiquement. Importez-le avec: outbil cgv add cgv.pdf --version 1")
+	}
+}
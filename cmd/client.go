@@ -1,11 +1,14 @@
 package cmd
 
 import (
+	"errors"
 	"fmt"
 	"outbil/db"
+	"outbil/i18n"
 	"outbil/models"
 	"outbil/utils"
 	"strconv"
+	"strings"
 
 	"github.com/manifoldco/promptui"
 	"github.com/spf13/cobra"
@@ -18,6 +21,11 @@ func init() {
 	clientCmd.AddCommand(clientEditCmd)
 	clientCmd.AddCommand(clientDeleteCmd)
 	clientCmd.AddCommand(clientShowCmd)
+	clientCmd.AddCommand(clientArchiveCmd)
+	clientCmd.AddCommand(clientUnarchiveCmd)
+
+	clientListCmd.Flags().BoolP("all", "a", false, "Inclure les clients archivés")
+	clientDeleteCmd.Flags().Bool("cascade", false, "Supprimer aussi les devis du client")
 }
 
 var clientCmd = &cobra.Command{
@@ -37,7 +45,8 @@ var clientListCmd = &cobra.Command{
 		}
 		defer database.Close()
 
-		clients, err := database.ListClients()
+		includeArchived, _ := cmd.Flags().GetBool("all")
+		clients, err := database.ListClients(includeArchived)
 		if err != nil {
 			utils.Error("Erreur lors de la récupération des clients: %v", err)
 			return
@@ -49,17 +58,29 @@ var clientListCmd = &cobra.Command{
 		}
 
 		table := utils.CreateTable()
-		table.Header("ID", "Nom", "Entreprise", "Email", "Téléphone", "Ville")
+		if includeArchived {
+			table.Header("ID", i18n.T("Nom"), i18n.T("Entreprise"), "Email", i18n.T("Téléphone"), i18n.T("Ville"), i18n.T("Statut"))
+		} else {
+			table.Header("ID", i18n.T("Nom"), i18n.T("Entreprise"), "Email", i18n.T("Téléphone"), i18n.T("Ville"))
+		}
 
 		for _, client := range clients {
-			table.Append([]string{
+			row := []string{
 				strconv.Itoa(client.ID),
 				client.Name,
 				client.Company,
 				client.Email,
 				client.Phone,
 				client.City,
-			})
+			}
+			if includeArchived {
+				if client.ArchivedAt != nil {
+					row = append(row, i18n.T("📦 Archivé"))
+				} else {
+					row = append(row, i18n.T("Actif"))
+				}
+			}
+			table.Append(row)
 		}
 
 		table.Render()
@@ -80,42 +101,48 @@ var clientAddCmd = &cobra.Command{
 		client := &models.Client{}
 
 		prompt := promptui.Prompt{
-			Label: "Nom du client",
+			Label: i18n.T("Nom du client"),
 			Validate: func(input string) error {
 				if len(input) < 2 {
-					return fmt.Errorf("le nom doit contenir au moins 2 caractères")
+					return errors.New(i18n.T("le nom doit contenir au moins 2 caractères"))
 				}
 				return nil
 			},
 		}
 		client.Name, _ = prompt.Run()
 
-		prompt = promptui.Prompt{Label: "Entreprise"}
+		prompt = promptui.Prompt{Label: i18n.T("Entreprise")}
 		client.Company, _ = prompt.Run()
 
-		prompt = promptui.Prompt{Label: "Email"}
+		prompt = promptui.Prompt{Label: i18n.T("Email")}
 		client.Email, _ = prompt.Run()
 
-		prompt = promptui.Prompt{Label: "Téléphone"}
+		prompt = promptui.Prompt{Label: i18n.T("Téléphone")}
 		client.Phone, _ = prompt.Run()
 
-		prompt = promptui.Prompt{Label: "Adresse"}
+		prompt = promptui.Prompt{Label: i18n.T("Adresse")}
 		client.Address, _ = prompt.Run()
 
-		prompt = promptui.Prompt{Label: "Ville"}
+		prompt = promptui.Prompt{Label: i18n.T("Ville")}
 		client.City, _ = prompt.Run()
 
-		prompt = promptui.Prompt{Label: "Code postal"}
+		prompt = promptui.Prompt{Label: i18n.T("Code postal")}
 		client.PostalCode, _ = prompt.Run()
 
-		prompt = promptui.Prompt{Label: "Pays", Default: "France"}
+		prompt = promptui.Prompt{Label: i18n.T("Pays"), Default: "France"}
 		client.Country, _ = prompt.Run()
 
-		prompt = promptui.Prompt{Label: "Numéro TVA"}
-		client.TaxID, _ = prompt.Run()
+		promptClientIdentifiers(client)
+		client.Category = promptClientCategory(defaultClientCategory(client))
+		client.Currency = promptClientCurrency(client.Currency)
+		client.Language = promptDocumentLanguage("Langue des documents", client.Language, "Langue de l'entreprise")
+		if err := utils.ValidateFrenchIdentifiers(client.SIREN, client.SIRET, client.TaxID); err != nil {
+			utils.Error("Identifiants invalides: %v", err)
+			return
+		}
 
 		confirm := promptui.Prompt{
-			Label:     "Confirmer la création du client",
+			Label:     i18n.T("Confirmer la création du client"),
 			IsConfirm: true,
 		}
 		result, _ := confirm.Run()
@@ -158,61 +185,64 @@ var clientEditCmd = &cobra.Command{
 		}
 
 		prompt := promptui.Prompt{
-			Label:   "Nom du client",
+			Label:   i18n.T("Nom du client"),
 			Default: client.Name,
 		}
 		client.Name, _ = prompt.Run()
 
 		prompt = promptui.Prompt{
-			Label:   "Entreprise",
+			Label:   i18n.T("Entreprise"),
 			Default: client.Company,
 		}
 		client.Company, _ = prompt.Run()
 
 		prompt = promptui.Prompt{
-			Label:   "Email",
+			Label:   i18n.T("Email"),
 			Default: client.Email,
 		}
 		client.Email, _ = prompt.Run()
 
 		prompt = promptui.Prompt{
-			Label:   "Téléphone",
+			Label:   i18n.T("Téléphone"),
 			Default: client.Phone,
 		}
 		client.Phone, _ = prompt.Run()
 
 		prompt = promptui.Prompt{
-			Label:   "Adresse",
+			Label:   i18n.T("Adresse"),
 			Default: client.Address,
 		}
 		client.Address, _ = prompt.Run()
 
 		prompt = promptui.Prompt{
-			Label:   "Ville",
+			Label:   i18n.T("Ville"),
 			Default: client.City,
 		}
 		client.City, _ = prompt.Run()
 
 		prompt = promptui.Prompt{
-			Label:   "Code postal",
+			Label:   i18n.T("Code postal"),
 			Default: client.PostalCode,
 		}
 		client.PostalCode, _ = prompt.Run()
 
 		prompt = promptui.Prompt{
-			Label:   "Pays",
+			Label:   i18n.T("Pays"),
 			Default: client.Country,
 		}
 		client.Country, _ = prompt.Run()
 
-		prompt = promptui.Prompt{
-			Label:   "Numéro TVA",
-			Default: client.TaxID,
+		promptClientIdentifiers(client)
+		client.Category = promptClientCategory(client.Category)
+		client.Currency = promptClientCurrency(client.Currency)
+		client.Language = promptDocumentLanguage("Langue des documents", client.Language, "Langue de l'entreprise")
+		if err := utils.ValidateFrenchIdentifiers(client.SIREN, client.SIRET, client.TaxID); err != nil {
+			utils.Error("Identifiants invalides: %v", err)
+			return
 		}
-		client.TaxID, _ = prompt.Run()
 
 		confirm := promptui.Prompt{
-			Label:     "Confirmer les modifications",
+			Label:     i18n.T("Confirmer les modifications"),
 			IsConfirm: true,
 		}
 		result, _ := confirm.Run()
@@ -254,16 +284,34 @@ var clientDeleteCmd = &cobra.Command{
 			return
 		}
 
+		cascade, _ := cmd.Flags().GetBool("cascade")
+
+		quoteCount, err := database.CountClientQuotes(id)
+		if err != nil {
+			utils.Error("Erreur lors de la vérification des devis: %v", err)
+			return
+		}
+
+		if quoteCount > 0 && !cascade {
+			utils.Error("Impossible de supprimer %s : le client possède %d devis", client.Name, quoteCount)
+			utils.Info("Archivez-le pour le masquer sans perdre l'historique: outbil client archive %d", id)
+			utils.Info("Ou supprimez-le avec ses devis: outbil client delete %d --cascade", id)
+			return
+		}
+
 		utils.Warning("Client à supprimer: %s (%s)", client.Name, client.Company)
+		if quoteCount > 0 {
+			utils.Warning("Ses %d devis seront également supprimés définitivement!", quoteCount)
+		}
 		
 		confirm := promptui.Prompt{
-			Label:     "Confirmer la suppression",
+			Label:     i18n.T("Confirmer la suppression"),
 			IsConfirm: true,
 		}
 		result, _ := confirm.Run()
 
 		if result == "y" {
-			err = database.DeleteClient(id)
+			err = database.DeleteClient(id, cascade)
 			if err != nil {
 				utils.Error("Erreur lors de la suppression: %v", err)
 				return
@@ -299,18 +347,301 @@ var clientShowCmd = &cobra.Command{
 			return
 		}
 
-		fmt.Printf("\n--- Détails du client ---\n")
-		fmt.Printf("ID:         %d\n", client.ID)
-		fmt.Printf("Nom:        %s\n", client.Name)
-		fmt.Printf("Entreprise: %s\n", client.Company)
-		fmt.Printf("Email:      %s\n", client.Email)
-		fmt.Printf("Téléphone:  %s\n", client.Phone)
-		fmt.Printf("Adresse:    %s\n", client.Address)
-		fmt.Printf("Ville:      %s\n", client.City)
-		fmt.Printf("Code postal: %s\n", client.PostalCode)
-		fmt.Printf("Pays:       %s\n", client.Country)
-		fmt.Printf("N° TVA:     %s\n", client.TaxID)
-		fmt.Printf("Créé le:    %s\n", client.CreatedAt.Format("02/01/2006"))
-		fmt.Printf("Modifié le: %s\n", client.UpdatedAt.Format("02/01/2006"))
+		fmt.Print(i18n.T("\n--- Détails du client ---\n"))
+		fmt.Printf(i18n.T("ID:         %d\n"), client.ID)
+		fmt.Printf(i18n.T("Nom:        %s\n"), client.Name)
+		fmt.Printf(i18n.T("Entreprise: %s\n"), client.Company)
+		fmt.Printf(i18n.T("Email:      %s\n"), client.Email)
+		fmt.Printf(i18n.T("Téléphone:  %s\n"), client.Phone)
+		fmt.Printf(i18n.T("Adresse:    %s\n"), client.Address)
+		fmt.Printf(i18n.T("Ville:      %s\n"), client.City)
+		fmt.Printf(i18n.T("Code postal: %s\n"), client.PostalCode)
+		fmt.Printf(i18n.T("Pays:       %s\n"), client.Country)
+		fmt.Printf(i18n.T("N° TVA:     %s\n"), client.TaxID)
+		fmt.Printf(i18n.T("Catégorie:  %s\n"), getClientCategoryLabel(client.Category))
+		if client.Currency != "" {
+			fmt.Printf(i18n.T("Devise:     %s\n"), client.Currency)
+		}
+		if client.Language != "" {
+			fmt.Printf(i18n.T("Langue:     %s\n"), i18n.LanguageName(client.Language))
+		}
+		if client.SIREN != "" {
+			fmt.Printf(i18n.T("SIREN:      %s\n"), client.SIREN)
+		}
+		if client.SIRET != "" {
+			fmt.Printf(i18n.T("SIRET:      %s\n"), client.SIRET)
+		}
+		fmt.Printf(i18n.T("Créé le:    %s\n"), client.CreatedAt.Format("02/01/2006"))
+		fmt.Printf(i18n.T("Modifié le: %s\n"), client.UpdatedAt.Format("02/01/2006"))
+		if client.ArchivedAt != nil {
+			fmt.Printf(i18n.T("Archivé le: %s\n"), client.ArchivedAt.Format("02/01/2006"))
+		}
+
+		if len(client.Contacts) > 0 {
+			fmt.Print(i18n.T("\n--- Contacts ---\n"))
+			for _, contact := range client.Contacts {
+				fmt.Printf("[%d] %s", contact.ID, contact.Name)
+				if contact.Role != "" {
+					fmt.Printf(" (%s)", contact.Role)
+				}
+				fmt.Printf(" - %s %s\n", contact.Email, contact.Phone)
+			}
+		}
+
+		if len(client.Addresses) > 0 {
+			fmt.Print(i18n.T("\n--- Adresses ---\n"))
+			for i, address := range client.Addresses {
+				fmt.Printf("[%d] %s: %s\n", address.ID, getAddressTypeLabel(address.Type), formatAddress(&client.Addresses[i]))
+			}
+		}
+
+		merges, err := database.ListClientMerges(client.ID)
+		if err != nil {
+			utils.Error("Erreur lors de la récupération des fusions: %v", err)
+			return
+		}
+		if len(merges) > 0 {
+			fmt.Print(i18n.T("\n--- Clients fusionnés ---\n"))
+			for _, merge := range merges {
+				fmt.Printf(i18n.T("%s: #%d %s (%d devis rattachés)\n"),
+					merge.MergedAt.Format("02/01/2006"), merge.SourceClientID, merge.SourceName, merge.QuotesMoved)
+			}
+		}
+	},
+}
+
+var clientArchiveCmd = &cobra.Command{
+	Use:   "archive [ID]",
+	Short: "Archiver un client",
+	Long:  "Masquer un client des listes et sélections sans supprimer son historique de devis",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		id, err := strconv.Atoi(args[0])
+		if err != nil {
+			utils.Error("ID invalide: %v", err)
+			return
+		}
+
+		database, err := db.New(utils.GetDatabasePath())
+		if err != nil {
+			utils.Error("Erreur d'ouverture de la base: %v", err)
+			return
+		}
+		defer database.Close()
+
+		client, err := database.GetClient(id)
+		if err != nil {
+			utils.Error("Client non trouvé: %v", err)
+			return
+		}
+
+		if client.ArchivedAt != nil {
+			utils.Info("Le client %s est déjà archivé", client.Name)
+			return
+		}
+
+		err = database.ArchiveClient(id)
+		if err != nil {
+			utils.Error("Erreur lors de l'archivage: %v", err)
+			return
+		}
+
+		utils.Success("Client %s archivé", client.Name)
 	},
-}
\ No newline at end of file
+}
+
+var clientUnarchiveCmd = &cobra.Command{
+	Use:   "unarchive [ID]",
+	Short: "Réactiver un client archivé",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		id, err := strconv.Atoi(args[0])
+		if err != nil {
+			utils.Error("ID invalide: %v", err)
+			return
+		}
+
+		database, err := db.New(utils.GetDatabasePath())
+		if err != nil {
+			utils.Error("Erreur d'ouverture de la base: %v", err)
+			return
+		}
+		defer database.Close()
+
+		client, err := database.GetClient(id)
+		if err != nil {
+			utils.Error("Client non trouvé: %v", err)
+			return
+		}
+
+		if client.ArchivedAt == nil {
+			utils.Info("Le client %s n'est pas archivé", client.Name)
+			return
+		}
+
+		err = database.UnarchiveClient(id)
+		if err != nil {
+			utils.Error("Erreur lors de la réactivation: %v", err)
+			return
+		}
+
+		utils.Success("Client %s réactivé", client.Name)
+	},
+}
+
+// promptClientIdentifiers demande SIREN, SIRET et numéro de TVA en les validant
+// au fil de la saisie. Le numéro de TVA français est proposé à partir du SIREN.
+func promptClientIdentifiers(client *models.Client) {
+	prompt := promptui.Prompt{
+		Label:    i18n.T("SIREN (optionnel)"),
+		Default:  client.SIREN,
+		Validate: optionalIdentifier(utils.ValidateSIREN),
+	}
+	siren, _ := prompt.Run()
+	client.SIREN = utils.CompactIdentifier(siren)
+
+	prompt = promptui.Prompt{
+		Label:   i18n.T("SIRET (optionnel)"),
+		Default: client.SIRET,
+		Validate: optionalIdentifier(func(input string) error {
+			return utils.ValidateFrenchIdentifiers(client.SIREN, input, "")
+		}),
+	}
+	siret, _ := prompt.Run()
+	client.SIRET = utils.CompactIdentifier(siret)
+	if client.SIREN == "" && client.SIRET != "" {
+		client.SIREN = client.SIRET[:9]
+	}
+
+	prompt = promptui.Prompt{
+		Label:   i18n.T("Numéro TVA"),
+		Default: suggestedVATNumber(client.TaxID, client.Country, client.SIREN),
+		Validate: optionalIdentifier(func(input string) error {
+			return utils.ValidateFrenchIdentifiers(client.SIREN, "", input)
+		}),
+	}
+	taxID, _ := prompt.Run()
+	client.TaxID = utils.CompactIdentifier(taxID)
+}
+
+// optionalIdentifier adapte une fonction de validation à un champ facultatif
+func optionalIdentifier(validate func(string) error) promptui.ValidateFunc {
+	return func(input string) error {
+		if strings.TrimSpace(input) == "" {
+			return nil
+		}
+		return validate(input)
+	}
+}
+
+// suggestedVATNumber retourne le numéro de TVA actuel ou, à défaut, celui
+// déduit du SIREN pour une entreprise française
+func suggestedVATNumber(current, country, siren string) string {
+	if current != "" || siren == "" {
+		return current
+	}
+	if country != "" && !strings.EqualFold(country, "France") && !strings.EqualFold(country, "FR") {
+		return current
+	}
+	vat, err := utils.FrenchVATNumber(siren)
+	if err != nil {
+		return current
+	}
+	return vat
+}
+
+// defaultClientCategory propose B2B pour un client identifié comme entreprise
+// (raison sociale, SIREN ou TVA), B2C sinon
+func defaultClientCategory(client *models.Client) string {
+	if client.Company != "" || client.SIREN != "" || client.TaxID != "" {
+		return models.ClientB2B
+	}
+	return models.ClientB2C
+}
+
+func promptClientCategory(current string) string {
+	categories := []string{models.ClientB2B, models.ClientB2C}
+	cursor := 0
+	if current == models.ClientB2C {
+		cursor = 1
+	}
+
+	sel := promptui.Select{
+		Label:     i18n.T("Catégorie de client"),
+		Items:     []string{getClientCategoryLabel(models.ClientB2B), getClientCategoryLabel(models.ClientB2C)},
+		CursorPos: cursor,
+	}
+	idx, _, err := sel.Run()
+	if err != nil {
+		return categories[cursor]
+	}
+	return categories[idx]
+}
+
+func getClientCategoryLabel(category string) string {
+	switch category {
+	case models.ClientB2C:
+		return i18n.T("Particulier (B2C)")
+	default:
+		return i18n.T("Professionnel (B2B)")
+	}
+}
+
+// promptClientCurrency demande la devise de facturation du client. Vide, les
+// devis du client sont établis dans la devise de l'entreprise.
+func promptClientCurrency(current string) string {
+	prompt := promptui.Prompt{
+		Label:   i18n.T("Devise (code ISO, vide = devise de l'entreprise)"),
+		Default: current,
+		Validate: func(input string) error {
+			if strings.TrimSpace(input) == "" {
+				return nil
+			}
+			return utils.ValidateCurrency(input)
+		},
+	}
+	result, err := prompt.Run()
+	if err != nil {
+		return current
+	}
+	if strings.TrimSpace(result) == "" {
+		return ""
+	}
+	return utils.NormalizeCurrency(result)
+}
+
+// promptDocumentLanguage demande la langue des documents. Le premier choix
+// (valeur vide) laisse la langue par défaut décrite par inheritLabel.
+func promptDocumentLanguage(label, current, inheritLabel string) string {
+	languages := append([]string{""}, i18n.Languages()...)
+	items := []string{i18n.T(inheritLabel)}
+	cursor := 0
+	for i, language := range languages[1:] {
+		items = append(items, i18n.LanguageName(language))
+		if language == i18n.NormalizeLanguage(current) {
+			cursor = i + 1
+		}
+	}
+
+	sel := promptui.Select{
+		Label:     i18n.T(label),
+		Items:     items,
+		CursorPos: cursor,
+	}
+	idx, _, err := sel.Run()
+	if err != nil {
+		return languages[cursor]
+	}
+	return languages[idx]
+}
+
+// parseClientCategory reconnaît b2b/b2c et leurs équivalents français
+func parseClientCategory(value string) string {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "b2b", "pro", "professionnel", "entreprise":
+		return models.ClientB2B
+	case "b2c", "particulier", "individual":
+		return models.ClientB2C
+	}
+	return ""
+}
@@ -0,0 +1,498 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"outbil/db"
+	"outbil/i18n"
+	"outbil/models"
+	"outbil/utils"
+	"strconv"
+
+	"github.com/manifoldco/promptui"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	clientCmd.AddCommand(clientContactCmd)
+	clientContactCmd.AddCommand(clientContactListCmd)
+	clientContactCmd.AddCommand(clientContactAddCmd)
+	clientContactCmd.AddCommand(clientContactEditCmd)
+	clientContactCmd.AddCommand(clientContactDeleteCmd)
+
+	clientCmd.AddCommand(clientAddressCmd)
+	clientAddressCmd.AddCommand(clientAddressListCmd)
+	clientAddressCmd.AddCommand(clientAddressAddCmd)
+	clientAddressCmd.AddCommand(clientAddressEditCmd)
+	clientAddressCmd.AddCommand(clientAddressDeleteCmd)
+}
+
+var clientContactCmd = &cobra.Command{
+	Use:   "contact",
+	Short: "Gérer les contacts d'un client",
+	Long:  `Commandes pour gérer les interlocuteurs (nom, fonction, email, téléphone) d'un client`,
+}
+
+var clientContactListCmd = &cobra.Command{
+	Use:   "list [CLIENT_ID]",
+	Short: "Lister les contacts d'un client",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		clientID, err := strconv.Atoi(args[0])
+		if err != nil {
+			utils.Error("ID invalide: %v", err)
+			return
+		}
+
+		database, err := db.New(utils.GetDatabasePath())
+		if err != nil {
+			utils.Error("Erreur d'ouverture de la base: %v", err)
+			return
+		}
+		defer database.Close()
+
+		contacts, err := database.ListClientContacts(clientID)
+		if err != nil {
+			utils.Error("Erreur lors de la récupération des contacts: %v", err)
+			return
+		}
+
+		if len(contacts) == 0 {
+			utils.Info("Aucun contact trouvé")
+			return
+		}
+
+		table := utils.CreateTable()
+		table.Header("ID", i18n.T("Nom"), i18n.T("Fonction"), "Email", i18n.T("Téléphone"))
+
+		for _, contact := range contacts {
+			table.Append([]string{
+				strconv.Itoa(contact.ID),
+				contact.Name,
+				contact.Role,
+				contact.Email,
+				contact.Phone,
+			})
+		}
+
+		table.Render()
+	},
+}
+
+var clientContactAddCmd = &cobra.Command{
+	Use:   "add [CLIENT_ID]",
+	Short: "Ajouter un contact à un client",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		clientID, err := strconv.Atoi(args[0])
+		if err != nil {
+			utils.Error("ID invalide: %v", err)
+			return
+		}
+
+		database, err := db.New(utils.GetDatabasePath())
+		if err != nil {
+			utils.Error("Erreur d'ouverture de la base: %v", err)
+			return
+		}
+		defer database.Close()
+
+		client, err := database.GetClient(clientID)
+		if err != nil {
+			utils.Error("Client non trouvé: %v", err)
+			return
+		}
+
+		utils.Info("Nouveau contact pour %s", client.Name)
+
+		contact := &models.ClientContact{ClientID: client.ID}
+		promptContact(contact)
+
+		confirm := promptui.Prompt{
+			Label:     i18n.T("Confirmer la création du contact"),
+			IsConfirm: true,
+		}
+		result, _ := confirm.Run()
+
+		if result == "y" {
+			err = database.CreateClientContact(contact)
+			if err != nil {
+				utils.Error("Erreur lors de la création du contact: %v", err)
+				return
+			}
+			utils.Success("Contact créé avec succès (ID: %d)", contact.ID)
+		} else {
+			utils.Info("Création annulée")
+		}
+	},
+}
+
+var clientContactEditCmd = &cobra.Command{
+	Use:   "edit [CONTACT_ID]",
+	Short: "Modifier un contact",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		id, err := strconv.Atoi(args[0])
+		if err != nil {
+			utils.Error("ID invalide: %v", err)
+			return
+		}
+
+		database, err := db.New(utils.GetDatabasePath())
+		if err != nil {
+			utils.Error("Erreur d'ouverture de la base: %v", err)
+			return
+		}
+		defer database.Close()
+
+		contact, err := database.GetClientContact(id)
+		if err != nil {
+			utils.Error("Contact non trouvé: %v", err)
+			return
+		}
+
+		promptContact(contact)
+
+		confirm := promptui.Prompt{
+			Label:     i18n.T("Confirmer les modifications"),
+			IsConfirm: true,
+		}
+		result, _ := confirm.Run()
+
+		if result == "y" {
+			err = database.UpdateClientContact(contact)
+			if err != nil {
+				utils.Error("Erreur lors de la mise à jour: %v", err)
+				return
+			}
+			utils.Success("Contact mis à jour avec succès")
+		} else {
+			utils.Info("Modifications annulées")
+		}
+	},
+}
+
+var clientContactDeleteCmd = &cobra.Command{
+	Use:   "delete [CONTACT_ID]",
+	Short: "Supprimer un contact",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		id, err := strconv.Atoi(args[0])
+		if err != nil {
+			utils.Error("ID invalide: %v", err)
+			return
+		}
+
+		database, err := db.New(utils.GetDatabasePath())
+		if err != nil {
+			utils.Error("Erreur d'ouverture de la base: %v", err)
+			return
+		}
+		defer database.Close()
+
+		contact, err := database.GetClientContact(id)
+		if err != nil {
+			utils.Error("Contact non trouvé: %v", err)
+			return
+		}
+
+		utils.Warning("Contact à supprimer: %s (%s)", contact.Name, contact.Role)
+
+		confirm := promptui.Prompt{
+			Label:     i18n.T("Confirmer la suppression"),
+			IsConfirm: true,
+		}
+		result, _ := confirm.Run()
+
+		if result == "y" {
+			err = database.DeleteClientContact(id)
+			if err != nil {
+				utils.Error("Erreur lors de la suppression: %v", err)
+				return
+			}
+			utils.Success("Contact supprimé avec succès")
+		} else {
+			utils.Info("Suppression annulée")
+		}
+	},
+}
+
+var clientAddressCmd = &cobra.Command{
+	Use:   "address",
+	Short: "Gérer les adresses d'un client",
+	Long:  `Commandes pour gérer les adresses de facturation et de livraison d'un client`,
+}
+
+var clientAddressListCmd = &cobra.Command{
+	Use:   "list [CLIENT_ID]",
+	Short: "Lister les adresses d'un client",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		clientID, err := strconv.Atoi(args[0])
+		if err != nil {
+			utils.Error("ID invalide: %v", err)
+			return
+		}
+
+		database, err := db.New(utils.GetDatabasePath())
+		if err != nil {
+			utils.Error("Erreur d'ouverture de la base: %v", err)
+			return
+		}
+		defer database.Close()
+
+		addresses, err := database.ListClientAddresses(clientID, "")
+		if err != nil {
+			utils.Error("Erreur lors de la récupération des adresses: %v", err)
+			return
+		}
+
+		if len(addresses) == 0 {
+			utils.Info("Aucune adresse trouvée")
+			return
+		}
+
+		table := utils.CreateTable()
+		table.Header("ID", "Type", i18n.T("Libellé"), i18n.T("Adresse"), i18n.T("Code postal"), i18n.T("Ville"), i18n.T("Pays"))
+
+		for _, address := range addresses {
+			table.Append([]string{
+				strconv.Itoa(address.ID),
+				getAddressTypeLabel(address.Type),
+				address.Label,
+				address.Address,
+				address.PostalCode,
+				address.City,
+				address.Country,
+			})
+		}
+
+		table.Render()
+	},
+}
+
+var clientAddressAddCmd = &cobra.Command{
+	Use:   "add [CLIENT_ID]",
+	Short: "Ajouter une adresse à un client",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		clientID, err := strconv.Atoi(args[0])
+		if err != nil {
+			utils.Error("ID invalide: %v", err)
+			return
+		}
+
+		database, err := db.New(utils.GetDatabasePath())
+		if err != nil {
+			utils.Error("Erreur d'ouverture de la base: %v", err)
+			return
+		}
+		defer database.Close()
+
+		client, err := database.GetClient(clientID)
+		if err != nil {
+			utils.Error("Client non trouvé: %v", err)
+			return
+		}
+
+		utils.Info("Nouvelle adresse pour %s", client.Name)
+
+		address := &models.ClientAddress{
+			ClientID: client.ID,
+			Type:     models.AddressBilling,
+			Country:  client.Country,
+		}
+		if err := promptAddress(address); err != nil {
+			return
+		}
+
+		confirm := promptui.Prompt{
+			Label:     i18n.T("Confirmer la création de l'adresse"),
+			IsConfirm: true,
+		}
+		result, _ := confirm.Run()
+
+		if result == "y" {
+			err = database.CreateClientAddress(address)
+			if err != nil {
+				utils.Error("Erreur lors de la création de l'adresse: %v", err)
+				return
+			}
+			utils.Success("Adresse créée avec succès (ID: %d)", address.ID)
+		} else {
+			utils.Info("Création annulée")
+		}
+	},
+}
+
+var clientAddressEditCmd = &cobra.Command{
+	Use:   "edit [ADDRESS_ID]",
+	Short: "Modifier une adresse",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		id, err := strconv.Atoi(args[0])
+		if err != nil {
+			utils.Error("ID invalide: %v", err)
+			return
+		}
+
+		database, err := db.New(utils.GetDatabasePath())
+		if err != nil {
+			utils.Error("Erreur d'ouverture de la base: %v", err)
+			return
+		}
+		defer database.Close()
+
+		address, err := database.GetClientAddress(id)
+		if err != nil {
+			utils.Error("Adresse non trouvée: %v", err)
+			return
+		}
+
+		if err := promptAddress(address); err != nil {
+			return
+		}
+
+		confirm := promptui.Prompt{
+			Label:     i18n.T("Confirmer les modifications"),
+			IsConfirm: true,
+		}
+		result, _ := confirm.Run()
+
+		if result == "y" {
+			err = database.UpdateClientAddress(address)
+			if err != nil {
+				utils.Error("Erreur lors de la mise à jour: %v", err)
+				return
+			}
+			utils.Success("Adresse mise à jour avec succès")
+		} else {
+			utils.Info("Modifications annulées")
+		}
+	},
+}
+
+var clientAddressDeleteCmd = &cobra.Command{
+	Use:   "delete [ADDRESS_ID]",
+	Short: "Supprimer une adresse",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		id, err := strconv.Atoi(args[0])
+		if err != nil {
+			utils.Error("ID invalide: %v", err)
+			return
+		}
+
+		database, err := db.New(utils.GetDatabasePath())
+		if err != nil {
+			utils.Error("Erreur d'ouverture de la base: %v", err)
+			return
+		}
+		defer database.Close()
+
+		address, err := database.GetClientAddress(id)
+		if err != nil {
+			utils.Error("Adresse non trouvée: %v", err)
+			return
+		}
+
+		utils.Warning("Adresse à supprimer: %s", formatAddress(address))
+
+		confirm := promptui.Prompt{
+			Label:     i18n.T("Confirmer la suppression"),
+			IsConfirm: true,
+		}
+		result, _ := confirm.Run()
+
+		if result == "y" {
+			err = database.DeleteClientAddress(id)
+			if err != nil {
+				utils.Error("Erreur lors de la suppression: %v", err)
+				return
+			}
+			utils.Success("Adresse supprimée avec succès")
+		} else {
+			utils.Info("Suppression annulée")
+		}
+	},
+}
+
+func promptContact(contact *models.ClientContact) {
+	prompt := promptui.Prompt{
+		Label:   i18n.T("Nom du contact"),
+		Default: contact.Name,
+		Validate: func(input string) error {
+			if len(input) < 2 {
+				return errors.New(i18n.T("le nom doit contenir au moins 2 caractères"))
+			}
+			return nil
+		},
+	}
+	contact.Name, _ = prompt.Run()
+
+	prompt = promptui.Prompt{Label: i18n.T("Fonction"), Default: contact.Role}
+	contact.Role, _ = prompt.Run()
+
+	prompt = promptui.Prompt{Label: i18n.T("Email"), Default: contact.Email}
+	contact.Email, _ = prompt.Run()
+
+	prompt = promptui.Prompt{Label: i18n.T("Téléphone"), Default: contact.Phone}
+	contact.Phone, _ = prompt.Run()
+}
+
+func promptAddress(address *models.ClientAddress) error {
+	types := []string{models.AddressBilling, models.AddressDelivery}
+	typeLabels := []string{getAddressTypeLabel(models.AddressBilling), getAddressTypeLabel(models.AddressDelivery)}
+
+	cursor := 0
+	if address.Type == models.AddressDelivery {
+		cursor = 1
+	}
+
+	typePrompt := promptui.Select{
+		Label:     i18n.T("Type d'adresse"),
+		Items:     typeLabels,
+		CursorPos: cursor,
+	}
+	index, _, err := typePrompt.Run()
+	if err != nil {
+		return err
+	}
+	address.Type = types[index]
+
+	prompt := promptui.Prompt{Label: i18n.T("Libellé (ex: Siège, Entrepôt)"), Default: address.Label}
+	address.Label, _ = prompt.Run()
+
+	prompt = promptui.Prompt{Label: i18n.T("Adresse"), Default: address.Address}
+	address.Address, _ = prompt.Run()
+
+	prompt = promptui.Prompt{Label: i18n.T("Ville"), Default: address.City}
+	address.City, _ = prompt.Run()
+
+	prompt = promptui.Prompt{Label: i18n.T("Code postal"), Default: address.PostalCode}
+	address.PostalCode, _ = prompt.Run()
+
+	prompt = promptui.Prompt{Label: i18n.T("Pays"), Default: address.Country}
+	address.Country, _ = prompt.Run()
+
+	return nil
+}
+
+func getAddressTypeLabel(addressType string) string {
+	switch addressType {
+	case models.AddressBilling:
+		return i18n.T("Facturation")
+	case models.AddressDelivery:
+		return i18n.T("Livraison")
+	default:
+		return addressType
+	}
+}
+
+func formatAddress(address *models.ClientAddress) string {
+	label := fmt.Sprintf("%s %s %s", address.Address, address.PostalCode, address.City)
+	if address.Label != "" {
+		label = fmt.Sprintf("%s - %s", address.Label, label)
+	}
+	return label
+}
@@ -0,0 +1,750 @@
+package cmd
+
+import (
+	"bufio"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"outbil/db"
+	"outbil/i18n"
+	"outbil/models"
+	"outbil/utils"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	clientCmd.AddCommand(clientImportCmd)
+	clientCmd.AddCommand(clientExportCmd)
+
+	clientImportCmd.Flags().StringP("format", "f", "", "Format du fichier: csv ou vcard (déduit de l'extension par défaut)")
+	clientImportCmd.Flags().StringP("map", "m", "", "Correspondance des colonnes CSV, ex: \"name=Nom complet,email=Courriel\"")
+	clientImportCmd.Flags().String("delimiter", "", "Séparateur CSV (détecté automatiquement par défaut)")
+	clientImportCmd.Flags().Bool("dry-run", false, "Afficher le rapport d'import sans rien enregistrer")
+
+	clientExportCmd.Flags().StringP("format", "f", "", "Format du fichier: csv ou vcard (déduit de l'extension par défaut)")
+	clientExportCmd.Flags().Int("vcard-version", 3, "Version vCard à produire (3 ou 4)")
+	clientExportCmd.Flags().BoolP("all", "a", false, "Inclure les clients archivés")
+}
+
+// clientFields liste les champs importables/exportables d'un client, dans l'ordre des colonnes CSV
+var clientFields = []string{"name", "company", "email", "phone", "address", "city", "postal_code", "country", "tax_id", "siren", "siret", "category", "currency", "language"}
+
+// clientFieldAliases associe des en-têtes courants (CRM, tableurs) aux champs du client
+var clientFieldAliases = map[string]string{
+	"nom":                    "name",
+	"nom complet":            "name",
+	"full name":              "name",
+	"contact":                "name",
+	"entreprise":             "company",
+	"société":                "company",
+	"societe":                "company",
+	"raison sociale":         "company",
+	"organisation":           "company",
+	"organization":           "company",
+	"e-mail":                 "email",
+	"courriel":               "email",
+	"mail":                   "email",
+	"téléphone":              "phone",
+	"telephone":              "phone",
+	"tél":                    "phone",
+	"tel":                    "phone",
+	"adresse":                "address",
+	"street":                 "address",
+	"ville":                  "city",
+	"code postal":            "postal_code",
+	"cp":                     "postal_code",
+	"zip":                    "postal_code",
+	"postal code":            "postal_code",
+	"pays":                   "country",
+	"numéro tva":             "tax_id",
+	"numero tva":             "tax_id",
+	"n° tva":                 "tax_id",
+	"tva intracommunautaire": "tax_id",
+	"vat":                    "tax_id",
+	"vat number":             "tax_id",
+	"n° siren":               "siren",
+	"numéro siren":           "siren",
+	"n° siret":               "siret",
+	"numéro siret":           "siret",
+	"catégorie":              "category",
+	"categorie":              "category",
+	"type de client":         "category",
+	"devise":                 "currency",
+	"monnaie":                "currency",
+	"langue":                 "language",
+	"lang":                   "language",
+}
+
+var clientImportCmd = &cobra.Command{
+	Use:   "import [FICHIER]",
+	Short: "Importer des clients depuis un fichier CSV ou vCard",
+	Long: `Importer des clients depuis un fichier CSV (export de CRM, tableur) ou vCard 3/4 (contacts).
+
+Les clients dont l'email ou le numéro de TVA existe déjà sont signalés comme doublons
+et ne sont pas importés. Utilisez --dry-run pour consulter le rapport sans rien enregistrer.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		path := args[0]
+		format, _ := cmd.Flags().GetString("format")
+		mapping, _ := cmd.Flags().GetString("map")
+		delimiter, _ := cmd.Flags().GetString("delimiter")
+		dryRun, _ := cmd.Flags().GetBool("dry-run")
+
+		format, err := detectClientFileFormat(path, format)
+		if err != nil {
+			utils.Error("%v", err)
+			return
+		}
+
+		file, err := os.Open(path)
+		if err != nil {
+			utils.Error("Impossible d'ouvrir le fichier: %v", err)
+			return
+		}
+		defer file.Close()
+
+		var clients []models.Client
+		if format == "csv" {
+			clients, err = readClientsCSV(file, mapping, delimiter)
+		} else {
+			clients, err = readClientsVCard(file)
+		}
+		if err != nil {
+			utils.Error("Erreur de lecture du fichier: %v", err)
+			return
+		}
+
+		if len(clients) == 0 {
+			utils.Info("Aucun client trouvé dans le fichier")
+			return
+		}
+
+		database, err := db.New(utils.GetDatabasePath())
+		if err != nil {
+			utils.Error("Erreur d'ouverture de la base: %v", err)
+			return
+		}
+		defer database.Close()
+
+		existing, err := database.ListClients(true)
+		if err != nil {
+			utils.Error("Erreur lors de la récupération des clients: %v", err)
+			return
+		}
+
+		seenEmails := make(map[string]string)
+		seenTaxIDs := make(map[string]string)
+		for _, client := range existing {
+			if key := normalizeEmail(client.Email); key != "" {
+				seenEmails[key] = fmt.Sprintf("client #%d", client.ID)
+			}
+			if key := normalizeTaxID(client.TaxID); key != "" {
+				seenTaxIDs[key] = fmt.Sprintf("client #%d", client.ID)
+			}
+		}
+
+		if dryRun {
+			utils.Info("Simulation: aucun client ne sera enregistré")
+		}
+
+		table := utils.CreateTable()
+		table.Header("#", i18n.T("Nom"), i18n.T("Entreprise"), "Email", i18n.T("N° TVA"), i18n.T("Résultat"))
+
+		var created, skipped int
+		for i := range clients {
+			client := &clients[i]
+			result := ""
+
+			emailKey := normalizeEmail(client.Email)
+			taxIDKey := normalizeTaxID(client.TaxID)
+
+			switch {
+			case len(strings.TrimSpace(client.Name)) < 2:
+				result = i18n.T("ignoré: nom manquant")
+			case utils.ValidateFrenchIdentifiers(client.SIREN, client.SIRET, client.TaxID) != nil:
+				result = fmt.Sprintf(i18n.T("ignoré: %v"), utils.ValidateFrenchIdentifiers(client.SIREN, client.SIRET, client.TaxID))
+			case client.Currency != "" && utils.ValidateCurrency(client.Currency) != nil:
+				result = fmt.Sprintf(i18n.T("ignoré: %v"), utils.ValidateCurrency(client.Currency))
+			case client.Language != "" && i18n.ValidateLanguage(client.Language) != nil:
+				result = fmt.Sprintf(i18n.T("ignoré: %v"), i18n.ValidateLanguage(client.Language))
+			case emailKey != "" && seenEmails[emailKey] != "":
+				result = fmt.Sprintf(i18n.T("doublon (email, %s)"), seenEmails[emailKey])
+			case taxIDKey != "" && seenTaxIDs[taxIDKey] != "":
+				result = fmt.Sprintf(i18n.T("doublon (TVA, %s)"), seenTaxIDs[taxIDKey])
+			}
+
+			if result != "" {
+				skipped++
+			} else {
+				if client.Category == "" {
+					client.Category = defaultClientCategory(client)
+				}
+				if !dryRun {
+					if err := database.CreateClient(client); err != nil {
+						result = fmt.Sprintf(i18n.T("erreur: %v"), err)
+						skipped++
+						table.Append([]string{strconv.Itoa(i + 1), client.Name, client.Company, client.Email, client.TaxID, result})
+						continue
+					}
+					result = fmt.Sprintf(i18n.T("créé (ID: %d)"), client.ID)
+				} else {
+					result = i18n.T("à créer")
+				}
+				created++
+
+				origin := fmt.Sprintf(i18n.T("ligne %d du fichier"), i+1)
+				if emailKey != "" {
+					seenEmails[emailKey] = origin
+				}
+				if taxIDKey != "" {
+					seenTaxIDs[taxIDKey] = origin
+				}
+			}
+
+			table.Append([]string{strconv.Itoa(i + 1), client.Name, client.Company, client.Email, client.TaxID, result})
+		}
+
+		table.Render()
+
+		if dryRun {
+			utils.Info("%d client(s) seraient importés, %d ignoré(s)", created, skipped)
+		} else {
+			utils.Success("%d client(s) importé(s), %d ignoré(s)", created, skipped)
+		}
+	},
+}
+
+var clientExportCmd = &cobra.Command{
+	Use:   "export [FICHIER]",
+	Short: "Exporter les clients vers un fichier CSV ou vCard",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		path := args[0]
+		format, _ := cmd.Flags().GetString("format")
+		version, _ := cmd.Flags().GetInt("vcard-version")
+		includeArchived, _ := cmd.Flags().GetBool("all")
+
+		format, err := detectClientFileFormat(path, format)
+		if err != nil {
+			utils.Error("%v", err)
+			return
+		}
+		if format == "vcard" && version != 3 && version != 4 {
+			utils.Error("Version vCard non supportée: %d (3 ou 4)", version)
+			return
+		}
+
+		database, err := db.New(utils.GetDatabasePath())
+		if err != nil {
+			utils.Error("Erreur d'ouverture de la base: %v", err)
+			return
+		}
+		defer database.Close()
+
+		clients, err := database.ListClients(includeArchived)
+		if err != nil {
+			utils.Error("Erreur lors de la récupération des clients: %v", err)
+			return
+		}
+
+		if len(clients) == 0 {
+			utils.Info("Aucun client à exporter")
+			return
+		}
+
+		file, err := os.Create(path)
+		if err != nil {
+			utils.Error("Impossible de créer le fichier: %v", err)
+			return
+		}
+		defer file.Close()
+
+		if format == "csv" {
+			err = writeClientsCSV(file, clients)
+		} else {
+			err = writeClientsVCard(file, clients, version)
+		}
+		if err != nil {
+			utils.Error("Erreur lors de l'export: %v", err)
+			return
+		}
+
+		utils.Success("%d client(s) exporté(s) vers %s", len(clients), path)
+	},
+}
+
+func detectClientFileFormat(path, format string) (string, error) {
+	if format == "" {
+		switch strings.ToLower(filepath.Ext(path)) {
+		case ".csv":
+			format = "csv"
+		case ".vcf", ".vcard":
+			format = "vcard"
+		default:
+			return "", fmt.Errorf(i18n.T("format non reconnu pour %s, précisez --format csv ou --format vcard"), path)
+		}
+	}
+
+	switch strings.ToLower(format) {
+	case "csv":
+		return "csv", nil
+	case "vcard", "vcf":
+		return "vcard", nil
+	}
+	return "", fmt.Errorf(i18n.T("format non supporté: %s (csv ou vcard)"), format)
+}
+
+func normalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
+
+func normalizeTaxID(taxID string) string {
+	return strings.ToUpper(strings.Join(strings.Fields(taxID), ""))
+}
+
+// readClientsCSV lit un fichier CSV avec ligne d'en-tête. mapping surcharge la
+// détection automatique des colonnes (champ=en-tête, séparés par des virgules).
+func readClientsCSV(r io.Reader, mapping, delimiter string) ([]models.Client, error) {
+	reader := bufio.NewReader(r)
+
+	// Retirer l'éventuel BOM UTF-8 laissé par les tableurs
+	if bom, err := reader.Peek(3); err == nil && string(bom) == "\xEF\xBB\xBF" {
+		reader.Discard(3)
+	}
+
+	csvReader := csv.NewReader(reader)
+	csvReader.LazyQuotes = true
+	csvReader.FieldsPerRecord = -1
+	csvReader.TrimLeadingSpace = true
+
+	if delimiter != "" {
+		csvReader.Comma = []rune(delimiter)[0]
+	} else if firstLine, err := reader.Peek(reader.Buffered()); err == nil || len(firstLine) > 0 {
+		// Les exports de tableurs français utilisent souvent le point-virgule
+		header := string(firstLine)
+		if i := strings.IndexByte(header, '\n'); i >= 0 {
+			header = header[:i]
+		}
+		if strings.Count(header, ";") > strings.Count(header, ",") {
+			csvReader.Comma = ';'
+		}
+	}
+
+	header, err := csvReader.Read()
+	if err == io.EOF {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	columns, err := mapClientColumns(header, mapping)
+	if err != nil {
+		return nil, err
+	}
+
+	var clients []models.Client
+	for {
+		record, err := csvReader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		var client models.Client
+		empty := true
+		for field, index := range columns {
+			if index >= len(record) {
+				continue
+			}
+			value := strings.TrimSpace(record[index])
+			if value != "" {
+				empty = false
+			}
+			setClientField(&client, field, value)
+		}
+		if empty {
+			continue
+		}
+		if client.Name == "" {
+			client.Name = client.Company
+		}
+		clients = append(clients, client)
+	}
+
+	return clients, nil
+}
+
+// mapClientColumns retourne l'index de colonne de chaque champ client reconnu
+func mapClientColumns(header []string, mapping string) (map[string]int, error) {
+	positions := make(map[string]int)
+	for i, name := range header {
+		positions[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+
+	columns := make(map[string]int)
+	for name, index := range positions {
+		if isClientField(name) {
+			columns[name] = index
+		} else if field, ok := clientFieldAliases[name]; ok {
+			if _, exists := columns[field]; !exists {
+				columns[field] = index
+			}
+		}
+	}
+
+	if mapping != "" {
+		for _, pair := range strings.Split(mapping, ",") {
+			parts := strings.SplitN(pair, "=", 2)
+			if len(parts) != 2 {
+				return nil, fmt.Errorf(i18n.T("correspondance invalide: %q (attendu champ=colonne)"), pair)
+			}
+			field := strings.TrimSpace(parts[0])
+			column := strings.ToLower(strings.TrimSpace(parts[1]))
+			if !isClientField(field) {
+				return nil, fmt.Errorf(i18n.T("champ inconnu: %s (champs possibles: %s)"), field, strings.Join(clientFields, ", "))
+			}
+			index, ok := positions[column]
+			if !ok {
+				return nil, fmt.Errorf(i18n.T("colonne introuvable dans l'en-tête: %s"), parts[1])
+			}
+			columns[field] = index
+		}
+	}
+
+	if _, ok := columns["name"]; !ok {
+		if _, ok := columns["company"]; !ok {
+			return nil, errors.New(i18n.T("aucune colonne de nom trouvée, utilisez --map name=<colonne>"))
+		}
+	}
+
+	return columns, nil
+}
+
+func isClientField(name string) bool {
+	for _, field := range clientFields {
+		if field == name {
+			return true
+		}
+	}
+	return false
+}
+
+func setClientField(client *models.Client, field, value string) {
+	switch field {
+	case "name":
+		client.Name = value
+	case "company":
+		client.Company = value
+	case "email":
+		client.Email = value
+	case "phone":
+		client.Phone = value
+	case "address":
+		client.Address = value
+	case "city":
+		client.City = value
+	case "postal_code":
+		client.PostalCode = value
+	case "country":
+		client.Country = value
+	case "tax_id":
+		client.TaxID = utils.CompactIdentifier(value)
+	case "siren":
+		client.SIREN = utils.CompactIdentifier(value)
+	case "siret":
+		client.SIRET = utils.CompactIdentifier(value)
+		if client.SIREN == "" && len(client.SIRET) >= 9 {
+			client.SIREN = client.SIRET[:9]
+		}
+	case "category":
+		client.Category = parseClientCategory(value)
+	case "currency":
+		client.Currency = strings.ToUpper(strings.TrimSpace(value))
+	case "language":
+		client.Language = i18n.NormalizeLanguage(value)
+	}
+}
+
+func clientFieldValues(client *models.Client) []string {
+	return []string{
+		client.Name, client.Company, client.Email, client.Phone, client.Address,
+		client.City, client.PostalCode, client.Country, client.TaxID, client.SIREN, client.SIRET,
+		client.Category, client.Currency, client.Language,
+	}
+}
+
+func writeClientsCSV(w io.Writer, clients []models.Client) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(clientFields); err != nil {
+		return err
+	}
+	for i := range clients {
+		if err := writer.Write(clientFieldValues(&clients[i])); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+// readClientsVCard lit les fiches d'un fichier vCard 3.0 ou 4.0
+func readClientsVCard(r io.Reader) ([]models.Client, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+
+	// Déplier les lignes continuées (RFC 6350 §3.2)
+	var lines []string
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if (strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")) && len(lines) > 0 {
+			lines[len(lines)-1] += line[1:]
+			continue
+		}
+		lines = append(lines, line)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	var clients []models.Client
+	var current *models.Client
+	for _, line := range lines {
+		colon := strings.IndexByte(line, ':')
+		if colon < 0 {
+			continue
+		}
+		nameAndParams, value := line[:colon], line[colon+1:]
+		params := strings.Split(nameAndParams, ";")
+		name := strings.ToUpper(params[0])
+		// Ignorer le préfixe de groupe (item1.EMAIL)
+		if dot := strings.LastIndexByte(name, '.'); dot >= 0 {
+			name = name[dot+1:]
+		}
+
+		switch name {
+		case "BEGIN":
+			if strings.EqualFold(value, "VCARD") {
+				current = &models.Client{}
+			}
+			continue
+		case "END":
+			if strings.EqualFold(value, "VCARD") && current != nil {
+				if current.Name == "" {
+					current.Name = current.Company
+				}
+				if current.Name != "" || current.Email != "" {
+					clients = append(clients, *current)
+				}
+				current = nil
+			}
+			continue
+		}
+
+		if current == nil {
+			continue
+		}
+
+		switch name {
+		case "FN":
+			current.Name = vcardUnescape(value)
+		case "N":
+			if current.Name == "" {
+				parts := vcardSplit(value)
+				var given, family string
+				if len(parts) > 0 {
+					family = parts[0]
+				}
+				if len(parts) > 1 {
+					given = parts[1]
+				}
+				current.Name = strings.TrimSpace(given + " " + family)
+			}
+		case "ORG":
+			current.Company = vcardSplit(value)[0]
+		case "EMAIL":
+			if current.Email == "" || vcardIsPreferred(params) {
+				current.Email = vcardUnescape(value)
+			}
+		case "TEL":
+			if current.Phone == "" || vcardIsPreferred(params) {
+				current.Phone = strings.TrimPrefix(vcardUnescape(value), "tel:")
+			}
+		case "ADR":
+			if current.Address == "" || vcardIsPreferred(params) {
+				// ADR: boîte postale;complément;rue;ville;région;code postal;pays
+				parts := vcardSplit(value)
+				for len(parts) < 7 {
+					parts = append(parts, "")
+				}
+				current.Address = strings.TrimSpace(strings.Join(nonEmpty(parts[2], parts[1]), ", "))
+				current.City = parts[3]
+				current.PostalCode = parts[5]
+				current.Country = parts[6]
+			}
+		case "X-TAX-ID", "X-OUTBIL-TAX-ID":
+			current.TaxID = vcardUnescape(value)
+		case "X-SIREN":
+			setClientField(current, "siren", vcardUnescape(value))
+		case "X-SIRET":
+			setClientField(current, "siret", vcardUnescape(value))
+		case "X-CATEGORY":
+			setClientField(current, "category", vcardUnescape(value))
+		case "X-CURRENCY":
+			setClientField(current, "currency", vcardUnescape(value))
+		case "LANG", "X-LANGUAGE":
+			setClientField(current, "language", vcardUnescape(value))
+		}
+	}
+
+	return clients, nil
+}
+
+// vcardStructuredName découpe un nom complet pour la propriété N : le dernier
+// mot est le nom de famille, le reste les prénoms (ex: Jean Dupont => Dupont;Jean)
+func vcardStructuredName(name string) string {
+	words := strings.Fields(name)
+	if len(words) == 0 {
+		return ";;;;"
+	}
+	family := words[len(words)-1]
+	given := strings.Join(words[:len(words)-1], " ")
+	return vcardEscape(family) + ";" + vcardEscape(given) + ";;;"
+}
+
+func writeClientsVCard(w io.Writer, clients []models.Client, version int) error {
+	buf := bufio.NewWriter(w)
+
+	for _, client := range clients {
+		lines := []string{
+			"BEGIN:VCARD",
+			fmt.Sprintf("VERSION:%d.0", version),
+			"FN:" + vcardEscape(client.Name),
+			"N:" + vcardStructuredName(client.Name),
+		}
+		if client.Company != "" {
+			lines = append(lines, "ORG:"+vcardEscape(client.Company))
+		}
+		if client.Email != "" {
+			lines = append(lines, "EMAIL;TYPE=work:"+vcardEscape(client.Email))
+		}
+		if client.Phone != "" {
+			if version == 4 {
+				lines = append(lines, "TEL;VALUE=uri;TYPE=work:tel:"+strings.ReplaceAll(client.Phone, " ", ""))
+			} else {
+				lines = append(lines, "TEL;TYPE=work:"+vcardEscape(client.Phone))
+			}
+		}
+		if client.Address != "" || client.City != "" || client.PostalCode != "" || client.Country != "" {
+			lines = append(lines, fmt.Sprintf("ADR;TYPE=work:;;%s;%s;;%s;%s",
+				vcardEscape(client.Address), vcardEscape(client.City),
+				vcardEscape(client.PostalCode), vcardEscape(client.Country)))
+		}
+		// Identifiants et préférences propres à outbil, LANG n'existant qu'en 4.0
+		extensions := []struct{ name, value string }{
+			{"X-TAX-ID", client.TaxID},
+			{"X-SIREN", client.SIREN},
+			{"X-SIRET", client.SIRET},
+			{"X-CATEGORY", client.Category},
+			{"X-CURRENCY", client.Currency},
+			{"X-LANGUAGE", client.Language},
+		}
+		if version == 4 {
+			extensions[len(extensions)-1].name = "LANG"
+		}
+		for _, extension := range extensions {
+			if extension.value != "" {
+				lines = append(lines, extension.name+":"+vcardEscape(extension.value))
+			}
+		}
+		lines = append(lines, "END:VCARD")
+
+		for _, line := range lines {
+			if _, err := buf.WriteString(vcardFold(line)); err != nil {
+				return err
+			}
+		}
+	}
+
+	return buf.Flush()
+}
+
+// vcardFold termine une ligne de contenu et la replie en lignes d'au plus 75
+// octets, continuées par une espace (RFC 6350 §3.2), sans couper de caractère
+func vcardFold(line string) string {
+	var folded strings.Builder
+	limit := 75
+	for len(line) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(line[cut]) {
+			cut--
+		}
+		folded.WriteString(line[:cut] + "\r\n ")
+		line = line[cut:]
+		limit = 74 // l'espace de continuation compte dans la ligne
+	}
+	folded.WriteString(line + "\r\n")
+	return folded.String()
+}
+
+func vcardIsPreferred(params []string) bool {
+	for _, param := range params[1:] {
+		param = strings.ToUpper(param)
+		if param == "PREF" || param == "PREF=1" || (strings.HasPrefix(param, "TYPE=") && strings.Contains(param, "PREF")) {
+			return true
+		}
+	}
+	return false
+}
+
+// vcardSplit découpe une valeur composée sur les points-virgules non échappés
+func vcardSplit(value string) []string {
+	var parts []string
+	var current strings.Builder
+	escaped := false
+	for _, r := range value {
+		switch {
+		case escaped:
+			current.WriteRune('\\')
+			current.WriteRune(r)
+			escaped = false
+		case r == '\\':
+			escaped = true
+		case r == ';':
+			parts = append(parts, vcardUnescape(current.String()))
+			current.Reset()
+		default:
+			current.WriteRune(r)
+		}
+	}
+	return append(parts, vcardUnescape(current.String()))
+}
+
+func vcardUnescape(value string) string {
+	replacer := strings.NewReplacer(`\n`, "\n", `\N`, "\n", `\,`, ",", `\;`, ";", `\\`, `\`)
+	return strings.TrimSpace(replacer.Replace(value))
+}
+
+func vcardEscape(value string) string {
+	replacer := strings.NewReplacer(`\`, `\\`, "\n", `\n`, ",", `\,`, ";", `\;`)
+	return replacer.Replace(value)
+}
+
+func nonEmpty(values ...string) []string {
+	var result []string
+	for _, value := range values {
+		if strings.TrimSpace(value) != "" {
+			result = append(result, value)
+		}
+	}
+	return result
+}
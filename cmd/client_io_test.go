@@ -0,0 +1,194 @@
+package cmd
+
+import (
+	"bytes"
+	"outbil/models"
+	"reflect"
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestReadClientsCSV(t *testing.T) {
+	tests := []struct {
+		name      string
+		input     string
+		mapping   string
+		delimiter string
+		want      []models.Client
+	}{
+		{
+			name:  "champs outbil, virgule",
+			input: "name,email,siret,category,currency,language\nJean Dupont,jean@dupont.fr,732 829 320 00074,particulier,usd,EN\n",
+			want: []models.Client{{Name: "Jean Dupont", Email: "jean@dupont.fr", SIREN: "732829320", SIRET: "73282932000074",
+				Category: models.ClientB2C, Currency: "USD", Language: "en"}},
+		},
+		{
+			name:  "en-têtes de tableur français, point-virgule et BOM",
+			input: "\xEF\xBB\xBFNom;Raison sociale;Courriel;Code postal;Ville\nMarie Martin;Martin SA;marie@martin.fr;75001;Paris\n",
+			want:  []models.Client{{Name: "Marie Martin", Company: "Martin SA", Email: "marie@martin.fr", PostalCode: "75001", City: "Paris"}},
+		},
+		{
+			name:  "virgules dans un champ entre guillemets, point-virgule majoritaire",
+			input: "nom;adresse;pays\nDurand;\"1, rue de la Paix\";France\n",
+			want:  []models.Client{{Name: "Durand", Address: "1, rue de la Paix", Country: "France"}},
+		},
+		{
+			name:      "séparateur imposé",
+			input:     "name\tcity\nDurand\tLyon\n",
+			delimiter: "\t",
+			want:      []models.Client{{Name: "Durand", City: "Lyon"}},
+		},
+		{
+			name:    "correspondance explicite",
+			input:   "Client,Contact principal,Mail pro\nAcme,Paul,paul@acme.fr\n",
+			mapping: "company=Client, name=Contact principal, email=Mail pro",
+			want:    []models.Client{{Name: "Paul", Company: "Acme", Email: "paul@acme.fr"}},
+		},
+		{
+			name:  "société seule, lignes vides ignorées",
+			input: "entreprise,ville\n,\nAcme,Lille\n",
+			want:  []models.Client{{Name: "Acme", Company: "Acme", City: "Lille"}},
+		},
+		{
+			name:  "en-tête seul",
+			input: "name,email\n",
+			want:  nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := readClientsCSV(strings.NewReader(test.input), test.mapping, test.delimiter)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf("clients %+v, attendu %+v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestReadClientsCSVErrors(t *testing.T) {
+	tests := []struct{ name, input, mapping string }{
+		{name: "sans colonne de nom", input: "email,ville\na@b.fr,Paris\n"},
+		{name: "correspondance mal formée", input: "name\nA\n", mapping: "name"},
+		{name: "champ inconnu", input: "name\nA\n", mapping: "surname=name"},
+		{name: "colonne absente", input: "name\nA\n", mapping: "email=courriel"},
+	}
+	for _, test := range tests {
+		if _, err := readClientsCSV(strings.NewReader(test.input), test.mapping, ""); err == nil {
+			t.Errorf("%s: erreur attendue", test.name)
+		}
+	}
+}
+
+func TestReadClientsVCard(t *testing.T) {
+	input := strings.Join([]string{
+		"BEGIN:VCARD",
+		"VERSION:3.0",
+		"N:Dupont;Jean;;;",
+		"ORG:Dupont SARL;Direction",
+		"item1.EMAIL;TYPE=INTERNET:perso@dupont.fr",
+		"item2.EMAIL;TYPE=INTERNET,PREF:jean@dupont.fr",
+		"TEL;TYPE=WORK:01 23 45 67 89",
+		"ADR;TYPE=WORK:;Bât. B;12 rue de la Paix\\, 2e étage;Paris;;75002;France",
+		"NOTE:une note repliée sur",
+		"  deux lignes",
+		"END:VCARD",
+		"BEGIN:VCARD",
+		"VERSION:4.0",
+		"FN:Ana García",
+		"TEL;VALUE=uri;TYPE=work:tel:+34911234567",
+		"LANG:en",
+		"X-SIRET:73282932000074",
+		"END:VCARD",
+		"BEGIN:VCARD",
+		"VERSION:4.0",
+		"NOTE:fiche sans nom ni email ignorée",
+		"END:VCARD",
+	}, "\r\n")
+
+	want := []models.Client{
+		{Name: "Jean Dupont", Company: "Dupont SARL", Email: "jean@dupont.fr", Phone: "01 23 45 67 89",
+			Address: "12 rue de la Paix, 2e étage, Bât. B", City: "Paris", PostalCode: "75002", Country: "France"},
+		{Name: "Ana García", Phone: "+34911234567", Language: "en", SIREN: "732829320", SIRET: "73282932000074"},
+	}
+
+	got, err := readClientsVCard(strings.NewReader(input))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("clients %+v, attendu %+v", got, want)
+	}
+}
+
+func TestClientsVCardRoundTrip(t *testing.T) {
+	clients := []models.Client{
+		{
+			Name: "Jean-Baptiste de La Fontaine", Company: "Fables, Contes & Cie; Paris", Email: "jb@fables.fr",
+			Phone: "+33123456789", Address: "12 avenue des Champs-Élysées, bâtiment A, escalier C, 3e étage",
+			City: "Paris", PostalCode: "75008", Country: "France", TaxID: "FR44732829320",
+			SIREN: "732829320", SIRET: "73282932000074", Category: models.ClientB2B, Currency: "EUR", Language: "fr",
+		},
+		{Name: "Ana", Email: "ana@example.com", Category: models.ClientB2C, Currency: "USD", Language: "en"},
+	}
+
+	for _, version := range []int{3, 4} {
+		var buf bytes.Buffer
+		if err := writeClientsVCard(&buf, clients, version); err != nil {
+			t.Fatal(err)
+		}
+		for _, line := range strings.Split(strings.TrimSuffix(buf.String(), "\r\n"), "\r\n") {
+			if len(line) > 75 {
+				t.Errorf("vCard %d.0: ligne de %d octets: %q", version, len(line), line)
+			}
+			if !utf8.ValidString(line) {
+				t.Errorf("vCard %d.0: caractère coupé: %q", version, line)
+			}
+		}
+
+		got, err := readClientsVCard(&buf)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(got, clients) {
+			t.Errorf("vCard %d.0: relu %+v, attendu %+v", version, got, clients)
+		}
+	}
+}
+
+func TestVCardFold(t *testing.T) {
+	tests := []string{
+		"",
+		"FN:Jean Dupont",
+		strings.Repeat("a", 75),
+		strings.Repeat("a", 76),
+		"NOTE:" + strings.Repeat("é", 100),
+		"NOTE:" + strings.Repeat("x€", 60),
+	}
+	for _, line := range tests {
+		folded := vcardFold(line)
+		if !strings.HasSuffix(folded, "\r\n") {
+			t.Errorf("%q: fin de ligne absente", line)
+		}
+		parts := strings.Split(strings.TrimSuffix(folded, "\r\n"), "\r\n")
+		unfolded := parts[0]
+		for i, part := range parts {
+			if len(part) > 75 {
+				t.Errorf("%q: ligne %d de %d octets", line, i, len(part))
+			}
+			if i > 0 {
+				if !strings.HasPrefix(part, " ") {
+					t.Errorf("%q: continuation %d sans espace", line, i)
+				}
+				unfolded += part[1:]
+			}
+		}
+		if unfolded != line {
+			t.Errorf("déplié %q, attendu %q", unfolded, line)
+		}
+	}
+}
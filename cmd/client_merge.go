@@ -0,0 +1,289 @@
+package cmd
+
+import (
+	"fmt"
+	"outbil/db"
+	"outbil/i18n"
+	"outbil/models"
+	"outbil/utils"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/manifoldco/promptui"
+	"github.com/spf13/cobra"
+	"golang.org/x/text/runes"
+	"golang.org/x/text/transform"
+	"golang.org/x/text/unicode/norm"
+)
+
+func init() {
+	clientCmd.AddCommand(clientDuplicatesCmd)
+	clientCmd.AddCommand(clientMergeCmd)
+
+	clientDuplicatesCmd.Flags().BoolP("all", "a", false, "Inclure les clients archivés")
+	clientMergeCmd.Flags().BoolP("yes", "y", false, "Ne pas demander de confirmation")
+}
+
+// legalForms sont ignorées lors de la comparaison des noms ("Acme SAS" = "ACME")
+var legalForms = map[string]bool{
+	"sa": true, "sas": true, "sasu": true, "sarl": true, "eurl": true, "sci": true,
+	"snc": true, "scop": true, "ei": true, "eirl": true, "micro": true,
+	"inc": true, "ltd": true, "llc": true, "gmbh": true, "bv": true, "srl": true,
+	"cie": true, "co": true, "et": true, "societe": true, "ste": true,
+}
+
+var clientDuplicatesCmd = &cobra.Command{
+	Use:   "duplicates",
+	Short: "Détecter les clients en doublon",
+	Long: `Rechercher les clients probablement en doublon en comparant le nom normalisé
+(sans casse, accents ni forme juridique), l'email, le numéro de TVA, le SIREN et le téléphone.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		includeArchived, _ := cmd.Flags().GetBool("all")
+
+		database, err := db.New(utils.GetDatabasePath())
+		if err != nil {
+			utils.Error("Erreur d'ouverture de la base: %v", err)
+			return
+		}
+		defer database.Close()
+
+		clients, err := database.ListClients(includeArchived)
+		if err != nil {
+			utils.Error("Erreur lors de la récupération des clients: %v", err)
+			return
+		}
+
+		groups := findDuplicateClients(clients)
+		if len(groups) == 0 {
+			utils.Success("Aucun doublon détecté")
+			return
+		}
+
+		utils.Warning("%d groupe(s) de doublons probables", len(groups))
+		for i, group := range groups {
+			fmt.Printf(i18n.T("\n--- Groupe %d (%s) ---\n"), i+1, strings.Join(group.reasons, ", "))
+
+			table := utils.CreateTable()
+			table.Header("ID", i18n.T("Nom"), i18n.T("Entreprise"), "Email", i18n.T("Téléphone"), i18n.T("N° TVA"))
+			for _, client := range group.clients {
+				table.Append([]string{
+					strconv.Itoa(client.ID),
+					client.Name,
+					client.Company,
+					client.Email,
+					client.Phone,
+					client.TaxID,
+				})
+			}
+			table.Render()
+
+			utils.Info("Pour fusionner: outbil client merge %d %d", group.clients[0].ID, group.clients[1].ID)
+		}
+	},
+}
+
+var clientMergeCmd = &cobra.Command{
+	Use:   "merge [ID_CIBLE] [ID_SOURCE]",
+	Short: "Fusionner deux clients",
+	Long: `Fusionner le client source dans le client cible : tous les devis, contacts et adresses
+du client source sont rattachés au client cible, les champs vides du client cible sont
+complétés, puis le client source est supprimé. La fusion est enregistrée dans l'historique.`,
+	Args: cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		targetID, err := strconv.Atoi(args[0])
+		if err != nil {
+			utils.Error("ID invalide: %v", err)
+			return
+		}
+		sourceID, err := strconv.Atoi(args[1])
+		if err != nil {
+			utils.Error("ID invalide: %v", err)
+			return
+		}
+		yes, _ := cmd.Flags().GetBool("yes")
+
+		database, err := db.New(utils.GetDatabasePath())
+		if err != nil {
+			utils.Error("Erreur d'ouverture de la base: %v", err)
+			return
+		}
+		defer database.Close()
+
+		target, err := database.GetClient(targetID)
+		if err != nil {
+			utils.Error("Client cible non trouvé: %v", err)
+			return
+		}
+		source, err := database.GetClient(sourceID)
+		if err != nil {
+			utils.Error("Client source non trouvé: %v", err)
+			return
+		}
+
+		quoteCount, err := database.CountClientQuotes(sourceID)
+		if err != nil {
+			utils.Error("Erreur lors de la vérification des devis: %v", err)
+			return
+		}
+
+		utils.Info("Client conservé: #%d %s (%s)", target.ID, target.Name, target.Company)
+		utils.Warning("Client fusionné puis supprimé: #%d %s (%s)", source.ID, source.Name, source.Company)
+		utils.Info("%d devis, %d contact(s) et %d adresse(s) seront rattachés au client #%d",
+			quoteCount, len(source.Contacts), len(source.Addresses), target.ID)
+
+		if !yes {
+			confirm := promptui.Prompt{
+				Label:     i18n.T("Confirmer la fusion"),
+				IsConfirm: true,
+			}
+			result, _ := confirm.Run()
+			if result != "y" {
+				utils.Info("Fusion annulée")
+				return
+			}
+		}
+
+		merge, err := database.MergeClients(targetID, sourceID)
+		if err != nil {
+			utils.Error("Erreur lors de la fusion: %v", err)
+			return
+		}
+
+		utils.Success("Client %s fusionné dans %s (%d devis rattachés)", source.Name, target.Name, merge.QuotesMoved)
+	},
+}
+
+type duplicateGroup struct {
+	clients []models.Client
+	reasons []string
+}
+
+// findDuplicateClients regroupe les clients partageant un nom normalisé, un
+// email, un numéro de TVA ou un téléphone
+func findDuplicateClients(clients []models.Client) []duplicateGroup {
+	parent := make([]int, len(clients))
+	for i := range parent {
+		parent[i] = i
+	}
+	var find func(int) int
+	find = func(i int) int {
+		if parent[i] != i {
+			parent[i] = find(parent[i])
+		}
+		return parent[i]
+	}
+
+	reasons := make(map[int]map[string]bool)
+	link := func(a, b int, reason string) {
+		ra, rb := find(a), find(b)
+		if ra != rb {
+			parent[rb] = ra
+			for r := range reasons[rb] {
+				if reasons[ra] == nil {
+					reasons[ra] = make(map[string]bool)
+				}
+				reasons[ra][r] = true
+			}
+		}
+		if reasons[ra] == nil {
+			reasons[ra] = make(map[string]bool)
+		}
+		reasons[ra][reason] = true
+	}
+
+	keys := []struct {
+		reason string
+		key    func(*models.Client) []string
+	}{
+		{i18n.T("nom"), func(c *models.Client) []string {
+			return []string{normalizeClientName(c.Name), normalizeClientName(c.Company)}
+		}},
+		{i18n.T("email"), func(c *models.Client) []string { return []string{normalizeEmail(c.Email)} }},
+		{i18n.T("TVA"), func(c *models.Client) []string { return []string{normalizeTaxID(c.TaxID)} }},
+		{i18n.T("SIREN"), func(c *models.Client) []string { return []string{normalizeTaxID(c.SIREN)} }},
+		{i18n.T("téléphone"), func(c *models.Client) []string { return []string{normalizePhone(c.Phone)} }},
+	}
+
+	for _, k := range keys {
+		first := make(map[string]int)
+		for i := range clients {
+			for _, value := range k.key(&clients[i]) {
+				if value == "" {
+					continue
+				}
+				// Nom et société identiques d'un même client : pas un doublon
+				if j, ok := first[value]; ok && j != i {
+					link(j, i, k.reason)
+				} else if !ok {
+					first[value] = i
+				}
+			}
+		}
+	}
+
+	members := make(map[int][]models.Client)
+	for i := range clients {
+		root := find(i)
+		members[root] = append(members[root], clients[i])
+	}
+
+	var groups []duplicateGroup
+	for root, group := range members {
+		if len(group) < 2 {
+			continue
+		}
+		sort.Slice(group, func(a, b int) bool { return group[a].ID < group[b].ID })
+
+		var why []string
+		for r := range reasons[find(root)] {
+			why = append(why, r)
+		}
+		sort.Strings(why)
+
+		groups = append(groups, duplicateGroup{clients: group, reasons: why})
+	}
+	sort.Slice(groups, func(a, b int) bool { return groups[a].clients[0].ID < groups[b].clients[0].ID })
+
+	return groups
+}
+
+// normalizeClientName réduit un nom à ses mots significatifs, sans casse,
+// accents, ponctuation ni forme juridique
+func normalizeClientName(name string) string {
+	stripAccents := transform.Chain(norm.NFD, runes.Remove(runes.In(unicode.Mn)), norm.NFC)
+	name, _, _ = transform.String(stripAccents, strings.ToLower(name))
+
+	words := strings.FieldsFunc(name, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	var significant []string
+	for _, word := range words {
+		if !legalForms[word] {
+			significant = append(significant, word)
+		}
+	}
+	return strings.Join(significant, " ")
+}
+
+// normalizePhone ne garde que les chiffres et ramène l'indicatif +33 au format national
+func normalizePhone(phone string) string {
+	var digits strings.Builder
+	for _, r := range phone {
+		if unicode.IsDigit(r) {
+			digits.WriteRune(r)
+		}
+	}
+	number := digits.String()
+	if strings.HasPrefix(number, "0033") {
+		number = "0" + number[4:]
+	} else if strings.HasPrefix(number, "33") && len(number) == 11 {
+		number = "0" + number[2:]
+	}
+	if len(number) < 6 {
+		return ""
+	}
+	return number
+}
@@ -1,10 +1,16 @@
 package cmd
 
 import (
+	"errors"
 	"fmt"
 	"outbil/db"
+	"outbil/i18n"
 	"outbil/models"
+	"outbil/render"
 	"outbil/utils"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/manifoldco/promptui"
 	"github.com/spf13/cobra"
@@ -14,12 +20,29 @@ func init() {
 	rootCmd.AddCommand(companyCmd)
 	companyCmd.AddCommand(companySetupCmd)
 	companyCmd.AddCommand(companyShowCmd)
+	companyCmd.AddCommand(companyListCmd)
+	companyCmd.AddCommand(companyAddCmd)
+	companyCmd.AddCommand(companyDefaultCmd)
+	companyCmd.AddCommand(companyDeleteCmd)
+	companyCmd.AddCommand(companyNumberingCmd)
+	companyCmd.AddCommand(companyLocaleCmd)
+
+	companyCmd.PersistentFlags().StringP("company", "c", "", "Entreprise concernée (ID ou nom), par défaut l'entreprise par défaut")
+
+	companyNumberingCmd.Flags().String("prefix", "", "Préfixe des numéros (ex: AC-)")
+	companyNumberingCmd.Flags().Int("next", 1, "Prochain numéro attribué")
+	companyNumberingCmd.Flags().Int("padding", 4, "Nombre minimal de chiffres")
+	companyNumberingCmd.Flags().Bool("random", false, "Revenir aux identifiants aléatoires de 8 lettres")
 }
 
 var companyCmd = &cobra.Command{
 	Use:   "company",
 	Short: "Gérer les informations de votre entreprise",
-	Long:  `Commandes pour configurer les informations de votre entreprise qui apparaîtront sur les devis`,
+	Long: `Commandes pour configurer les informations de vos entreprises qui apparaîtront sur les devis.
+
+Une base peut contenir plusieurs entreprises émettrices, chacune avec sa numérotation, son logo,
+ses CGV, ses comptes bancaires et son taux de TVA par défaut. L'option --company (ID ou nom)
+sélectionne l'entreprise concernée, sinon l'entreprise par défaut est utilisée.`,
 }
 
 var companySetupCmd = &cobra.Command{
@@ -33,120 +56,220 @@ var companySetupCmd = &cobra.Command{
 		}
 		defer database.Close()
 
-		company, err := database.GetCompany()
+		selector, _ := cmd.Flags().GetString("company")
+		company, err := database.FindCompany(selector)
 		if err != nil {
 			utils.Error("Erreur lors de la récupération: %v", err)
 			return
 		}
 
 		if company == nil {
-			company = &models.Company{
-				Currency: "EUR",
-				TaxRate:  20.0,
+			company = newCompany()
+		}
+
+		if err := promptCompanyProfile(company); err != nil {
+			utils.Error("%v", err)
+			return
+		}
+
+		confirm := promptui.Prompt{
+			Label:     i18n.T("Enregistrer les modifications"),
+			IsConfirm: true,
+		}
+		result, _ := confirm.Run()
+
+		if result == "y" {
+			err = database.SaveCompany(company)
+			if err != nil {
+				utils.Error("Erreur lors de l'enregistrement: %v", err)
+				return
 			}
+			utils.Success("Informations de l'entreprise enregistrées")
+		} else {
+			utils.Info("Modifications annulées")
 		}
+	},
+}
 
-		prompt := promptui.Prompt{
-			Label:   "Nom de l'entreprise",
-			Default: company.Name,
-			Validate: func(input string) error {
-				if len(input) < 2 {
-					return fmt.Errorf("le nom doit contenir au moins 2 caractères")
-				}
-				return nil
-			},
+var companyShowCmd = &cobra.Command{
+	Use:   "show",
+	Short: "Afficher les informations de l'entreprise",
+	Run: func(cmd *cobra.Command, args []string) {
+		database, err := db.New(utils.GetDatabasePath())
+		if err != nil {
+			utils.Error("Erreur d'ouverture de la base: %v", err)
+			return
 		}
-		company.Name, _ = prompt.Run()
+		defer database.Close()
 
-		prompt = promptui.Prompt{
-			Label:   "Email",
-			Default: company.Email,
+		selector, _ := cmd.Flags().GetString("company")
+		company, err := database.FindCompany(selector)
+		if err != nil {
+			utils.Error("Erreur lors de la récupération: %v", err)
+			return
 		}
-		company.Email, _ = prompt.Run()
 
-		prompt = promptui.Prompt{
-			Label:   "Téléphone",
-			Default: company.Phone,
+		if company == nil {
+			utils.Info("Aucune information d'entreprise configurée. Utilisez 'outbil company setup'")
+			return
 		}
-		company.Phone, _ = prompt.Run()
 
-		prompt = promptui.Prompt{
-			Label:   "Adresse",
-			Default: company.Address,
+		fmt.Print(i18n.T("\n--- Informations de l'entreprise ---\n"))
+		fmt.Printf(i18n.T("ID:          %d"), company.ID)
+		if company.IsDefault {
+			fmt.Print(i18n.T(" (par défaut)"))
 		}
-		company.Address, _ = prompt.Run()
+		fmt.Println()
+		fmt.Printf(i18n.T("Nom:         %s\n"), company.Name)
+		fmt.Printf(i18n.T("Email:       %s\n"), company.Email)
+		fmt.Printf(i18n.T("Téléphone:   %s\n"), company.Phone)
+		fmt.Printf(i18n.T("Adresse:     %s\n"), company.Address)
+		fmt.Printf(i18n.T("Ville:       %s\n"), company.City)
+		fmt.Printf(i18n.T("Code postal: %s\n"), company.PostalCode)
+		fmt.Printf(i18n.T("Pays:        %s\n"), company.Country)
+		fmt.Printf(i18n.T("SIRET:       %s\n"), company.SIRET)
+		fmt.Printf(i18n.T("N° TVA:      %s\n"), company.TaxID)
+		fmt.Printf(i18n.T("Site web:    %s\n"), company.Website)
+		fmt.Printf(i18n.T("Devise:      %s\n"), company.Currency)
+		fmt.Printf(i18n.T("Format:      %s\n"), utils.GetLocale(company.Locale).Name)
+		fmt.Printf(i18n.T("TVA défaut:  %s\n"), utils.GetLocale(company.Locale).FormatPercent(company.TaxRate))
+		fmt.Printf(i18n.T("Thème PDF:   %s\n"), company.Theme)
+		fmt.Printf(i18n.T("Protection:  %s\n"), pdfProtectionSummary(company))
+		fmt.Printf(i18n.T("Signature:   %s\n"), pdfSignatureSummary(company, nil))
 
-		prompt = promptui.Prompt{
-			Label:   "Ville",
-			Default: company.City,
+		fmt.Print(i18n.T("\n--- Mentions légales ---\n"))
+		fmt.Printf(i18n.T("Forme:       %s\n"), company.LegalForm)
+		if company.ShareCapital > 0 {
+			fmt.Printf(i18n.T("Capital:     %s\n"), utils.GetLocale(company.Locale).FormatPrice(company.ShareCapital, company.Currency))
+		}
+		if company.RegistryType != "" {
+			fmt.Printf(i18n.T("Registre:    %s %s\n"), company.RegistryType, company.RegistryCity)
+		}
+		fmt.Printf(i18n.T("Code APE:    %s\n"), company.APECode)
+		if company.InsurerName != "" {
+			fmt.Printf(i18n.T("Assurance:   %s, police n° %s (%s)\n"), company.InsurerName, company.InsurancePolicy, company.InsuranceCoverage)
 		}
-		company.City, _ = prompt.Run()
+		fmt.Printf(i18n.T("Paiement:    %s\n"), company.PaymentTerms)
 
-		prompt = promptui.Prompt{
-			Label:   "Code postal",
-			Default: company.PostalCode,
+		if seq, err := database.GetNumberSequence(company.ID, models.DocumentQuote); err == nil && seq != nil {
+			fmt.Printf(i18n.T("Numérotation: %s (prochain devis: %s)\n"), seq.Format(1), seq.Format(seq.NextValue))
+		} else {
+			fmt.Print(i18n.T("Numérotation: identifiants aléatoires\n"))
 		}
-		company.PostalCode, _ = prompt.Run()
 
-		prompt = promptui.Prompt{
-			Label:   "Pays",
-			Default: func() string {
-				if company.Country != "" {
-					return company.Country
-				}
-				return "France"
-			}(),
+		if account := company.DefaultBankAccount(); account != nil {
+			fmt.Print(i18n.T("\n--- Coordonnées bancaires ---\n"))
+			fmt.Printf(i18n.T("Titulaire:   %s\n"), account.Holder)
+			fmt.Printf(i18n.T("IBAN:        %s\n"), utils.FormatIBAN(account.IBAN))
+			fmt.Printf(i18n.T("BIC:         %s\n"), account.BIC)
+			if len(company.BankAccounts) > 1 {
+				utils.Info("%d comptes enregistrés, voir: outbil company bank list", len(company.BankAccounts))
+			}
+		}
+
+		if err := checkCompanyLegalProfile(company); err != nil {
+			fmt.Println()
+			utils.Warning("%v", err)
+			utils.Info("Complétez-les avec: outbil company setup")
+		}
+	},
+}
+
+var companyListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "Lister les entreprises émettrices",
+	Run: func(cmd *cobra.Command, args []string) {
+		database, err := db.New(utils.GetDatabasePath())
+		if err != nil {
+			utils.Error("Erreur d'ouverture de la base: %v", err)
+			return
 		}
-		company.Country, _ = prompt.Run()
+		defer database.Close()
 
-		prompt = promptui.Prompt{
-			Label:   "Numéro TVA",
-			Default: company.TaxID,
+		companies, err := database.ListCompanies()
+		if err != nil {
+			utils.Error("Erreur lors de la récupération des entreprises: %v", err)
+			return
 		}
-		company.TaxID, _ = prompt.Run()
 
-		prompt = promptui.Prompt{
-			Label:   "Site web",
-			Default: company.Website,
+		if len(companies) == 0 {
+			utils.Info("Aucune entreprise configurée. Utilisez 'outbil company setup'")
+			return
 		}
-		company.Website, _ = prompt.Run()
 
-		prompt = promptui.Prompt{
-			Label:   "Devise",
-			Default: company.Currency,
+		table := utils.CreateTable()
+		table.Header("ID", i18n.T("Nom"), "SIRET", i18n.T("Devise"), i18n.T("TVA défaut"), i18n.T("Devis"), i18n.T("Défaut"))
+
+		for _, company := range companies {
+			isDefault := ""
+			if company.IsDefault {
+				isDefault = "✓"
+			}
+			quotes, _ := database.CountCompanyQuotes(company.ID)
+			table.Append([]string{
+				strconv.Itoa(company.ID),
+				company.Name,
+				company.SIRET,
+				company.Currency,
+				fmt.Sprintf("%.0f%%", company.TaxRate),
+				strconv.Itoa(quotes),
+				isDefault,
+			})
 		}
-		company.Currency, _ = prompt.Run()
 
-		prompt = promptui.Prompt{
-			Label:   "Taux de TVA par défaut (%)",
-			Default: fmt.Sprintf("%.0f", company.TaxRate),
+		table.Render()
+	},
+}
+
+var companyAddCmd = &cobra.Command{
+	Use:   "add",
+	Short: "Ajouter une entreprise émettrice",
+	Run: func(cmd *cobra.Command, args []string) {
+		database, err := db.New(utils.GetDatabasePath())
+		if err != nil {
+			utils.Error("Erreur d'ouverture de la base: %v", err)
+			return
+		}
+		defer database.Close()
+
+		company := newCompany()
+		if err := promptCompanyProfile(company); err != nil {
+			utils.Error("%v", err)
+			return
 		}
-		taxStr, _ := prompt.Run()
-		company.TaxRate, _ = utils.ParseFloat(taxStr)
 
 		confirm := promptui.Prompt{
-			Label:     "Enregistrer les modifications",
+			Label:     i18n.T("Confirmer la création de l'entreprise"),
 			IsConfirm: true,
 		}
 		result, _ := confirm.Run()
 
 		if result == "y" {
-			err = database.SaveCompany(company)
-			if err != nil {
+			if err := database.SaveCompany(company); err != nil {
 				utils.Error("Erreur lors de l'enregistrement: %v", err)
 				return
 			}
-			utils.Success("Informations de l'entreprise enregistrées")
+			utils.Success("Entreprise %s créée (ID: %d)", company.Name, company.ID)
+			if !company.IsDefault {
+				utils.Info("Pour l'utiliser par défaut: outbil company default %d", company.ID)
+			}
 		} else {
-			utils.Info("Modifications annulées")
+			utils.Info("Création annulée")
 		}
 	},
 }
 
-var companyShowCmd = &cobra.Command{
-	Use:   "show",
-	Short: "Afficher les informations de l'entreprise",
+var companyDefaultCmd = &cobra.Command{
+	Use:   "default [ID]",
+	Short: "Définir l'entreprise utilisée par défaut",
+	Args:  cobra.ExactArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
+		id, err := strconv.Atoi(args[0])
+		if err != nil {
+			utils.Error("ID invalide: %v", err)
+			return
+		}
+
 		database, err := db.New(utils.GetDatabasePath())
 		if err != nil {
 			utils.Error("Erreur d'ouverture de la base: %v", err)
@@ -154,28 +277,327 @@ var companyShowCmd = &cobra.Command{
 		}
 		defer database.Close()
 
-		company, err := database.GetCompany()
+		if err := database.SetDefaultCompany(id); err != nil {
+			utils.Error("Erreur lors de la mise à jour: %v", err)
+			return
+		}
+
+		utils.Success("Entreprise par défaut mise à jour")
+	},
+}
+
+var companyDeleteCmd = &cobra.Command{
+	Use:   "delete [ID]",
+	Short: "Supprimer une entreprise sans devis",
+	Long:  "Supprimer une entreprise qui n'a émis aucun devis, avec ses comptes bancaires, CGV et numérotations",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		id, err := strconv.Atoi(args[0])
 		if err != nil {
-			utils.Error("Erreur lors de la récupération: %v", err)
+			utils.Error("ID invalide: %v", err)
 			return
 		}
 
-		if company == nil {
-			utils.Info("Aucune information d'entreprise configurée. Utilisez 'outbil company setup'")
+		database, err := db.New(utils.GetDatabasePath())
+		if err != nil {
+			utils.Error("Erreur d'ouverture de la base: %v", err)
+			return
+		}
+		defer database.Close()
+
+		company, err := database.GetCompanyByID(id)
+		if err != nil {
+			utils.Error("Entreprise non trouvée: %v", err)
+			return
+		}
+
+		utils.Warning("Entreprise à supprimer: %s", company.Name)
+
+		confirm := promptui.Prompt{
+			Label:     i18n.T("Confirmer la suppression"),
+			IsConfirm: true,
+		}
+		result, _ := confirm.Run()
+
+		if result == "y" {
+			if err := database.DeleteCompany(id); err != nil {
+				utils.Error("Suppression impossible: %v", err)
+				return
+			}
+			utils.Success("Entreprise supprimée")
+		} else {
+			utils.Info("Suppression annulée")
+		}
+	},
+}
+
+var companyNumberingCmd = &cobra.Command{
+	Use:   "numbering",
+	Short: "Configurer la numérotation des devis de l'entreprise",
+	Long: `Définir une numérotation séquentielle propre à l'entreprise (préfixe, prochain numéro,
+nombre de chiffres). Sans numérotation, les devis reçoivent un identifiant aléatoire de 8 lettres.
+
+Exemples:
+  outbil company numbering --company 2 --prefix AC- --next 1 --padding 4   # AC-0001, AC-0002...
+  outbil company numbering --random`,
+	Run: func(cmd *cobra.Command, args []string) {
+		selector, _ := cmd.Flags().GetString("company")
+		prefix, _ := cmd.Flags().GetString("prefix")
+		next, _ := cmd.Flags().GetInt("next")
+		padding, _ := cmd.Flags().GetInt("padding")
+		random, _ := cmd.Flags().GetBool("random")
+
+		database, err := db.New(utils.GetDatabasePath())
+		if err != nil {
+			utils.Error("Erreur d'ouverture de la base: %v", err)
+			return
+		}
+		defer database.Close()
+
+		company, err := requireCompany(database, selector)
+		if err != nil {
+			utils.Error("%v", err)
+			return
+		}
+
+		if random {
+			if err := database.DeleteNumberSequence(company.ID, models.DocumentQuote); err != nil {
+				utils.Error("Erreur lors de la mise à jour: %v", err)
+				return
+			}
+			utils.Success("Les devis de %s recevront des identifiants aléatoires", company.Name)
+			return
+		}
+
+		if next < 1 || padding < 0 || padding > 12 {
+			utils.Error("Valeurs invalides: --next doit être positif et --padding compris entre 0 et 12")
+			return
+		}
+
+		seq := &models.NumberSequence{
+			CompanyID:    company.ID,
+			DocumentType: models.DocumentQuote,
+			Prefix:       strings.TrimSpace(prefix),
+			NextValue:    next,
+			Padding:      padding,
+		}
+		if err := database.SaveNumberSequence(seq); err != nil {
+			utils.Error("Erreur lors de l'enregistrement: %v", err)
+			return
+		}
+
+		utils.Success("Numérotation des devis de %s: prochain numéro %s", company.Name, seq.Format(seq.NextValue))
+	},
+}
+
+func newCompany() *models.Company {
+	return &models.Company{
+		Currency:      "EUR",
+		TaxRate:       20.0,
+		Locale:        utils.DefaultLocale,
+		Theme:         render.DefaultTheme,
+		PDFProtection: models.PDFProtectionPrint,
+	}
+}
+
+// promptCompanyProfile demande l'ensemble des informations d'une entreprise
+func promptCompanyProfile(company *models.Company) error {
+	prompt := promptui.Prompt{
+		Label:   i18n.T("Nom de l'entreprise"),
+		Default: company.Name,
+		Validate: func(input string) error {
+			if len(input) < 2 {
+				return errors.New(i18n.T("le nom doit contenir au moins 2 caractères"))
+			}
+			return nil
+		},
+	}
+	company.Name, _ = prompt.Run()
+
+	prompt = promptui.Prompt{
+		Label:   i18n.T("Email"),
+		Default: company.Email,
+	}
+	company.Email, _ = prompt.Run()
+
+	prompt = promptui.Prompt{
+		Label:   i18n.T("Téléphone"),
+		Default: company.Phone,
+	}
+	company.Phone, _ = prompt.Run()
+
+	prompt = promptui.Prompt{
+		Label:   i18n.T("Adresse"),
+		Default: company.Address,
+	}
+	company.Address, _ = prompt.Run()
+
+	prompt = promptui.Prompt{
+		Label:   i18n.T("Ville"),
+		Default: company.City,
+	}
+	company.City, _ = prompt.Run()
+
+	prompt = promptui.Prompt{
+		Label:   i18n.T("Code postal"),
+		Default: company.PostalCode,
+	}
+	company.PostalCode, _ = prompt.Run()
+
+	prompt = promptui.Prompt{
+		Label:   i18n.T("Pays"),
+		Default: func() string {
+			if company.Country != "" {
+				return company.Country
+			}
+			return "France"
+		}(),
+	}
+	company.Country, _ = prompt.Run()
+
+	prompt = promptui.Prompt{
+		Label:    i18n.T("SIRET"),
+		Default:  company.SIRET,
+		Validate: optionalIdentifier(utils.ValidateSIRET),
+	}
+	siret, _ := prompt.Run()
+	company.SIRET = utils.CompactIdentifier(siret)
+
+	siren := ""
+	if company.SIRET != "" {
+		siren = company.SIRET[:9]
+	}
+
+	prompt = promptui.Prompt{
+		Label:   i18n.T("Numéro TVA"),
+		Default: suggestedVATNumber(company.TaxID, company.Country, siren),
+		Validate: optionalIdentifier(func(input string) error {
+			return utils.ValidateFrenchIdentifiers(siren, "", input)
+		}),
+	}
+	taxID, _ := prompt.Run()
+	company.TaxID = utils.CompactIdentifier(taxID)
+
+	if err := utils.ValidateFrenchIdentifiers("", company.SIRET, company.TaxID); err != nil {
+		return fmt.Errorf(i18n.T("identifiants invalides: %v"), err)
+	}
+
+	promptCompanyLegalProfile(company)
+
+	prompt = promptui.Prompt{
+		Label:   i18n.T("Site web"),
+		Default: company.Website,
+	}
+	company.Website, _ = prompt.Run()
+
+	company.Currency = promptCurrency("Devise de référence", company.Currency)
+	company.Locale = promptLocale(company.Locale)
+
+	prompt = promptui.Prompt{
+		Label:   i18n.T("Taux de TVA par défaut (%)"),
+		Default: fmt.Sprintf("%.0f", company.TaxRate),
+	}
+	taxStr, _ := prompt.Run()
+	company.TaxRate, _ = utils.ParseFloat(taxStr)
+
+	return nil
+}
+
+var companyLocaleCmd = &cobra.Command{
+	Use:   "locale [CODE]",
+	Short: "Afficher ou choisir le format des nombres et des dates de l'entreprise",
+	Long: `Afficher ou choisir la locale de l'entreprise : le format des montants et des
+dates de ses devis, dans les commandes comme dans les PDFs, et la langue par défaut
+de ses documents.
+
+Exemples:
+  outbil company locale
+  outbil company locale en-US`,
+	Args: cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		database, err := db.New(utils.GetDatabasePath())
+		if err != nil {
+			utils.Error("Erreur d'ouverture de la base: %v", err)
 			return
 		}
+		defer database.Close()
 
-		fmt.Printf("\n--- Informations de l'entreprise ---\n")
-		fmt.Printf("Nom:         %s\n", company.Name)
-		fmt.Printf("Email:       %s\n", company.Email)
-		fmt.Printf("Téléphone:   %s\n", company.Phone)
-		fmt.Printf("Adresse:     %s\n", company.Address)
-		fmt.Printf("Ville:       %s\n", company.City)
-		fmt.Printf("Code postal: %s\n", company.PostalCode)
-		fmt.Printf("Pays:        %s\n", company.Country)
-		fmt.Printf("N° TVA:      %s\n", company.TaxID)
-		fmt.Printf("Site web:    %s\n", company.Website)
-		fmt.Printf("Devise:      %s\n", company.Currency)
-		fmt.Printf("TVA défaut:  %.0f%%\n", company.TaxRate)
+		selector, _ := cmd.Flags().GetString("company")
+		company, err := requireCompany(database, selector)
+		if err != nil {
+			utils.Error("%v", err)
+			return
+		}
+
+		if len(args) == 0 {
+			current := utils.NormalizeLocale(company.Locale)
+			for _, code := range utils.LocaleCodes() {
+				line := localeDescription(code)
+				if code == current {
+					utils.Success("  → %s", line)
+				} else {
+					fmt.Printf("    %s\n", line)
+				}
+			}
+			return
+		}
+
+		if err := utils.ValidateLocale(args[0]); err != nil {
+			utils.Error("%v", err)
+			return
+		}
+		company.Locale = utils.NormalizeLocale(args[0])
+		if err := database.SaveCompany(company); err != nil {
+			utils.Error("Erreur lors de la sauvegarde: %v", err)
+			return
+		}
+		utils.Success("Format de %s: %s", company.Name, localeDescription(company.Locale))
 	},
-}
\ No newline at end of file
+}
+
+// localeDescription présente une locale avec un montant et une date d'exemple
+func localeDescription(code string) string {
+	locale := utils.GetLocale(code)
+	return fmt.Sprintf("%s - %s (%s, %s)", code, locale.Name,
+		locale.FormatPrice(1234.5, utils.DefaultCurrency), locale.FormatDate(time.Date(2025, 12, 31, 0, 0, 0, 0, time.Local)))
+}
+
+// promptLocale demande les conventions d'affichage des nombres, montants et
+// dates de l'entreprise
+func promptLocale(current string) string {
+	codes := utils.LocaleCodes()
+	items := make([]string, len(codes))
+	cursor := 0
+	for i, code := range codes {
+		items[i] = localeDescription(code)
+		if code == utils.NormalizeLocale(current) {
+			cursor = i
+		}
+	}
+
+	sel := promptui.Select{
+		Label:     i18n.T("Format des nombres et des dates"),
+		Items:     items,
+		CursorPos: cursor,
+	}
+	idx, _, err := sel.Run()
+	if err != nil {
+		return codes[cursor]
+	}
+	return codes[idx]
+}
+
+// companyLocale retourne les conventions d'affichage de l'entreprise (fr-FR à défaut)
+func companyLocale(company *models.Company) utils.Locale {
+	if company == nil {
+		return utils.GetLocale("")
+	}
+	return utils.GetLocale(company.Locale)
+}
+
+// useCompanyLocale affiche les montants et les dates selon les conventions de l'entreprise
+func useCompanyLocale(company *models.Company) {
+	if company != nil {
+		utils.SetLocale(company.Locale)
+	}
+}
@@ -0,0 +1,285 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"outbil/db"
+	"outbil/i18n"
+	"outbil/models"
+	"outbil/utils"
+	"strconv"
+	"strings"
+
+	"github.com/manifoldco/promptui"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	companyCmd.AddCommand(companyBankCmd)
+	companyBankCmd.AddCommand(companyBankListCmd)
+	companyBankCmd.AddCommand(companyBankAddCmd)
+	companyBankCmd.AddCommand(companyBankDefaultCmd)
+	companyBankCmd.AddCommand(companyBankDeleteCmd)
+}
+
+var companyBankCmd = &cobra.Command{
+	Use:   "bank",
+	Short: "Gérer les comptes bancaires de l'entreprise",
+	Long: `Commandes pour gérer les comptes bancaires imprimés sur les devis avec un QR code
+de virement SEPA (EPC)`,
+}
+
+var companyBankListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "Lister les comptes bancaires",
+	Run: func(cmd *cobra.Command, args []string) {
+		database, err := db.New(utils.GetDatabasePath())
+		if err != nil {
+			utils.Error("Erreur d'ouverture de la base: %v", err)
+			return
+		}
+		defer database.Close()
+
+		selector, _ := cmd.Flags().GetString("company")
+		company, err := requireCompany(database, selector)
+		if err != nil {
+			utils.Error("%v", err)
+			return
+		}
+
+		if len(company.BankAccounts) == 0 {
+			utils.Info("Aucun compte bancaire enregistré")
+			return
+		}
+
+		table := utils.CreateTable()
+		table.Header("ID", i18n.T("Libellé"), i18n.T("Titulaire"), "IBAN", "BIC", i18n.T("Défaut"))
+
+		for _, account := range company.BankAccounts {
+			isDefault := ""
+			if account.IsDefault {
+				isDefault = "✓"
+			}
+			table.Append([]string{
+				strconv.Itoa(account.ID),
+				account.Label,
+				account.Holder,
+				utils.FormatIBAN(account.IBAN),
+				account.BIC,
+				isDefault,
+			})
+		}
+
+		table.Render()
+	},
+}
+
+var companyBankAddCmd = &cobra.Command{
+	Use:   "add",
+	Short: "Ajouter un compte bancaire",
+	Run: func(cmd *cobra.Command, args []string) {
+		database, err := db.New(utils.GetDatabasePath())
+		if err != nil {
+			utils.Error("Erreur d'ouverture de la base: %v", err)
+			return
+		}
+		defer database.Close()
+
+		selector, _ := cmd.Flags().GetString("company")
+		company, err := requireCompany(database, selector)
+		if err != nil {
+			utils.Error("%v", err)
+			return
+		}
+
+		account := &models.BankAccount{CompanyID: company.ID}
+
+		prompt := promptui.Prompt{
+			Label: i18n.T("Libellé (ex: Compte courant)"),
+		}
+		account.Label, _ = prompt.Run()
+
+		prompt = promptui.Prompt{
+			Label:   i18n.T("Titulaire du compte"),
+			Default: company.Name,
+			Validate: func(input string) error {
+				if strings.TrimSpace(input) == "" {
+					return errors.New(i18n.T("le titulaire est obligatoire"))
+				}
+				if len([]rune(input)) > 70 {
+					return errors.New(i18n.T("70 caractères maximum"))
+				}
+				return nil
+			},
+		}
+		holder, _ := prompt.Run()
+		account.Holder = strings.TrimSpace(holder)
+
+		prompt = promptui.Prompt{
+			Label:    i18n.T("IBAN"),
+			Validate: utils.ValidateIBAN,
+		}
+		iban, _ := prompt.Run()
+		account.IBAN = utils.CompactIdentifier(iban)
+
+		prompt = promptui.Prompt{
+			Label:    i18n.T("BIC"),
+			Validate: optionalIdentifier(utils.ValidateBIC),
+		}
+		bic, _ := prompt.Run()
+		account.BIC = utils.CompactIdentifier(bic)
+
+		if len(company.BankAccounts) > 0 {
+			defaultPrompt := promptui.Prompt{
+				Label:     i18n.T("Utiliser ce compte par défaut"),
+				IsConfirm: true,
+			}
+			result, _ := defaultPrompt.Run()
+			account.IsDefault = result == "y"
+		}
+
+		if err := utils.ValidateIBAN(account.IBAN); err != nil {
+			utils.Error("%v", err)
+			return
+		}
+
+		if err := database.CreateBankAccount(account); err != nil {
+			utils.Error("Erreur lors de l'enregistrement du compte: %v", err)
+			return
+		}
+
+		utils.Success("Compte bancaire enregistré (ID: %d)", account.ID)
+	},
+}
+
+var companyBankDefaultCmd = &cobra.Command{
+	Use:   "default [ID]",
+	Short: "Définir le compte bancaire imprimé sur les documents",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		id, err := strconv.Atoi(args[0])
+		if err != nil {
+			utils.Error("ID invalide: %v", err)
+			return
+		}
+
+		database, err := db.New(utils.GetDatabasePath())
+		if err != nil {
+			utils.Error("Erreur d'ouverture de la base: %v", err)
+			return
+		}
+		defer database.Close()
+
+		if err := database.SetDefaultBankAccount(id); err != nil {
+			utils.Error("Erreur lors de la mise à jour: %v", err)
+			return
+		}
+
+		utils.Success("Compte bancaire par défaut mis à jour")
+	},
+}
+
+var companyBankDeleteCmd = &cobra.Command{
+	Use:   "delete [ID]",
+	Short: "Supprimer un compte bancaire",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		id, err := strconv.Atoi(args[0])
+		if err != nil {
+			utils.Error("ID invalide: %v", err)
+			return
+		}
+
+		database, err := db.New(utils.GetDatabasePath())
+		if err != nil {
+			utils.Error("Erreur d'ouverture de la base: %v", err)
+			return
+		}
+		defer database.Close()
+
+		account, err := database.GetBankAccount(id)
+		if err != nil {
+			utils.Error("Compte non trouvé: %v", err)
+			return
+		}
+
+		utils.Warning("Compte à supprimer: %s %s", account.Label, utils.FormatIBAN(account.IBAN))
+
+		confirm := promptui.Prompt{
+			Label:     i18n.T("Confirmer la suppression"),
+			IsConfirm: true,
+		}
+		result, _ := confirm.Run()
+
+		if result == "y" {
+			if err := database.DeleteBankAccount(id); err != nil {
+				utils.Error("Erreur lors de la suppression: %v", err)
+				return
+			}
+			utils.Success("Compte bancaire supprimé")
+		} else {
+			utils.Info("Suppression annulée")
+		}
+	},
+}
+
+// requireCompany retourne l'entreprise désignée par le sélecteur (ID ou nom),
+// ou l'entreprise par défaut
+func requireCompany(database *db.Database, selector string) (*models.Company, error) {
+	company, err := database.FindCompany(selector)
+	if err != nil {
+		return nil, fmt.Errorf(i18n.T("erreur lors de la récupération de l'entreprise: %v"), err)
+	}
+	if company == nil {
+		return nil, errors.New(i18n.T("aucune entreprise configurée. Utilisez 'outbil company setup' d'abord"))
+	}
+	return company, nil
+}
+
+// bankDetailsLines construit les coordonnées bancaires imprimées sur les documents
+func bankDetailsLines(account *models.BankAccount, reference string, labels i18n.DocumentLabels) []string {
+	lines := []string{
+		fmt.Sprintf(labels.Holder, account.Holder),
+		fmt.Sprintf(labels.IBAN, utils.FormatIBAN(account.IBAN)),
+	}
+	if account.BIC != "" {
+		lines = append(lines, fmt.Sprintf(labels.BIC, account.BIC))
+	}
+	if reference != "" {
+		lines = append(lines, fmt.Sprintf(labels.Reference, reference))
+	}
+	return lines
+}
+
+// epcQRPayload construit le contenu d'un QR code de virement SEPA selon la
+// recommandation EPC069-12 (version 002, encodage UTF-8)
+func epcQRPayload(account *models.BankAccount, amount float64, reference string) string {
+	holder := []rune(account.Holder)
+	if len(holder) > 70 {
+		holder = holder[:70]
+	}
+	remittance := []rune(reference)
+	if len(remittance) > 140 {
+		remittance = remittance[:140]
+	}
+
+	amountField := ""
+	if amount >= 0.01 && amount <= 999999999.99 {
+		amountField = fmt.Sprintf("EUR%.2f", amount)
+	}
+
+	lines := []string{
+		"BCD",
+		"002",
+		"1",
+		"SCT",
+		utils.CompactIdentifier(account.BIC),
+		string(holder),
+		utils.CompactIdentifier(account.IBAN),
+		amountField,
+		"",
+		"",
+		string(remittance),
+	}
+	return strings.Join(lines, "\n")
+}
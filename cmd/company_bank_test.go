@@ -0,0 +1,82 @@
+package cmd
+
+import (
+	"outbil/models"
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestEPCQRPayload(t *testing.T) {
+	account := &models.BankAccount{
+		Holder: "Acme Conseil",
+		IBAN:   "FR14 2004 1010 0505 0001 3M02 606",
+		BIC:    "bnpa frpp xxx",
+	}
+
+	got := epcQRPayload(account, 1234.5, "Devis DEV-2025-001")
+	want := "BCD\n" + // Service tag
+		"002\n" + // Version
+		"1\n" + // Jeu de caractères : UTF-8
+		"SCT\n" + // Identification : virement SEPA
+		"BNPAFRPPXXX\n" + // BIC
+		"Acme Conseil\n" + // Bénéficiaire
+		"FR1420041010050500013M02606\n" + // IBAN
+		"EUR1234.50\n" + // Montant
+		"\n" + // Code motif
+		"\n" + // Référence structurée
+		"Devis DEV-2025-001" // Référence libre
+	if got != want {
+		t.Errorf("contenu du QR code:\n%q\nattendu:\n%q", got, want)
+	}
+}
+
+func TestEPCQRPayloadLimits(t *testing.T) {
+	account := &models.BankAccount{
+		Holder: strings.Repeat("H", 80),
+		IBAN:   "DE89370400440532013000",
+	}
+
+	tests := []struct {
+		name       string
+		amount     float64
+		reference  string
+		wantAmount string
+		wantRef    string
+	}{
+		{name: "montant minimal", amount: 0.01, wantAmount: "EUR0.01"},
+		{name: "montant maximal", amount: 999999999.99, wantAmount: "EUR999999999.99"},
+		{name: "montant nul omis", amount: 0, wantAmount: ""},
+		{name: "montant trop élevé omis", amount: 1e9, wantAmount: ""},
+		{name: "référence tronquée à 140 caractères", amount: 10, reference: strings.Repeat("R", 150),
+			wantAmount: "EUR10.00", wantRef: strings.Repeat("R", 140)},
+		{name: "troncature par caractère", amount: 10, reference: strings.Repeat("é", 150),
+			wantAmount: "EUR10.00", wantRef: strings.Repeat("é", 140)},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			payload := epcQRPayload(account, test.amount, test.reference)
+			fields := strings.Split(payload, "\n")
+			if len(fields) != 11 {
+				t.Fatalf("%d champs, attendu 11", len(fields))
+			}
+			if fields[4] != "" {
+				t.Errorf("BIC %q, attendu vide", fields[4])
+			}
+			if fields[5] != strings.Repeat("H", 70) {
+				t.Errorf("bénéficiaire de %d caractères, attendu 70", utf8.RuneCountInString(fields[5]))
+			}
+			if fields[7] != test.wantAmount {
+				t.Errorf("montant %q, attendu %q", fields[7], test.wantAmount)
+			}
+			if fields[10] != test.wantRef {
+				t.Errorf("référence de %d caractères, attendu %d", utf8.RuneCountInString(fields[10]), utf8.RuneCountInString(test.wantRef))
+			}
+			// Les 331 octets de la recommandation tiennent pour des champs ASCII à leur maximum
+			if utf8.RuneCountInString(payload) == len(payload) && len(payload) > 331 {
+				t.Errorf("contenu de %d octets, maximum 331", len(payload))
+			}
+		})
+	}
+}
@@ -0,0 +1,210 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"outbil/i18n"
+	"outbil/models"
+	"outbil/utils"
+	"regexp"
+	"strings"
+
+	"github.com/manifoldco/promptui"
+)
+
+var apeCodePattern = regexp.MustCompile(`^\d{2}\.?\d{2}[A-Z]$`)
+
+// capitalLegalForms sont les formes de société dont le capital social doit
+// figurer sur les documents commerciaux
+var capitalLegalForms = map[string]bool{
+	"SA": true, "SAS": true, "SASU": true, "SARL": true, "EURL": true,
+	"SCA": true, "SCI": true, "SCOP": true, "SELARL": true, "SELAS": true,
+}
+
+func requiresShareCapital(legalForm string) bool {
+	return capitalLegalForms[strings.ToUpper(strings.TrimSpace(legalForm))]
+}
+
+// checkCompanyLegalProfile vérifie que les mentions légales obligatoires sur un
+// document commercial sont renseignées
+func checkCompanyLegalProfile(company *models.Company) error {
+	if company == nil {
+		return errors.New(i18n.T("aucune entreprise configurée"))
+	}
+
+	var missing []string
+	required := []struct {
+		label, value string
+	}{
+		{i18n.T("nom"), company.Name},
+		{i18n.T("adresse"), company.Address},
+		{i18n.T("code postal"), company.PostalCode},
+		{i18n.T("ville"), company.City},
+		{"SIRET", company.SIRET},
+		{i18n.T("forme juridique"), company.LegalForm},
+	}
+	for _, field := range required {
+		if strings.TrimSpace(field.value) == "" {
+			missing = append(missing, field.label)
+		}
+	}
+
+	if requiresShareCapital(company.LegalForm) {
+		if company.ShareCapital <= 0 {
+			missing = append(missing, i18n.T("capital social"))
+		}
+		if company.RegistryType == "" || company.RegistryCity == "" {
+			missing = append(missing, i18n.T("immatriculation RCS/RM"))
+		}
+	}
+
+	if company.InsurerName != "" && company.InsurancePolicy == "" {
+		missing = append(missing, i18n.T("numéro de police d'assurance"))
+	}
+
+	if len(missing) > 0 {
+		return fmt.Errorf(i18n.T("mentions légales manquantes: %s"), strings.Join(missing, ", "))
+	}
+	return nil
+}
+
+// legalFooterLines construit les mentions légales imprimées en pied de page
+func legalFooterLines(company *models.Company, labels i18n.DocumentLabels, locale utils.Locale) []string {
+	if company == nil {
+		return nil
+	}
+
+	identity := company.Name
+	if company.LegalForm != "" {
+		identity += " " + company.LegalForm
+	}
+	if company.ShareCapital > 0 {
+		identity = fmt.Sprintf(labels.ShareCapital, identity, locale.FormatPrice(company.ShareCapital, company.Currency))
+	}
+
+	parts := []string{identity}
+	if company.RegistryType != "" && len(company.SIRET) >= 9 {
+		parts = append(parts, fmt.Sprintf("%s %s %s", company.RegistryType, company.RegistryCity, company.SIRET[:9]))
+	}
+	if company.SIRET != "" {
+		parts = append(parts, "SIRET "+company.SIRET)
+	}
+	if company.APECode != "" {
+		parts = append(parts, "APE "+company.APECode)
+	}
+	if company.TaxID != "" {
+		parts = append(parts, "TVA "+company.TaxID)
+	}
+
+	lines := []string{strings.Join(parts, " - ")}
+
+	if company.InsurerName != "" {
+		insurance := fmt.Sprintf(labels.Insurance, company.InsurerName, company.InsurancePolicy)
+		if company.InsuranceCoverage != "" {
+			insurance += fmt.Sprintf(labels.InsuranceCoverage, company.InsuranceCoverage)
+		}
+		lines = append(lines, insurance)
+	}
+
+	return lines
+}
+
+
+// promptCompanyLegalProfile demande les mentions légales de l'entreprise
+func promptCompanyLegalProfile(company *models.Company) {
+	prompt := promptui.Prompt{
+		Label:   i18n.T("Forme juridique (EI, EURL, SARL, SAS, SASU...)"),
+		Default: company.LegalForm,
+	}
+	legalForm, _ := prompt.Run()
+	company.LegalForm = strings.TrimSpace(legalForm)
+
+	if requiresShareCapital(company.LegalForm) {
+		prompt = promptui.Prompt{
+			Label:   i18n.T("Capital social"),
+			Default: fmt.Sprintf("%.0f", company.ShareCapital),
+			Validate: func(input string) error {
+				if value, err := utils.ParseFloat(input); err != nil || value <= 0 {
+					return errors.New(i18n.T("montant invalide"))
+				}
+				return nil
+			},
+		}
+		capitalStr, _ := prompt.Run()
+		company.ShareCapital, _ = utils.ParseFloat(capitalStr)
+	} else {
+		company.ShareCapital = 0
+	}
+
+	prompt = promptui.Prompt{
+		Label:   i18n.T("Registre d'immatriculation (RCS, RM ou vide)"),
+		Default: company.RegistryType,
+		Validate: func(input string) error {
+			switch strings.ToUpper(strings.TrimSpace(input)) {
+			case "", models.RegistryRCS, models.RegistryRM:
+				return nil
+			}
+			return errors.New(i18n.T("valeurs possibles: RCS, RM ou vide"))
+		},
+	}
+	registry, _ := prompt.Run()
+	company.RegistryType = strings.ToUpper(strings.TrimSpace(registry))
+
+	if company.RegistryType != "" {
+		prompt = promptui.Prompt{
+			Label:   i18n.T("Ville d'immatriculation (greffe ou chambre des métiers)"),
+			Default: company.RegistryCity,
+		}
+		company.RegistryCity, _ = prompt.Run()
+	} else {
+		company.RegistryCity = ""
+	}
+
+	prompt = promptui.Prompt{
+		Label:   i18n.T("Code APE/NAF (ex: 6201Z)"),
+		Default: company.APECode,
+		Validate: func(input string) error {
+			input = strings.ToUpper(strings.TrimSpace(input))
+			if input != "" && !apeCodePattern.MatchString(input) {
+				return errors.New(i18n.T("format attendu: 4 chiffres et une lettre (ex: 6201Z)"))
+			}
+			return nil
+		},
+	}
+	ape, _ := prompt.Run()
+	company.APECode = strings.ReplaceAll(strings.ToUpper(strings.TrimSpace(ape)), ".", "")
+
+	prompt = promptui.Prompt{
+		Label:   i18n.T("Assureur professionnel (optionnel)"),
+		Default: company.InsurerName,
+	}
+	company.InsurerName, _ = prompt.Run()
+
+	if company.InsurerName != "" {
+		prompt = promptui.Prompt{
+			Label:   i18n.T("Numéro de police d'assurance"),
+			Default: company.InsurancePolicy,
+		}
+		company.InsurancePolicy, _ = prompt.Run()
+
+		prompt = promptui.Prompt{
+			Label:   i18n.T("Couverture géographique de l'assurance"),
+			Default: company.InsuranceCoverage,
+		}
+		company.InsuranceCoverage, _ = prompt.Run()
+	} else {
+		company.InsurancePolicy = ""
+		company.InsuranceCoverage = ""
+	}
+
+	prompt = promptui.Prompt{
+		Label: i18n.T("Conditions de paiement par défaut"),
+		Default: func() string {
+			if company.PaymentTerms != "" {
+				return company.PaymentTerms
+			}
+			return "Paiement à 30 jours"
+		}(),
+	}
+	company.PaymentTerms, _ = prompt.Run()
+}
@@ -0,0 +1,151 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"outbil/db"
+	"outbil/i18n"
+	"outbil/utils"
+
+	"github.com/manifoldco/promptui"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	companyCmd.AddCommand(companyLogoCmd)
+	companyLogoCmd.AddCommand(companyLogoSetCmd)
+	companyLogoCmd.AddCommand(companyLogoShowCmd)
+	companyLogoCmd.AddCommand(companyLogoRemoveCmd)
+}
+
+var companyLogoCmd = &cobra.Command{
+	Use:   "logo",
+	Short: "Gérer le logo imprimé sur les devis",
+	Long: `Le logo est enregistré dans la base de données avec les informations de l'entreprise,
+les PDFs sont donc identiques quel que soit le dossier depuis lequel outbil est lancé.`,
+}
+
+var companyLogoSetCmd = &cobra.Command{
+	Use:   "set [FICHIER]",
+	Short: "Importer un logo (PNG, JPEG ou GIF)",
+	Long: `Importer une image comme logo de l'entreprise. L'image est contrôlée, réduite
+si elle dépasse 600x300 pixels puis enregistrée au format PNG dans la base.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		data, err := os.ReadFile(args[0])
+		if err != nil {
+			utils.Error("Impossible de lire le fichier: %v", err)
+			return
+		}
+
+		logo, err := utils.PrepareLogo(data)
+		if err != nil {
+			utils.Error("Logo invalide: %v", err)
+			return
+		}
+
+		database, err := db.New(utils.GetDatabasePath())
+		if err != nil {
+			utils.Error("Erreur d'ouverture de la base: %v", err)
+			return
+		}
+		defer database.Close()
+
+		selector, _ := cmd.Flags().GetString("company")
+		company, err := requireCompany(database, selector)
+		if err != nil {
+			utils.Error("%v", err)
+			return
+		}
+
+		if err := database.SetCompanyLogo(company.ID, logo); err != nil {
+			utils.Error("Erreur lors de l'enregistrement du logo: %v", err)
+			return
+		}
+
+		width, height, _ := utils.ImageSize(logo)
+		utils.Success("Logo enregistré (%dx%d pixels, %d Ko)", width, height, (len(logo)+1023)/1024)
+	},
+}
+
+var companyLogoShowCmd = &cobra.Command{
+	Use:   "show",
+	Short: "Afficher les caractéristiques du logo enregistré",
+	Run: func(cmd *cobra.Command, args []string) {
+		database, err := db.New(utils.GetDatabasePath())
+		if err != nil {
+			utils.Error("Erreur d'ouverture de la base: %v", err)
+			return
+		}
+		defer database.Close()
+
+		selector, _ := cmd.Flags().GetString("company")
+		company, err := requireCompany(database, selector)
+		if err != nil {
+			utils.Error("%v", err)
+			return
+		}
+
+		if len(company.Logo) == 0 {
+			utils.Info("Aucun logo enregistré. Utilisez 'outbil company logo set <fichier>'")
+			return
+		}
+
+		width, height, err := utils.ImageSize(company.Logo)
+		if err != nil {
+			utils.Error("Le logo enregistré est illisible: %v", err)
+			return
+		}
+		utils.Info("Logo: %dx%d pixels, %d Ko", width, height, (len(company.Logo)+1023)/1024)
+	},
+}
+
+var companyLogoRemoveCmd = &cobra.Command{
+	Use:   "remove",
+	Short: "Supprimer le logo",
+	Run: func(cmd *cobra.Command, args []string) {
+		database, err := db.New(utils.GetDatabasePath())
+		if err != nil {
+			utils.Error("Erreur d'ouverture de la base: %v", err)
+			return
+		}
+		defer database.Close()
+
+		selector, _ := cmd.Flags().GetString("company")
+		company, err := requireCompany(database, selector)
+		if err != nil {
+			utils.Error("%v", err)
+			return
+		}
+
+		confirm := promptui.Prompt{
+			Label:     fmt.Sprintf(i18n.T("Supprimer le logo de %s"), company.Name),
+			IsConfirm: true,
+		}
+		result, _ := confirm.Run()
+		if result != "y" {
+			utils.Info("Suppression annulée")
+			return
+		}
+
+		if err := database.SetCompanyLogo(company.ID, nil); err != nil {
+			utils.Error("Erreur lors de la suppression du logo: %v", err)
+			return
+		}
+		utils.Success("Logo supprimé")
+	},
+}
+
+// warnLegacyLogoFile signale un ancien fichier logo du dossier courant, qui
+// n'est plus utilisé depuis que le logo est enregistré en base
+func warnLegacyLogoFile(hasLogo bool) {
+	if hasLogo {
+		return
+	}
+	for _, path := range []string{"logo.png", "logo.jpg", "logo.jpeg"} {
+		if _, err := os.Stat(path); err == nil {
+			utils.Warning("Le fichier %s n'est plus lu automatiquement. Importez-le avec: outbil company logo set %s", path, path)
+			return
+		}
+	}
+}
@@ -0,0 +1,227 @@
+package cmd
+
+import (
+	"fmt"
+	"outbil/db"
+	"outbil/i18n"
+	"outbil/models"
+	"outbil/utils"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/manifoldco/promptui"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	rootCmd.AddCommand(currencyCmd)
+	currencyCmd.AddCommand(currencyListCmd)
+	currencyCmd.AddCommand(currencyRateCmd)
+	currencyRateCmd.AddCommand(currencyRateListCmd)
+	currencyRateCmd.AddCommand(currencyRateSetCmd)
+	currencyRateCmd.AddCommand(currencyRateDeleteCmd)
+
+	currencyRateSetCmd.Flags().String("base", "", "Devise de référence (par défaut la devise de l'entreprise par défaut)")
+	currencyRateSetCmd.Flags().String("from", "", "Date d'effet, au format de l'entreprise ou AAAA-MM-JJ (par défaut aujourd'hui)")
+}
+
+var currencyCmd = &cobra.Command{
+	Use:   "currency",
+	Short: "Gérer les devises et les taux de change",
+	Long: `Chaque devis est établi dans sa propre devise (par défaut celle du client, puis celle de
+l'entreprise). Les taux de change saisis localement permettent de convertir les totaux
+dans la devise de l'entreprise dans les rapports.`,
+}
+
+var currencyListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "Lister les devises prises en charge",
+	Run: func(cmd *cobra.Command, args []string) {
+		codes := utils.CurrencyCodes()
+		sort.Strings(codes)
+
+		table := utils.CreateTable()
+		table.Header("Code", i18n.T("Devise"), i18n.T("Symbole"), i18n.T("Décimales"))
+		for _, code := range codes {
+			currency, _ := utils.LookupCurrency(code)
+			table.Append([]string{currency.Code, currency.Name, currency.Symbol, strconv.Itoa(currency.Decimals)})
+		}
+		table.Render()
+	},
+}
+
+var currencyRateCmd = &cobra.Command{
+	Use:   "rate",
+	Short: "Gérer les taux de change",
+}
+
+var currencyRateListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "Lister les taux de change enregistrés",
+	Run: func(cmd *cobra.Command, args []string) {
+		database, err := db.New(utils.GetDatabasePath())
+		if err != nil {
+			utils.Error("Erreur d'ouverture de la base: %v", err)
+			return
+		}
+		defer database.Close()
+
+		rates, err := database.ListExchangeRates()
+		if err != nil {
+			utils.Error("Erreur lors de la récupération des taux: %v", err)
+			return
+		}
+
+		if len(rates) == 0 {
+			utils.Info("Aucun taux enregistré. Utilisez 'outbil currency rate set USD 0.92'")
+			return
+		}
+
+		table := utils.CreateTable()
+		table.Header("ID", i18n.T("Devise"), i18n.T("Référence"), i18n.T("Taux"), i18n.T("En vigueur le"))
+		for _, rate := range rates {
+			table.Append([]string{
+				strconv.Itoa(rate.ID),
+				rate.Currency,
+				rate.BaseCurrency,
+				fmt.Sprintf("1 %s = %s %s", rate.Currency, strconv.FormatFloat(rate.Rate, 'f', -1, 64), rate.BaseCurrency),
+				rate.EffectiveFrom.Format("02/01/2006"),
+			})
+		}
+		table.Render()
+	},
+}
+
+var currencyRateSetCmd = &cobra.Command{
+	Use:   "set [DEVISE] [TAUX]",
+	Short: "Enregistrer un taux de change",
+	Long: `Enregistrer la valeur d'une unité de DEVISE dans la devise de référence, à partir
+d'une date d'effet. Le taux le plus récent antérieur à la date d'un devis est utilisé
+pour le convertir.
+
+Exemples:
+  outbil currency rate set USD 0.92
+  outbil currency rate set GBP 1.17 --from 01/01/2025
+  outbil currency rate set EUR 0.94 --base CHF`,
+	Args: cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		base, _ := cmd.Flags().GetString("base")
+		from, _ := cmd.Flags().GetString("from")
+
+		if err := utils.ValidateCurrency(args[0]); err != nil {
+			utils.Error("%v", err)
+			return
+		}
+		value, err := utils.ParseFloat(args[1])
+		if err != nil || value <= 0 {
+			utils.Error("Taux invalide: %s", args[1])
+			return
+		}
+
+		database, err := db.New(utils.GetDatabasePath())
+		if err != nil {
+			utils.Error("Erreur d'ouverture de la base: %v", err)
+			return
+		}
+		defer database.Close()
+
+		company, err := database.GetCompany()
+		if err != nil {
+			utils.Error("Erreur lors de la récupération de l'entreprise: %v", err)
+			return
+		}
+		useCompanyLocale(company)
+		if base == "" {
+			base = companyCurrency(company)
+		}
+		if err := utils.ValidateCurrency(base); err != nil {
+			utils.Error("%v", err)
+			return
+		}
+
+		effectiveFrom := time.Now()
+		if from != "" {
+			effectiveFrom, err = utils.ParseDate(from)
+			if err != nil {
+				utils.Error("%v", err)
+				return
+			}
+		}
+
+		rate := &models.ExchangeRate{
+			Currency:      utils.NormalizeCurrency(args[0]),
+			BaseCurrency:  utils.NormalizeCurrency(base),
+			Rate:          value,
+			EffectiveFrom: effectiveFrom,
+		}
+		if rate.Currency == rate.BaseCurrency {
+			utils.Error("La devise et la devise de référence sont identiques")
+			return
+		}
+
+		if err := database.SaveExchangeRate(rate); err != nil {
+			utils.Error("Erreur lors de l'enregistrement du taux: %v", err)
+			return
+		}
+
+		utils.Success("Taux enregistré: 1 %s = %s %s à partir du %s", rate.Currency,
+			strconv.FormatFloat(rate.Rate, 'f', -1, 64), rate.BaseCurrency, rate.EffectiveFrom.Format("02/01/2006"))
+	},
+}
+
+var currencyRateDeleteCmd = &cobra.Command{
+	Use:   "delete [ID]",
+	Short: "Supprimer un taux de change",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		id, err := strconv.Atoi(args[0])
+		if err != nil {
+			utils.Error("ID invalide: %v", err)
+			return
+		}
+
+		database, err := db.New(utils.GetDatabasePath())
+		if err != nil {
+			utils.Error("Erreur d'ouverture de la base: %v", err)
+			return
+		}
+		defer database.Close()
+
+		if err := database.DeleteExchangeRate(id); err != nil {
+			utils.Error("Suppression impossible: %v", err)
+			return
+		}
+		utils.Success("Taux supprimé")
+	},
+}
+
+// companyCurrency retourne la devise de référence d'une entreprise (EUR à défaut)
+func companyCurrency(company *models.Company) string {
+	if company == nil {
+		return utils.DefaultCurrency
+	}
+	return utils.NormalizeCurrency(company.Currency)
+}
+
+// defaultQuoteCurrency propose la devise du client, puis celle de l'entreprise
+func defaultQuoteCurrency(client *models.Client, company *models.Company) string {
+	if client != nil && client.Currency != "" {
+		return utils.NormalizeCurrency(client.Currency)
+	}
+	return companyCurrency(company)
+}
+
+func promptCurrency(label, current string) string {
+	prompt := promptui.Prompt{
+		Label:    i18n.T(label),
+		Default:  current,
+		Validate: utils.ValidateCurrency,
+	}
+	result, err := prompt.Run()
+	if err != nil || strings.TrimSpace(result) == "" {
+		return utils.NormalizeCurrency(current)
+	}
+	return utils.NormalizeCurrency(result)
+}
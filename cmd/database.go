@@ -7,6 +7,7 @@ import (
 	"strings"
 
 	"outbil/db"
+	"outbil/i18n"
 	"outbil/utils"
 
 	"github.com/spf13/cobra"
@@ -62,11 +63,11 @@ var dbListCmd = &cobra.Command{
 			if info, err := os.Stat(file); err == nil {
 				size := info.Size()
 				if size < 1024 {
-					fmt.Printf("      Taille: %d octets\n", size)
+					fmt.Printf(i18n.T("      Taille: %d octets\n"), size)
 				} else if size < 1024*1024 {
-					fmt.Printf("      Taille: %.1f Ko\n", float64(size)/1024)
+					fmt.Printf(i18n.T("      Taille: %.1f Ko\n"), float64(size)/1024)
 				} else {
-					fmt.Printf("      Taille: %.1f Mo\n", float64(size)/(1024*1024))
+					fmt.Printf(i18n.T("      Taille: %.1f Mo\n"), float64(size)/(1024*1024))
 				}
 			}
 		}
@@ -212,7 +213,7 @@ var dbDeleteCmd = &cobra.Command{
 		utils.Warning("Cette action est irréversible!")
 		
 		var confirm string
-		fmt.Print("Tapez le nom de la base pour confirmer: ")
+		fmt.Print(i18n.T("Tapez le nom de la base pour confirmer: "))
 		fmt.Scanln(&confirm)
 		
 		if confirm != dbName {
@@ -0,0 +1,56 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"outbil/i18n"
+	"outbil/utils"
+
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	rootCmd.AddCommand(languageCmd)
+}
+
+var languageCmd = &cobra.Command{
+	Use:   "language [fr|en|auto]",
+	Short: "Afficher ou choisir la langue des messages",
+	Long: `Afficher ou choisir la langue des messages de la ligne de commande.
+
+Sans réglage (auto), la langue suit les variables LC_ALL, LC_MESSAGES et LANG.
+La langue des PDFs se règle séparément, par client ou par devis.`,
+	Args: cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		if len(args) == 0 {
+			configured := utils.GetLanguage()
+			fmt.Printf(i18n.T("Langue des messages: %s\n"), i18n.LanguageName(i18n.CurrentLanguage()))
+			if configured == "" {
+				utils.Info("Langue détectée depuis l'environnement (LANG)")
+			}
+			fmt.Printf(i18n.T("Langues disponibles: %s\n"), strings.Join(i18n.MessageLanguages(), ", "))
+			return
+		}
+
+		language := i18n.NormalizeLanguage(args[0])
+		if language == "auto" {
+			language = ""
+		} else if !i18n.IsMessageLanguage(language) {
+			utils.Error("Langue non disponible: %s (%s)", args[0], strings.Join(i18n.MessageLanguages(), ", "))
+			return
+		}
+
+		if err := utils.SaveLanguage(language); err != nil {
+			utils.Error("Impossible de sauvegarder la configuration: %v", err)
+			return
+		}
+
+		i18n.SetLanguage(i18n.DetectLanguage(language))
+		if language == "" {
+			utils.Success("La langue des messages suit désormais l'environnement (%s)", i18n.LanguageName(i18n.CurrentLanguage()))
+			return
+		}
+		utils.Success("Langue des messages: %s", i18n.LanguageName(i18n.CurrentLanguage()))
+	},
+}
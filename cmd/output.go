@@ -0,0 +1,368 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"outbil/db"
+	"outbil/i18n"
+	"outbil/models"
+	"outbil/utils"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"unicode"
+
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	dbCmd.AddCommand(dbOutputCmd)
+	dbOutputCmd.Flags().String("dir", "", "Dossier racine des fichiers générés (relatif au dossier courant ou absolu)")
+	dbOutputCmd.Flags().String("name", "", "Modèle de nom des fichiers, par exemple {year}/{client}/{number}.pdf")
+	dbOutputCmd.Flags().String("conflict", "", "Fichier existant d'un autre document: rename, overwrite ou fail")
+	dbOutputCmd.Flags().Bool("reset", false, "Revenir aux réglages par défaut")
+}
+
+// Emplacement et nom des fichiers générés par défaut
+const (
+	defaultOutputDir  = "quotes"
+	defaultOutputName = "{year}_{month}_devis_{seq}"
+)
+
+// Conduites face à un fichier existant généré pour un autre document ; le
+// fichier d'un même document est toujours remplacé
+const (
+	conflictRename    = "rename"    // numéroter le nouveau fichier (-2, -3...)
+	conflictOverwrite = "overwrite" // remplacer le fichier existant
+	conflictFail      = "fail"      // refuser la génération
+)
+
+var outputConflicts = []string{conflictRename, conflictOverwrite, conflictFail}
+
+// Variables des modèles de nom, avec les valeurs de l'exemple affiché
+var outputVariables = []struct{ name, sample string }{
+	{"year", "2025"},
+	{"month", "01"},
+	{"day", "15"},
+	{"number", "2025-01-0001"},
+	{"seq", "0001"},
+	{"client", "Dupont SARL"},
+	{"company", "Ma Société"},
+	{"status", models.StatusDraft},
+}
+
+var outputVariablePattern = regexp.MustCompile(`\{([^{}]*)\}`)
+
+var dbOutputCmd = &cobra.Command{
+	Use:   "output",
+	Short: "Afficher ou choisir l'emplacement et le nom des fichiers générés",
+	Long: `Afficher ou choisir, pour la base active, le dossier et le modèle de nom des
+PDFs et pages HTML générés. L'option --output de 'quote pdf' et 'quote html' les
+remplace pour une génération.
+
+Variables du modèle de nom (l'extension est ajoutée si besoin):
+  {year} {month} {day}   date du devis
+  {number}               numéro complet (2025-01-0001)
+  {seq}                  numéro dans la séquence (0001)
+  {client}               client (société ou nom)
+  {company}              entreprise émettrice
+  {status}               statut du devis
+
+Un fichier existant généré pour le même devis est remplacé. Pour un fichier
+d'un autre document, --conflict choisit de numéroter le nouveau fichier
+(rename, par défaut), de le remplacer (overwrite) ou d'échouer (fail).
+
+Exemples:
+  outbil db output --dir ~/Documents/devis --name "{year}/{client}/{number}.pdf"
+  outbil db output --conflict fail
+  outbil db output --reset`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		database, err := db.New(utils.GetDatabasePath())
+		if err != nil {
+			utils.Error("Erreur d'ouverture de la base: %v", err)
+			return
+		}
+		defer database.Close()
+
+		changes := map[string]string{}
+		if reset, _ := cmd.Flags().GetBool("reset"); reset {
+			changes[db.SettingOutputDir] = ""
+			changes[db.SettingOutputName] = ""
+			changes[db.SettingOutputConflict] = ""
+		}
+		if cmd.Flags().Changed("dir") {
+			changes[db.SettingOutputDir], _ = cmd.Flags().GetString("dir")
+		}
+		if cmd.Flags().Changed("name") {
+			name, _ := cmd.Flags().GetString("name")
+			if err := validateOutputName(name); err != nil {
+				utils.Error("%v", err)
+				return
+			}
+			if !strings.Contains(name, "{number}") && !strings.Contains(name, "{seq}") {
+				utils.Warning("Le modèle ne contient ni {number} ni {seq}: des devis différents risquent de porter le même nom")
+			}
+			changes[db.SettingOutputName] = name
+		}
+		if cmd.Flags().Changed("conflict") {
+			conflict, _ := cmd.Flags().GetString("conflict")
+			if err := validateConflict(conflict); err != nil {
+				utils.Error("%v", err)
+				return
+			}
+			changes[db.SettingOutputConflict] = conflict
+		}
+
+		for key, value := range changes {
+			if err := database.SetSetting(key, value); err != nil {
+				utils.Error("Erreur lors de la sauvegarde: %v", err)
+				return
+			}
+		}
+		if len(changes) > 0 {
+			utils.Success("Réglages des fichiers générés de la base %s enregistrés", utils.GetCurrentDatabase())
+		}
+
+		settings, err := loadOutputSettings(database)
+		if err != nil {
+			utils.Error("%v", err)
+			return
+		}
+		sample := map[string]string{}
+		for _, variable := range outputVariables {
+			sample[variable.name] = variable.sample
+		}
+		name, _ := expandOutputName(settings.Name, sample)
+		fmt.Printf(i18n.T("Dossier:     %s\n"), settings.Dir)
+		fmt.Printf(i18n.T("Nom:         %s\n"), settings.Name)
+		fmt.Printf(i18n.T("Conflits:    %s\n"), settings.Conflict)
+		fmt.Printf(i18n.T("Exemple:     %s\n"), filepath.Join(settings.Dir, name+".pdf"))
+	},
+}
+
+// outputSettings sont l'emplacement et le nom des fichiers générés d'une base
+type outputSettings struct {
+	Dir      string
+	Name     string
+	Conflict string
+}
+
+// loadOutputSettings lit les réglages de la base, complétés des valeurs par défaut
+func loadOutputSettings(database *db.Database) (outputSettings, error) {
+	settings := outputSettings{Dir: defaultOutputDir, Name: defaultOutputName, Conflict: conflictRename}
+	values := map[string]*string{
+		db.SettingOutputDir:      &settings.Dir,
+		db.SettingOutputName:     &settings.Name,
+		db.SettingOutputConflict: &settings.Conflict,
+	}
+	for key, target := range values {
+		value, err := database.GetSetting(key)
+		if err != nil {
+			return settings, fmt.Errorf(i18n.T("erreur de lecture des réglages: %w"), err)
+		}
+		if value != "" {
+			*target = value
+		}
+	}
+	return settings, nil
+}
+
+// quoteOutputPath retourne le chemin absolu du fichier d'un devis : --output
+// s'il est donné, sinon le dossier et le modèle de nom de la base, l'éventuel
+// conflit avec un fichier existant résolu selon --conflict ou la base.
+// reserved contient les chemins déjà attribués dans un même lot.
+func quoteOutputPath(cmd *cobra.Command, database *db.Database, quote *models.Quote, company *models.Company, ext string, reserved map[string]bool) (string, error) {
+	settings, err := loadOutputSettings(database)
+	if err != nil {
+		return "", err
+	}
+	if conflict, _ := cmd.Flags().GetString("conflict"); conflict != "" {
+		if err := validateConflict(conflict); err != nil {
+			return "", err
+		}
+		settings.Conflict = conflict
+	}
+
+	name, err := expandOutputName(settings.Name, quoteOutputValues(quote, company))
+	if err != nil {
+		return "", err
+	}
+	path := filepath.Join(expandHome(settings.Dir), name+ext)
+
+	// --output désigne un dossier (existant ou terminé par /) ou un fichier
+	if output, _ := cmd.Flags().GetString("output"); output != "" {
+		output = expandHome(output)
+		if info, err := os.Stat(output); strings.HasSuffix(output, string(filepath.Separator)) || strings.HasSuffix(output, "/") || err == nil && info.IsDir() {
+			path = filepath.Join(output, name+ext)
+		} else if filepath.Ext(output) == "" {
+			path = output + ext
+		} else {
+			path = output
+		}
+	}
+
+	if path, err = filepath.Abs(path); err != nil {
+		return "", err
+	}
+	return resolveOutputConflict(database, path, models.DocumentQuote, quote.ID, settings.Conflict, reserved)
+}
+
+// quoteOutputValues retourne les valeurs des variables du modèle de nom
+func quoteOutputValues(quote *models.Quote, company *models.Company) map[string]string {
+	values := map[string]string{
+		"year":   quote.Date.Format("2006"),
+		"month":  quote.Date.Format("01"),
+		"day":    quote.Date.Format("02"),
+		"number": fmt.Sprintf("%s-%s", quote.Date.Format("2006-01"), quote.QuoteNumber),
+		"seq":    quote.QuoteNumber,
+		"status": quote.Status,
+	}
+	if client := quote.Client; client != nil {
+		values["client"] = client.Name
+		if client.Company != "" {
+			values["client"] = client.Company
+		}
+	}
+	if company != nil {
+		values["company"] = company.Name
+	}
+	return values
+}
+
+// expandOutputName remplace les variables du modèle, sans extension. Les
+// valeurs ne peuvent pas ajouter de dossier : seuls les / du modèle en créent.
+func expandOutputName(template string, values map[string]string) (string, error) {
+	template = strings.TrimSuffix(strings.TrimSuffix(template, ".pdf"), ".html")
+	var unknown []string
+	name := outputVariablePattern.ReplaceAllStringFunc(template, func(match string) string {
+		variable := match[1 : len(match)-1]
+		for _, known := range outputVariables {
+			if known.name == variable {
+				return sanitizeFileName(values[variable])
+			}
+		}
+		unknown = append(unknown, match)
+		return match
+	})
+	if len(unknown) > 0 {
+		var names []string
+		for _, variable := range outputVariables {
+			names = append(names, "{"+variable.name+"}")
+		}
+		return "", fmt.Errorf(i18n.T("variable inconnue %s dans le modèle de nom (%s)"), strings.Join(unknown, ", "), strings.Join(names, " "))
+	}
+	return filepath.FromSlash(name), nil
+}
+
+// validateOutputName contrôle un modèle de nom : variables connues, chemin
+// relatif au dossier racine sans en sortir
+func validateOutputName(template string) error {
+	if strings.TrimSpace(template) == "" {
+		return errors.New(i18n.T("le modèle de nom est vide"))
+	}
+	name, err := expandOutputName(template, map[string]string{})
+	if err != nil {
+		return err
+	}
+	if filepath.IsAbs(name) || strings.HasPrefix(template, "/") {
+		return errors.New(i18n.T("le modèle de nom doit être relatif au dossier (--dir)"))
+	}
+	for _, part := range strings.Split(filepath.ToSlash(name), "/") {
+		if part == ".." {
+			return errors.New(i18n.T("le modèle de nom ne peut pas sortir du dossier (--dir)"))
+		}
+	}
+	return nil
+}
+
+func validateConflict(conflict string) error {
+	for _, known := range outputConflicts {
+		if conflict == known {
+			return nil
+		}
+	}
+	return fmt.Errorf(i18n.T("conduite invalide %q (%s)"), conflict, strings.Join(outputConflicts, ", "))
+}
+
+// sanitizeFileName remplace les caractères interdits dans un nom de fichier
+func sanitizeFileName(value string) string {
+	name := strings.Map(func(r rune) rune {
+		if unicode.IsControl(r) || strings.ContainsRune(`<>:"/\|?*`, r) {
+			return '-'
+		}
+		return r
+	}, value)
+	name = strings.Trim(name, " .")
+	if name == "" {
+		return "-"
+	}
+	return name
+}
+
+// expandHome remplace le ~ initial d'un chemin par le dossier de l'utilisateur
+func expandHome(path string) string {
+	if path != "~" && !strings.HasPrefix(path, "~/") {
+		return path
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return path
+	}
+	return filepath.Join(home, strings.TrimPrefix(path, "~"))
+}
+
+// resolveOutputConflict retourne le chemin où écrire le fichier d'un document.
+// Un fichier existant généré pour ce document est remplacé ; pour un autre
+// fichier, la conduite choisie s'applique. Un chemin réservé par un autre
+// document du lot n'est jamais écrasé.
+func resolveOutputConflict(database *db.Database, path, documentType string, documentID int, conflict string, reserved map[string]bool) (string, error) {
+	ext := filepath.Ext(path)
+	candidate := path
+	for n := 2; ; n++ {
+		if reserved[candidate] {
+			if conflict == conflictFail {
+				return "", fmt.Errorf(i18n.T("le fichier %s est déjà attribué à un autre devis du lot"), displayPath(candidate))
+			}
+			candidate = fmt.Sprintf("%s-%d%s", strings.TrimSuffix(path, ext), n, ext)
+			continue
+		}
+		if _, err := os.Stat(candidate); os.IsNotExist(err) {
+			return candidate, nil
+		} else if err != nil {
+			return "", err
+		}
+
+		// Les fichiers générés avant les chemins absolus sont enregistrés
+		// relativement au dossier courant
+		owner, err := database.FindGeneratedDocumentByPath(candidate, displayPath(candidate))
+		if err != nil {
+			return "", err
+		}
+		if owner != nil && owner.DocumentType == documentType && owner.DocumentID == documentID {
+			return candidate, nil
+		}
+
+		switch conflict {
+		case conflictOverwrite:
+			return candidate, nil
+		case conflictFail:
+			return "", fmt.Errorf(i18n.T("le fichier %s existe déjà pour un autre document (--conflict overwrite pour le remplacer)"), displayPath(candidate))
+		}
+		candidate = fmt.Sprintf("%s-%d%s", strings.TrimSuffix(path, ext), n, ext)
+	}
+}
+
+// displayPath retourne un chemin relatif au dossier courant s'il s'y trouve
+func displayPath(path string) string {
+	wd, err := os.Getwd()
+	if err != nil {
+		return path
+	}
+	relative, err := filepath.Rel(wd, path)
+	if err != nil || relative == ".." || strings.HasPrefix(relative, ".."+string(filepath.Separator)) {
+		return path
+	}
+	return relative
+}
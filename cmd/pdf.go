@@ -1,301 +1,279 @@
 package cmd
 
 import (
+	"bytes"
 	"fmt"
 	"io"
 	"os"
+	"outbil/i18n"
 	"outbil/models"
-	"path/filepath"
+	"outbil/render"
+	"outbil/utils"
 
-	"github.com/jung-kurt/gofpdf"
 	"github.com/pdfcpu/pdfcpu/pkg/api"
 )
 
-func generatePDF(quote *models.Quote, company *models.Company, filename string) error {
-	// Créer le PDF principal du devis
-	pdf := gofpdf.New("P", "mm", "A4", "")
-
-	// Activer le support UTF-8
-	pdf.SetFont("Arial", "", 12)
-	tr := pdf.UnicodeTranslatorFromDescriptor("")
-
-	// Définir les permissions : lecture et impression seulement
-	// Permissions : PermPrint = 4 (impression autorisée)
-	pdf.SetProtection(4, "", "")
-
-	pdf.AddPage()
+// generateQuotePDF met en page le devis avec le thème choisi, joint les CGV puis
+// le finalise (métadonnées, PDF/A, chiffrement, signature). En PDF/A, les
+// données du devis sont jointes au PDF en JSON. Tout est fait en mémoire : le
+// fichier n'est écrit qu'une fois le PDF final obtenu.
+func generateQuotePDF(quote *models.Quote, company *models.Company, terms *models.TermsDocument, theme *render.Theme, filename string, archive bool) error {
+	doc, content, err := renderQuotePDF(quote, company, terms, theme, archive)
+	if err != nil {
+		return err
+	}
+	return saveQuotePDF(quote, company, doc, content, filename, archive)
+}
 
-	// Vérifier si un logo existe (jpg, jpeg ou png)
-	var logoPath string
-	var logoType string
-	logoFiles := []struct{path, imgType string}{
-		{"logo.jpg", "JPG"},
-		{"logo.jpeg", "JPG"},
-		{"logo.png", "PNG"},
-	}
-	
-	for _, lf := range logoFiles {
-		if _, err := os.Stat(lf.path); err == nil {
-			logoPath = lf.path
-			logoType = lf.imgType
-			break
+// saveQuotePDF finalise le PDF rendu d'un devis et l'écrit
+func saveQuotePDF(quote *models.Quote, company *models.Company, doc *render.Document, content []byte, filename string, archive bool) error {
+	var attachments []render.Attachment
+	if archive {
+		data, err := quoteArchiveData(quote, company, doc)
+		if err != nil {
+			return err
 		}
+		attachments = append(attachments, data)
 	}
-	
-	// Insérer le logo s'il existe (ne pas décaler le reste du contenu)
-	if logoPath != "" {
-		// Obtenir les infos de l'image pour calculer les proportions
-		options := gofpdf.ImageOptions{
-			ImageType: logoType,
-			ReadDpi:   true,
-		}
-		info := pdf.RegisterImageOptions(logoPath, options)
-		if info != nil {
-			// Calculer la taille proportionnelle (largeur max 35mm, plus petit)
-			maxWidth := 35.0
-			ratio := info.Width() / info.Height()
-			width := maxWidth
-			height := width / ratio
-			
-			// Si trop haut, limiter la hauteur
-			maxHeight := 25.0
-			if height > maxHeight {
-				height = maxHeight
-				width = height * ratio
-			}
-			
-			// Positionner en haut à droite
-			x := 210 - 15 - width // A4 = 210mm, marge 15mm
-			y := 15.0
-			
-			pdf.ImageOptions(logoPath, x, y, width, height, false, options, 0, "")
-		}
+	content, err := finalizePDF(content, doc, company, archive, attachments...)
+	if err != nil {
+		return err
 	}
+	return writePDF(filename, content)
+}
 
-	// Les infos société commencent normalement à leur position habituelle
-	if company != nil {
-		pdf.SetFont("Arial", "B", 16)
-		pdf.Cell(190, 10, tr(company.Name))
-		pdf.Ln(8)
-
-		pdf.SetFont("Arial", "", 10)
-		if company.Address != "" {
-			pdf.Cell(190, 5, tr(company.Address))
-			pdf.Ln(5)
-		}
-		if company.City != "" {
-			pdf.Cell(190, 5, tr(fmt.Sprintf("%s %s", company.PostalCode, company.City)))
-			pdf.Ln(5)
-		}
-		if company.Phone != "" {
-			pdf.Cell(190, 5, tr(fmt.Sprintf("Tél: %s", company.Phone)))
-			pdf.Ln(5)
-		}
-		if company.Email != "" {
-			pdf.Cell(190, 5, tr(fmt.Sprintf("Email: %s", company.Email)))
-			pdf.Ln(5)
-		}
-		if company.TaxID != "" {
-			pdf.Cell(190, 5, tr(fmt.Sprintf("N° TVA: %s", company.TaxID)))
-			pdf.Ln(5)
-		}
+// renderQuotePDF met en page le devis, le tamponne et lui joint les CGV, sans
+// le finaliser
+func renderQuotePDF(quote *models.Quote, company *models.Company, terms *models.TermsDocument, theme *render.Theme, archive bool) (*render.Document, []byte, error) {
+	doc := quoteDocument(quote, company)
+	if archive {
+		theme = theme.Archival()
 	}
+	content, err := render.New(theme).Render(doc)
+	if err != nil {
+		return nil, nil, err
+	}
+	// Filigrane du statut (brouillon...), sur le devis seulement et pas sur les CGV
+	if content, err = render.Stamp(content, theme, doc); err != nil {
+		return nil, nil, err
+	}
+	if content, err = appendTerms(content, terms); err != nil {
+		return nil, nil, err
+	}
+	return doc, content, nil
+}
 
-	pdf.Ln(10)
-	pdf.SetFont("Arial", "B", 20)
-	pdf.Cell(190, 10, tr("DEVIS"))
-	pdf.Ln(10)
-
-	// Afficher le numéro avec préfixe année-mois en police monospace plus petite
-	pdf.SetFont("Courier", "", 12)
-	yearMonth := quote.Date.Format("2006-01")
-	pdf.Cell(190, 5, fmt.Sprintf("%s-%s", yearMonth, quote.QuoteNumber))
-	pdf.Ln(10)
-
-	pdf.SetFont("Arial", "", 10)
-	pdf.Cell(95, 5, tr(fmt.Sprintf("Date: %s", quote.Date.Format("02/01/2006"))))
-	pdf.Cell(95, 5, tr(fmt.Sprintf("Valable jusqu'au: %s", quote.ValidUntil.Format("02/01/2006"))))
-	pdf.Ln(10)
-
-	pdf.SetFont("Arial", "B", 12)
-	pdf.Cell(190, 5, tr("CLIENT:"))
-	pdf.Ln(5)
-
-	pdf.SetFont("Arial", "", 10)
-	pdf.Cell(190, 5, tr(quote.Client.Name))
-	pdf.Ln(5)
-	if quote.Client.Company != "" && quote.Client.Company != quote.Client.Name {
-		pdf.Cell(190, 5, tr(quote.Client.Company))
-		pdf.Ln(5)
-	}
-	if quote.Client.Address != "" {
-		pdf.Cell(190, 5, tr(quote.Client.Address))
-		pdf.Ln(5)
-	}
-	pdf.Cell(190, 5, tr(fmt.Sprintf("%s %s", quote.Client.PostalCode, quote.Client.City)))
-	pdf.Ln(5)
-	if quote.Client.TaxID != "" {
-		pdf.Cell(190, 5, tr(fmt.Sprintf("N° TVA: %s", quote.Client.TaxID)))
-		pdf.Ln(5)
+// writePDF écrit un PDF dans un fichier temporaire renommé une fois complet :
+// un fichier existant n'est jamais remplacé par un PDF tronqué
+func writePDF(filename string, content []byte) error {
+	tempFile := filename + ".temp"
+	if err := os.WriteFile(tempFile, content, 0644); err != nil {
+		os.Remove(tempFile)
+		return fmt.Errorf(i18n.T("erreur lors de la sauvegarde du PDF: %w"), err)
 	}
+	if err := os.Rename(tempFile, filename); err != nil {
+		os.Remove(tempFile)
+		return fmt.Errorf(i18n.T("erreur lors de la sauvegarde du PDF: %w"), err)
+	}
+	return nil
+}
 
-	pdf.Ln(10)
+// quoteDocument prépare le contenu imprimé d'un devis : libellés traduits,
+// montants et dates formatés selon la locale du document
+func quoteDocument(quote *models.Quote, company *models.Company) *render.Document {
+	labels, locale := documentFormat(quote, company)
+	number := fmt.Sprintf("%s-%s", quote.Date.Format("2006-01"), quote.QuoteNumber)
+
+	doc := &render.Document{
+		Title:       labels.QuoteTitle,
+		Number:      number,
+		Date:        fmt.Sprintf(labels.Date, locale.FormatDate(quote.Date)),
+		ValidUntil:  fmt.Sprintf(labels.ValidUntil, locale.FormatDate(quote.ValidUntil)),
+		ClientTitle: labels.Client,
+		ClientLines: clientBlockLines(quote, labels),
+		Headers: map[string]string{
+			render.FieldDescription: labels.Description,
+			render.FieldQuantity:    labels.Quantity,
+			render.FieldUnitPrice:   labels.UnitPrice,
+			render.FieldTaxRate:     labels.TaxRate,
+			render.FieldAmount:      labels.LineTotal,
+		},
+		NotesTitle: labels.Notes,
+		Notes:      quote.Notes,
+		TermsTitle: labels.Conditions,
+		Terms:      quote.Terms,
+		Footer:     legalFooterLines(company, labels, locale),
+		Reference:  fmt.Sprintf(labels.QuoteReference, number),
+		PageNumber: labels.PageNumber,
+
+		CarriedForward: labels.CarriedForward,
+		BroughtForward: labels.BroughtForward,
+
+		Status: quote.Status,
+		Watermarks: map[string]string{
+			render.WatermarkDraft:     labels.Draft,
+			render.WatermarkCancelled: labels.Cancelled,
+		},
+
+		Quote:     quote,
+		Client:    quote.Client,
+		Company:   company,
+		Locale:    locale,
+		Currency:  quote.Currency,
+		Subtotal:  quote.TotalAmount - quote.TaxAmount + quote.Discount,
+		Discount:  quote.Discount,
+		TaxAmount: quote.TaxAmount,
+		Total:     quote.TotalAmount,
+	}
 
-	// Tableau des articles avec largeurs ajustées
-	pdf.SetFont("Arial", "B", 10)
-	pdf.SetFillColor(240, 240, 240)
-	pdf.CellFormat(80, 8, tr("Description"), "1", 0, "L", true, 0, "")
-	pdf.CellFormat(20, 8, tr("Qté"), "1", 0, "C", true, 0, "")
-	pdf.CellFormat(30, 8, tr("PU HT"), "1", 0, "R", true, 0, "")
-	pdf.CellFormat(20, 8, tr("TVA"), "1", 0, "C", true, 0, "")
-	pdf.CellFormat(40, 8, tr("Total HT"), "1", 0, "R", true, 0, "")
-	pdf.Ln(8)
+	if quote.DeliveryAddress != nil {
+		doc.DeliveryTitle = labels.Delivery
+		doc.DeliveryLines = addressLines(quote.DeliveryAddress)
+	}
 
-	pdf.SetFont("Arial", "", 9)
 	for _, item := range quote.Items {
-		// Calcul de la hauteur nécessaire pour la description
-		lineHt := 5.0
-		lines := pdf.SplitLines([]byte(tr(item.Description)), 80)
-		cellHeight := float64(len(lines)) * lineHt
-		if cellHeight < 8 {
-			cellHeight = 8
-		}
-
-		// Position actuelle
-		x, y := pdf.GetXY()
-
-		// Dessiner d'abord toutes les cellules sans texte pour avoir les bordures alignées
-		pdf.CellFormat(80, cellHeight, "", "1", 0, "L", false, 0, "")
-		pdf.CellFormat(20, cellHeight, fmt.Sprintf("%.2f", item.Quantity), "1", 0, "C", false, 0, "")
-		pdf.CellFormat(30, cellHeight, fmt.Sprintf("%.2f", item.UnitPrice), "1", 0, "R", false, 0, "")
-		pdf.CellFormat(20, cellHeight, fmt.Sprintf("%.0f%%", item.TaxRate), "1", 0, "C", false, 0, "")
-		pdf.CellFormat(40, cellHeight, fmt.Sprintf("%.2f", item.Amount), "1", 0, "R", false, 0, "")
-		
-		// Revenir à la position de départ pour écrire la description
-		pdf.SetXY(x, y)
-		
-		// Écrire la description dans la première cellule (sans bordure car déjà dessinée)
-		pdf.MultiCell(80, lineHt, tr(item.Description), "", "L", false)
-		
-		// Se positionner pour la ligne suivante
-		pdf.SetXY(x, y+cellHeight)
+		doc.Items = append(doc.Items, render.Item{
+			Description: item.Description,
+			Quantity:    locale.FormatQuantity(item.Quantity),
+			UnitPrice:   locale.FormatAmount(item.UnitPrice, quote.Currency),
+			TaxRate:     locale.FormatPercent(item.TaxRate),
+			Amount:      locale.FormatAmount(item.Amount, quote.Currency),
+			Net:         item.Amount,
+		})
 	}
 
-	pdf.Ln(5)
-
-	subtotal := quote.TotalAmount - quote.TaxAmount + quote.Discount
-
-	// Totaux alignés correctement
-	pdf.SetFont("Arial", "", 10)
-	pdf.Cell(115, 5, "")
-	pdf.Cell(40, 5, tr("Sous-total HT:"))
-	pdf.CellFormat(35, 5, fmt.Sprintf("%.2f EUR", subtotal), "", 0, "R", false, 0, "")
-	pdf.Ln(5)
-
+	doc.Totals = append(doc.Totals, render.Total{Label: labels.Subtotal, Value: locale.FormatPrice(doc.Subtotal, quote.Currency)})
 	if quote.Discount > 0 {
-		pdf.Cell(115, 5, "")
-		pdf.Cell(40, 5, tr("Remise:"))
-		pdf.CellFormat(35, 5, fmt.Sprintf("%.2f EUR", quote.Discount), "", 0, "R", false, 0, "")
-		pdf.Ln(5)
+		doc.Totals = append(doc.Totals, render.Total{Label: labels.Discount, Value: locale.FormatPrice(quote.Discount, quote.Currency)})
 	}
+	doc.Totals = append(doc.Totals,
+		render.Total{Label: labels.TaxAmount, Value: locale.FormatPrice(quote.TaxAmount, quote.Currency)},
+		render.Total{Label: labels.Total, Value: locale.FormatPrice(quote.TotalAmount, quote.Currency), Grand: true},
+	)
 
-	pdf.Cell(115, 5, "")
-	pdf.Cell(40, 5, tr("TVA:"))
-	pdf.CellFormat(35, 5, fmt.Sprintf("%.2f EUR", quote.TaxAmount), "", 0, "R", false, 0, "")
-	pdf.Ln(5)
+	if company == nil {
+		return doc
+	}
+	doc.CompanyName = company.Name
+	doc.CompanyLines = companyBlockLines(company, labels)
+	doc.Logo = company.Logo
+
+	// Coordonnées bancaires et QR code de virement SEPA (en euros uniquement)
+	if account := company.DefaultBankAccount(); account != nil {
+		doc.PaymentTitle = labels.BankTransfer
+		doc.PaymentLines = bankDetailsLines(account, doc.Reference, labels)
+		if utils.NormalizeCurrency(quote.Currency) == "EUR" {
+			doc.PaymentQR = epcQRPayload(account, quote.TotalAmount, doc.Reference)
+		}
+	}
 
-	pdf.SetFont("Arial", "B", 12)
-	pdf.Cell(115, 7, "")
-	pdf.Cell(40, 7, tr("TOTAL TTC:"))
-	pdf.CellFormat(35, 7, fmt.Sprintf("%.2f EUR", quote.TotalAmount), "", 0, "R", false, 0, "")
-	pdf.Ln(15)
+	return doc
+}
 
-	if quote.Notes != "" {
-		pdf.SetFont("Arial", "B", 10)
-		pdf.Cell(190, 5, tr("Notes:"))
-		pdf.Ln(5)
-		pdf.SetFont("Arial", "", 9)
-		pdf.MultiCell(190, 5, tr(quote.Notes), "", "", false)
-		pdf.Ln(5)
+// companyBlockLines construit les coordonnées de l'entreprise imprimées sous son nom
+func companyBlockLines(company *models.Company, labels i18n.DocumentLabels) []string {
+	var lines []string
+	if company.Address != "" {
+		lines = append(lines, company.Address)
 	}
-
-	if quote.Terms != "" {
-		pdf.SetFont("Arial", "B", 10)
-		pdf.Cell(190, 5, tr("Conditions:"))
-		pdf.Ln(5)
-		pdf.SetFont("Arial", "", 9)
-		pdf.MultiCell(190, 5, tr(quote.Terms), "", "", false)
+	if company.City != "" {
+		lines = append(lines, fmt.Sprintf("%s %s", company.PostalCode, company.City))
+	}
+	if company.Phone != "" {
+		lines = append(lines, fmt.Sprintf(labels.Phone, company.Phone))
+	}
+	if company.Email != "" {
+		lines = append(lines, fmt.Sprintf(labels.Email, company.Email))
 	}
+	if company.SIRET != "" {
+		lines = append(lines, fmt.Sprintf(labels.SIRET, company.SIRET))
+	}
+	if company.TaxID != "" {
+		lines = append(lines, fmt.Sprintf(labels.VATNumber, company.TaxID))
+	}
+	return lines
+}
 
-	// Sauvegarder le PDF temporairement
-	tempFile := filename + ".temp"
-	err := pdf.OutputFileAndClose(tempFile)
-	if err != nil {
-		return err
+// appendTerms fusionne les CGV retenues à la fin du PDF
+func appendTerms(content []byte, terms *models.TermsDocument) ([]byte, error) {
+	if terms == nil || len(terms.Content) == 0 {
+		return content, nil
+	}
+	var merged bytes.Buffer
+	files := []io.ReadSeeker{bytes.NewReader(content), bytes.NewReader(terms.Content)}
+	if err := api.MergeRaw(files, &merged, false, nil); err != nil {
+		return nil, fmt.Errorf(i18n.T("erreur lors de la fusion avec les CGV: %w"), err)
 	}
+	return merged.Bytes(), nil
+}
 
-	// Vérifier si @cgv.pdf existe
-	cgvPath := "cgv.pdf"
-	if _, err := os.Stat(cgvPath); err == nil {
-		// Fusionner avec les CGV
-		err = mergePDFs(tempFile, cgvPath, filename)
-		os.Remove(tempFile) // Nettoyer le fichier temporaire
-		if err != nil {
-			return fmt.Errorf("erreur lors de la fusion avec les CGV: %w", err)
-		}
-	} else {
-		// Pas de CGV, renommer le fichier temporaire
-		err = os.Rename(tempFile, filename)
-		if err != nil {
-			return err
-		}
+// documentFormat retourne les libellés et la locale d'un document : la langue
+// du devis, à défaut celle du client, à défaut celle de l'entreprise
+func documentFormat(quote *models.Quote, company *models.Company) (i18n.DocumentLabels, utils.Locale) {
+	preferred := companyLocale(company)
+
+	language := quote.Language
+	if language == "" && quote.Client != nil {
+		language = quote.Client.Language
+	}
+	if language == "" {
+		language = preferred.Language()
 	}
 
-	return nil
+	labels := i18n.Document(language)
+	return labels, utils.LocaleForLanguage(labels.Language, preferred.Code)
 }
 
-func mergePDFs(quotePDF, cgvPDF, outputPDF string) error {
-	// Créer un dossier temporaire pour l'opération
-	tempDir, err := os.MkdirTemp("", "outbil_merge_")
-	if err != nil {
-		return err
+// clientBlockLines construit les lignes du bloc CLIENT : société, contact
+// destinataire, adresse de facturation retenue (ou adresse principale) et TVA
+func clientBlockLines(quote *models.Quote, labels i18n.DocumentLabels) []string {
+	client := quote.Client
+	if client == nil {
+		return nil
 	}
-	defer os.RemoveAll(tempDir)
 
-	// Copier les fichiers dans le dossier temporaire
-	tempQuote := filepath.Join(tempDir, "quote.pdf")
-	tempCGV := filepath.Join(tempDir, "cgv.pdf")
+	lines := []string{client.Name}
+	if client.Company != "" && client.Company != client.Name {
+		lines = append(lines, client.Company)
+	}
 
-	if err := copyFile(quotePDF, tempQuote); err != nil {
-		return err
+	if quote.Contact != nil {
+		contact := fmt.Sprintf(labels.Attention, quote.Contact.Name)
+		if quote.Contact.Role != "" {
+			contact += fmt.Sprintf(" (%s)", quote.Contact.Role)
+		}
+		lines = append(lines, contact)
 	}
-	if err := copyFile(cgvPDF, tempCGV); err != nil {
-		return err
+
+	if quote.BillingAddress != nil {
+		lines = append(lines, addressLines(quote.BillingAddress)...)
+	} else {
+		if client.Address != "" {
+			lines = append(lines, client.Address)
+		}
+		lines = append(lines, fmt.Sprintf("%s %s", client.PostalCode, client.City))
 	}
 
-	// Fusionner les PDFs
-	inFiles := []string{tempQuote, tempCGV}
-	err = api.MergeCreateFile(inFiles, outputPDF, false, nil)
-	if err != nil {
-		return fmt.Errorf("erreur lors de la fusion PDF: %w", err)
+	if client.SIRET != "" {
+		lines = append(lines, fmt.Sprintf(labels.SIRET, client.SIRET))
+	} else if client.SIREN != "" {
+		lines = append(lines, fmt.Sprintf(labels.SIREN, client.SIREN))
+	}
+	if client.TaxID != "" {
+		lines = append(lines, fmt.Sprintf(labels.VATNumber, client.TaxID))
 	}
 
-	return nil
+	return lines
 }
 
-func copyFile(src, dst string) error {
-	sourceFile, err := os.Open(src)
-	if err != nil {
-		return err
+func addressLines(address *models.ClientAddress) []string {
+	var lines []string
+	if address.Label != "" {
+		lines = append(lines, address.Label)
 	}
-	defer sourceFile.Close()
-
-	destFile, err := os.Create(dst)
-	if err != nil {
-		return err
+	if address.Address != "" {
+		lines = append(lines, address.Address)
 	}
-	defer destFile.Close()
-
-	_, err = io.Copy(destFile, sourceFile)
-	return err
+	lines = append(lines, fmt.Sprintf("%s %s", address.PostalCode, address.City))
+	return lines
 }
@@ -0,0 +1,56 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"outbil/models"
+	"outbil/render"
+	"outbil/utils"
+	"time"
+)
+
+// quoteArchive est le contenu JSON joint aux devis archivés en PDF/A
+type quoteArchive struct {
+	Document string          `json:"document"`
+	Number   string          `json:"number"`
+	Company  *models.Company `json:"company,omitempty"`
+	Quote    *models.Quote   `json:"quote"`
+}
+
+// quoteArchiveData retourne les données structurées du devis, jointes au PDF/A
+// pour qu'elles restent exploitables sans ressaisie
+func quoteArchiveData(quote *models.Quote, company *models.Company, doc *render.Document) (render.Attachment, error) {
+	archive := quoteArchive{Document: models.DocumentQuote, Number: doc.Number, Quote: quote}
+	if company != nil {
+		// Sans le logo, déjà imprimé, ni le chemin local du certificat
+		seller := *company
+		seller.Logo = nil
+		seller.PDFCertificate = ""
+		archive.Company = &seller
+	}
+	data, err := json.MarshalIndent(archive, "", "  ")
+	if err != nil {
+		return render.Attachment{}, err
+	}
+	return render.Attachment{
+		Name:        doc.Number + ".json",
+		Description: doc.Reference,
+		MimeType:    "application/json",
+		Data:        data,
+		ModTime:     time.Now(),
+	}, nil
+}
+
+// printConformance détaille les écarts d'un PDF/A refusé
+func printConformance(err error) bool {
+	var conformance *render.ConformanceError
+	if !errors.As(err, &conformance) {
+		return false
+	}
+	utils.Error("PDF non conforme à PDF/A-3b, il n'a pas été écrit:")
+	for _, problem := range conformance.Problems {
+		fmt.Printf("  - %s\n", problem)
+	}
+	return true
+}
@@ -0,0 +1,231 @@
+package cmd
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"outbil/db"
+	"outbil/i18n"
+	"outbil/models"
+	"outbil/render"
+	"outbil/utils"
+	"strings"
+
+	"github.com/manifoldco/promptui"
+	"github.com/pdfcpu/pdfcpu/pkg/api"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	companyCmd.AddCommand(companyProtectionCmd)
+	companyProtectionCmd.Flags().Bool("owner-password", false, "Saisir le mot de passe propriétaire (vide: aléatoire à chaque PDF)")
+	companyProtectionCmd.Flags().Bool("user-password", false, "Saisir le mot de passe d'ouverture des PDFs (vide: aucun)")
+}
+
+var companyProtectionCmd = &cobra.Command{
+	Use:   "protection [off|print|read|all]",
+	Short: "Afficher ou choisir la protection des PDFs de l'entreprise",
+	Long: `Afficher ou choisir la protection appliquée aux PDFs générés, par chiffrement :
+  off     aucune protection
+  print   lecture et impression, modification interdite (par défaut)
+  read    lecture seule, ni impression ni modification
+  all     aucune restriction (avec --user-password pour exiger un mot de passe)
+
+Les mots de passe sont saisis sans être affichés. Sans mot de passe
+propriétaire, un mot de passe aléatoire est tiré pour chaque PDF : personne ne
+peut alors lever les restrictions. Une saisie vide supprime le mot de passe
+enregistré.
+
+Les mots de passe sont enregistrés en clair dans la base, pour protéger les
+PDFs générés sans nouvelle saisie : quiconque lit la base peut ouvrir les PDFs.
+
+Exemples:
+  outbil company protection print
+  outbil company protection all --user-password
+  outbil company protection print --owner-password`,
+	Args: cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		database, err := db.New(utils.GetDatabasePath())
+		if err != nil {
+			utils.Error("Erreur d'ouverture de la base: %v", err)
+			return
+		}
+		defer database.Close()
+
+		selector, _ := cmd.Flags().GetString("company")
+		company, err := requireCompany(database, selector)
+		if err != nil {
+			utils.Error("%v", err)
+			return
+		}
+
+		ownerChanged, _ := cmd.Flags().GetBool("owner-password")
+		userChanged, _ := cmd.Flags().GetBool("user-password")
+		if len(args) == 0 && !ownerChanged && !userChanged {
+			fmt.Printf(i18n.T("Protection: %s\n"), pdfProtectionSummary(company))
+			return
+		}
+
+		if len(args) == 1 {
+			protection := strings.ToLower(strings.TrimSpace(args[0]))
+			if !isPDFProtection(protection) {
+				utils.Error("Protection %q inconnue (%s)", args[0], strings.Join(models.PDFProtections, ", "))
+				return
+			}
+			company.PDFProtection = protection
+		}
+		if ownerChanged {
+			if company.PDFOwnerPassword, err = promptPassword(i18n.T("Mot de passe propriétaire")); err != nil {
+				utils.Info("Modifications annulées")
+				return
+			}
+		}
+		if userChanged {
+			if company.PDFUserPassword, err = promptPassword(i18n.T("Mot de passe d'ouverture")); err != nil {
+				utils.Info("Modifications annulées")
+				return
+			}
+		}
+		if company.PDFProtection == models.PDFProtectionAll && company.PDFUserPassword == "" {
+			utils.Warning("Sans mot de passe d'ouverture, la protection \"all\" ne restreint rien")
+		}
+
+		if err := database.SaveCompany(company); err != nil {
+			utils.Error("Erreur lors de la sauvegarde: %v", err)
+			return
+		}
+		utils.Success("Protection des PDFs de %s: %s", company.Name, pdfProtectionSummary(company))
+		if company.PDFOwnerPassword != "" || company.PDFUserPassword != "" {
+			utils.Warning("Les mots de passe des PDFs sont enregistrés en clair dans %s", utils.GetDatabasePath())
+		}
+	},
+}
+
+// promptPassword demande un mot de passe sans l'afficher ; une saisie vide est
+// acceptée
+func promptPassword(label string) (string, error) {
+	prompt := promptui.Prompt{Label: label, Mask: '*'}
+	return prompt.Run()
+}
+
+func isPDFProtection(protection string) bool {
+	for _, candidate := range models.PDFProtections {
+		if candidate == protection {
+			return true
+		}
+	}
+	return false
+}
+
+// pdfProtectionSummary décrit la protection des PDFs d'une entreprise
+func pdfProtectionSummary(company *models.Company) string {
+	var summary string
+	switch company.PDFProtection {
+	case models.PDFProtectionOff:
+		return i18n.T("aucune")
+	case models.PDFProtectionRead:
+		summary = i18n.T("lecture seule")
+	case models.PDFProtectionAll:
+		summary = i18n.T("sans restriction")
+	default:
+		summary = i18n.T("impression autorisée, modification interdite")
+	}
+	if company.PDFUserPassword != "" {
+		summary += i18n.T(", mot de passe d'ouverture")
+	}
+	if company.PDFOwnerPassword != "" {
+		summary += i18n.T(", mot de passe propriétaire")
+	}
+	return summary
+}
+
+// finalizePDF renseigne les métadonnées du PDF (titre, auteur, sujet), le
+// convertit en PDF/A si demandé, le chiffre selon la protection choisie pour
+// l'entreprise puis le signe si elle a un certificat : la signature, dernière,
+// couvre le document final.
+func finalizePDF(content []byte, doc *render.Document, company *models.Company, archive bool, attachments ...render.Attachment) ([]byte, error) {
+	var described bytes.Buffer
+	if err := api.AddProperties(bytes.NewReader(content), &described, pdfMetadata(doc), nil); err != nil {
+		return nil, fmt.Errorf(i18n.T("erreur lors de l'écriture des métadonnées du PDF: %w"), err)
+	}
+	content = described.Bytes()
+
+	var err error
+	if archive {
+		if content, err = render.Archive(content, attachments...); err != nil {
+			return nil, err
+		}
+	}
+
+	var conf *model.Configuration
+	if archive && company != nil && company.EffectivePDFProtection() != models.PDFProtectionOff {
+		// PDF/A interdit le chiffrement
+		utils.Warning("PDF/A: protection %s ignorée, la norme interdit le chiffrement", company.EffectivePDFProtection())
+	} else if company != nil && company.EffectivePDFProtection() != models.PDFProtectionOff {
+		if conf, err = pdfEncryption(company); err != nil {
+			return nil, err
+		}
+		var encrypted bytes.Buffer
+		if err := api.Encrypt(bytes.NewReader(content), &encrypted, conf); err != nil {
+			return nil, fmt.Errorf(i18n.T("erreur lors du chiffrement du PDF: %w"), err)
+		}
+		content = encrypted.Bytes()
+	}
+
+	if company != nil && company.PDFCertificate != "" {
+		if content, err = signPDF(content, company, conf); err != nil {
+			return nil, err
+		}
+	}
+	return content, nil
+}
+
+// pdfMetadata retourne les propriétés du PDF d'un document
+func pdfMetadata(doc *render.Document) map[string]string {
+	properties := map[string]string{
+		"Title":   doc.Reference,
+		"Subject": doc.Reference,
+		"Creator": "outbil",
+	}
+	keywords := []string{doc.Number}
+	if doc.Company != nil {
+		properties["Author"] = doc.Company.Name
+	}
+	if client := doc.Client; client != nil {
+		name := client.Name
+		if client.Company != "" {
+			name = client.Company
+		}
+		properties["Subject"] = fmt.Sprintf("%s - %s", doc.Reference, name)
+		keywords = append(keywords, name)
+	}
+	properties["Keywords"] = strings.Join(keywords, ", ")
+	return properties
+}
+
+// pdfEncryption prépare le chiffrement AES-256 et les permissions des PDFs
+// d'une entreprise
+func pdfEncryption(company *models.Company) (*model.Configuration, error) {
+	owner := company.PDFOwnerPassword
+	if owner == "" {
+		// Mot de passe jetable : les restrictions ne peuvent pas être levées
+		random := make([]byte, 16)
+		if _, err := rand.Read(random); err != nil {
+			return nil, err
+		}
+		owner = hex.EncodeToString(random)
+	}
+
+	conf := model.NewAESConfiguration(company.PDFUserPassword, owner, 256)
+	switch company.PDFProtection {
+	case models.PDFProtectionRead:
+		conf.Permissions = model.PermissionsNone
+	case models.PDFProtectionAll:
+		conf.Permissions = model.PermissionsAll
+	default:
+		conf.Permissions = model.PermissionsPrint
+	}
+	return conf, nil
+}
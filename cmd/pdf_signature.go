@@ -0,0 +1,284 @@
+package cmd
+
+import (
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"os"
+	"outbil/db"
+	"outbil/i18n"
+	"outbil/models"
+	"outbil/signature"
+	"outbil/utils"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	companyCmd.AddCommand(companySignatureCmd)
+
+	rootCmd.AddCommand(pdfCmd)
+	pdfCmd.AddCommand(pdfVerifyCmd)
+	pdfVerifyCmd.Flags().String("password", "", "Mot de passe d'ouverture du PDF")
+	pdfVerifyCmd.Flags().StringArray("trust", nil, "Certificat d'autorité de confiance supplémentaire (PEM ou DER)")
+}
+
+var companySignatureCmd = &cobra.Command{
+	Use:   "signature [FICHIER.p12|off]",
+	Short: "Afficher ou choisir le certificat de signature des PDFs de l'entreprise",
+	Long: `Afficher ou choisir le certificat PKCS#12 (.p12, .pfx) avec lequel les PDFs
+générés sont signés. La signature, au format PAdES, est ajoutée après la jonction
+des CGV et le chiffrement : toute modification ultérieure du fichier est détectée
+par les lecteurs PDF et par 'outbil pdf verify'.
+
+Le certificat reste à son emplacement ; seul son chemin est enregistré. Son mot
+de passe, saisi sans être affiché, n'est jamais enregistré : il est demandé pour
+valider le certificat, puis une fois par commande qui signe des PDFs. "off"
+désactive la signature.
+
+Exemples:
+  outbil company signature ~/certificats/entreprise.p12
+  outbil company signature off`,
+	Args: cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		database, err := db.New(utils.GetDatabasePath())
+		if err != nil {
+			utils.Error("Erreur d'ouverture de la base: %v", err)
+			return
+		}
+		defer database.Close()
+
+		selector, _ := cmd.Flags().GetString("company")
+		company, err := requireCompany(database, selector)
+		if err != nil {
+			utils.Error("%v", err)
+			return
+		}
+
+		if len(args) == 0 {
+			fmt.Printf(i18n.T("Signature: %s\n"), pdfSignatureSummary(company, nil))
+			return
+		}
+
+		var signer *signature.Signer
+		if strings.EqualFold(args[0], "off") {
+			company.PDFCertificate = ""
+		} else {
+			path, err := filepath.Abs(args[0])
+			if err != nil {
+				utils.Error("%v", err)
+				return
+			}
+			// Le certificat est ouvert pour le valider avant d'être enregistré
+			if signer, err = openCertificate(path); err != nil {
+				utils.Error("Certificat invalide: %v", err)
+				return
+			}
+			company.PDFCertificate = path
+		}
+
+		if err := database.SaveCompany(company); err != nil {
+			utils.Error("Erreur lors de la sauvegarde: %v", err)
+			return
+		}
+		utils.Success("Signature des PDFs de %s: %s", company.Name, pdfSignatureSummary(company, signer))
+	},
+}
+
+// pdfSignatureSummary décrit le certificat de signature d'une entreprise. Sans
+// certificat ouvert, seul son chemin est connu s'il a un mot de passe.
+func pdfSignatureSummary(company *models.Company, signer *signature.Signer) string {
+	if company.PDFCertificate == "" {
+		return i18n.T("aucune")
+	}
+	if signer == nil {
+		if _, err := os.Stat(company.PDFCertificate); err != nil {
+			return fmt.Sprintf(i18n.T("%s (illisible: %v)"), company.PDFCertificate, err)
+		}
+		var err error
+		if signer, err = signature.Load(company.PDFCertificate, ""); err != nil {
+			return fmt.Sprintf(i18n.T("%s (mot de passe demandé à la signature)"), company.PDFCertificate)
+		}
+	}
+	cert := signer.Certificate
+	return fmt.Sprintf(i18n.T("%s, émis par %s, valable jusqu'au %s (%s)"),
+		signature.Name(cert), signature.Name(&x509.Certificate{Subject: cert.Issuer}),
+		cert.NotAfter.Format("2006-01-02"), company.PDFCertificate)
+}
+
+// certificateSigners garde les certificats ouverts pendant la commande, par
+// chemin : le mot de passe n'est demandé qu'une fois
+var (
+	certificateSigners   = map[string]*signature.Signer{}
+	certificateSignersMu sync.Mutex
+)
+
+// companySigner ouvre le certificat de signature de l'entreprise, en demandant
+// son mot de passe à la première signature de la commande
+func companySigner(company *models.Company) (*signature.Signer, error) {
+	certificateSignersMu.Lock()
+	defer certificateSignersMu.Unlock()
+	if signer, ok := certificateSigners[company.PDFCertificate]; ok {
+		return signer, nil
+	}
+	signer, err := openCertificate(company.PDFCertificate)
+	if err != nil {
+		return nil, err
+	}
+	certificateSigners[company.PDFCertificate] = signer
+	return signer, nil
+}
+
+// openCertificate ouvre un certificat PKCS#12, en demandant son mot de passe
+// s'il en a un
+func openCertificate(path string) (*signature.Signer, error) {
+	if _, err := os.Stat(path); err != nil {
+		return nil, fmt.Errorf(i18n.T("erreur de lecture du certificat: %w"), err)
+	}
+	if signer, err := signature.Load(path, ""); err == nil {
+		return signer, nil
+	}
+	password, err := promptPassword(fmt.Sprintf(i18n.T("Mot de passe du certificat %s"), filepath.Base(path)))
+	if err != nil {
+		return nil, err
+	}
+	return signature.Load(path, password)
+}
+
+// signPDF signe le PDF final avec le certificat de l'entreprise. Un PDF
+// chiffré est relu avec la configuration de son chiffrement.
+func signPDF(content []byte, company *models.Company, conf *model.Configuration) ([]byte, error) {
+	signer, err := companySigner(company)
+	if err != nil {
+		return nil, err
+	}
+	return signer.Sign(content, signature.Options{Location: company.City}, conf)
+}
+
+var pdfCmd = &cobra.Command{
+	Use:   "pdf",
+	Short: "Contrôler les PDFs générés",
+}
+
+var pdfVerifyCmd = &cobra.Command{
+	Use:   "verify [FICHIER.pdf]",
+	Short: "Vérifier hors ligne les signatures et l'intégrité d'un PDF",
+	Long: `Vérifier, sans connexion, les signatures d'un PDF : intégrité des octets
+signés, validité de la signature et confiance dans le certificat du signataire.
+
+Le certificat est reconnu s'il remonte à une autorité du système ou à un
+certificat donné avec --trust, par exemple son propre certificat auto-signé.
+La commande échoue (code de sortie 1) si le PDF n'est pas signé, si une
+signature est invalide ou si le document a été modifié après la signature.
+
+Exemples:
+  outbil pdf verify devis.pdf
+  outbil pdf verify devis.pdf --trust entreprise.pem`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		content, err := os.ReadFile(args[0])
+		if err != nil {
+			utils.Error("Erreur de lecture: %v", err)
+			os.Exit(1)
+		}
+
+		trusted, _ := cmd.Flags().GetStringArray("trust")
+		roots, err := trustedRoots(trusted)
+		if err != nil {
+			utils.Error("%v", err)
+			os.Exit(1)
+		}
+
+		var conf *model.Configuration
+		if password, _ := cmd.Flags().GetString("password"); password != "" {
+			conf = model.NewDefaultConfiguration()
+			conf.UserPW = password
+		}
+
+		results, err := signature.Verify(content, conf, roots)
+		if err != nil {
+			utils.Error("%v", err)
+			os.Exit(1)
+		}
+		if len(results) == 0 {
+			utils.Error("%s n'est pas signé", args[0])
+			os.Exit(1)
+		}
+
+		valid := true
+		for _, result := range results {
+			printSignature(result)
+			valid = valid && result.Valid()
+		}
+		fmt.Println()
+		if !valid {
+			utils.Error("%s: signature invalide ou document modifié", args[0])
+			os.Exit(1)
+		}
+		utils.Success("%s: document intact, signé par %s", args[0], results[len(results)-1].Signer)
+	},
+}
+
+// printSignature affiche le résultat de la vérification d'une signature
+func printSignature(result *signature.Result) {
+	fmt.Printf(i18n.T("\n--- Signature %s ---\n"), result.Field)
+	fmt.Printf(i18n.T("Signataire:  %s\n"), result.Signer)
+	if cert := result.Certificate; cert != nil {
+		fmt.Printf(i18n.T("Émetteur:    %s\n"), signature.Name(&x509.Certificate{Subject: cert.Issuer}))
+		fmt.Printf(i18n.T("Validité:    %s - %s\n"), cert.NotBefore.Format("2006-01-02"), cert.NotAfter.Format("2006-01-02"))
+	}
+	if !result.SigningTime.IsZero() {
+		fmt.Printf(i18n.T("Date:        %s\n"), result.SigningTime.Local().Format("2006-01-02 15:04:05"))
+	}
+	if result.Location != "" {
+		fmt.Printf(i18n.T("Lieu:        %s\n"), result.Location)
+	}
+	if result.Reason != "" {
+		fmt.Printf(i18n.T("Motif:       %s\n"), result.Reason)
+	}
+	fmt.Printf(i18n.T("Format:      %s\n"), result.SubFilter)
+
+	switch {
+	case result.Err != nil:
+		fmt.Printf(i18n.T("Signature:   invalide (%v)\n"), result.Err)
+	case result.Modified:
+		fmt.Print(i18n.T("Signature:   valide, mais le document a été complété après la signature\n"))
+	default:
+		fmt.Print(i18n.T("Signature:   valide, document intact\n"))
+	}
+	if result.Err == nil {
+		if result.TrustErr != nil {
+			fmt.Printf(i18n.T("Confiance:   certificat non reconnu (%v)\n"), result.TrustErr)
+		} else {
+			fmt.Print(i18n.T("Confiance:   certificat reconnu\n"))
+		}
+	}
+}
+
+// trustedRoots retourne les autorités du système complétées des certificats
+// donnés, au format PEM ou DER
+func trustedRoots(files []string) (*x509.CertPool, error) {
+	roots, err := x509.SystemCertPool()
+	if err != nil {
+		roots = x509.NewCertPool()
+	}
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf(i18n.T("erreur de lecture du certificat: %w"), err)
+		}
+		if block, _ := pem.Decode(data); block != nil {
+			data = block.Bytes
+		}
+		cert, err := x509.ParseCertificate(data)
+		if err != nil {
+			return nil, fmt.Errorf(i18n.T("certificat %s illisible: %w"), file, err)
+		}
+		roots.AddCert(cert)
+	}
+	return roots, nil
+}
@@ -4,8 +4,11 @@ import (
 	"fmt"
 	"os"
 	"outbil/db"
+	"outbil/i18n"
 	"outbil/models"
+	"outbil/render"
 	"outbil/utils"
+	"path/filepath"
 	"strconv"
 	"strings"
 	"time"
@@ -23,7 +26,22 @@ func init() {
 	quoteCmd.AddCommand(quoteStatusCmd)
 	quoteCmd.AddCommand(quoteDeleteCmd)
 	quoteCmd.AddCommand(quotePDFCmd)
+	quoteCmd.AddCommand(quoteHTMLCmd)
 	quoteCmd.AddCommand(quoteDuplicateCmd)
+
+	quoteCreateCmd.Flags().StringP("company", "c", "", "Entreprise émettrice (ID ou nom), par défaut l'entreprise par défaut")
+	quoteCreateCmd.Flags().String("currency", "", "Devise du devis (code ISO 4217), par défaut celle du client puis de l'entreprise")
+	quoteCreateCmd.Flags().String("language", "", "Langue du PDF (fr, en), par défaut celle du client")
+	quotePDFCmd.Flags().String("language", "", "Générer le PDF dans une autre langue (fr, en) sans modifier le devis")
+	quotePDFCmd.Flags().String("theme", "", "Thème de mise en page (nom ou fichier JSON) à la place de celui de l'entreprise")
+	quotePDFCmd.Flags().Bool("pdfa", false, "Générer un PDF/A-3b d'archivage (polices incorporées, données du devis jointes)")
+	quoteHTMLCmd.Flags().String("language", "", "Générer la page dans une autre langue (fr, en) sans modifier le devis")
+	quoteHTMLCmd.Flags().String("theme", "", "Thème dont le modèle HTML est utilisé, à la place de celui de l'entreprise")
+	quoteHTMLCmd.Flags().Bool("stdout", false, "Écrire la page sur la sortie standard (corps d'email, aperçu)")
+	for _, command := range []*cobra.Command{quotePDFCmd, quoteHTMLCmd} {
+		command.Flags().StringP("output", "o", "", "Fichier ou dossier de destination, à la place des réglages de la base (outbil db output)")
+		command.Flags().String("conflict", "", "Fichier existant d'un autre document: rename, overwrite ou fail")
+	}
 }
 
 var quoteCmd = &cobra.Command{
@@ -54,8 +72,12 @@ var quoteListCmd = &cobra.Command{
 			return
 		}
 
+		if company, err := database.GetCompany(); err == nil {
+			useCompanyLocale(company)
+		}
+
 		table := utils.CreateTable()
-		table.Header("ID", "Numéro", "Client", "Date", "Montant", "Statut")
+		table.Header("ID", i18n.T("Numéro"), "Client", "Date", i18n.T("Montant"), i18n.T("Statut"))
 
 		for _, quote := range quotes {
 			statusColor := getStatusColor(quote.Status)
@@ -65,8 +87,8 @@ var quoteListCmd = &cobra.Command{
 				strconv.Itoa(quote.ID),
 				fullNumber,
 				quote.Client.Name,
-				quote.Date.Format("02/01/2006"),
-				utils.FormatPrice(quote.TotalAmount, "EUR"),
+				utils.FormatDate(quote.Date),
+				utils.FormatPrice(quote.TotalAmount, quote.Currency),
 				statusColor,
 			})
 		}
@@ -86,7 +108,33 @@ var quoteCreateCmd = &cobra.Command{
 		}
 		defer database.Close()
 
-		clients, err := database.ListClients()
+		selector, _ := cmd.Flags().GetString("company")
+		currency, _ := cmd.Flags().GetString("currency")
+		if currency != "" {
+			if err := utils.ValidateCurrency(currency); err != nil {
+				utils.Error("%v", err)
+				return
+			}
+		}
+		language, _ := cmd.Flags().GetString("language")
+		if language != "" {
+			if err := i18n.ValidateLanguage(language); err != nil {
+				utils.Error("%v", err)
+				return
+			}
+		}
+
+		company, err := database.FindCompany(selector)
+		if err != nil {
+			utils.Error("Entreprise émettrice introuvable: %v", err)
+			return
+		}
+		if company != nil && selector != "" {
+			utils.Info("Entreprise émettrice: %s", company.Name)
+		}
+		useCompanyLocale(company)
+
+		clients, err := database.ListClients(false)
 		if err != nil {
 			utils.Error("Erreur lors de la récupération des clients: %v", err)
 			return
@@ -103,7 +151,7 @@ var quoteCreateCmd = &cobra.Command{
 		}
 
 		prompt := promptui.Select{
-			Label: "Sélectionner un client",
+			Label: i18n.T("Sélectionner un client"),
 			Items: clientNames,
 		}
 
@@ -118,17 +166,25 @@ var quoteCreateCmd = &cobra.Command{
 			ClientID: selectedClient.ID,
 			Date:     time.Now(),
 			Status:   models.StatusDraft,
+			Language: i18n.NormalizeLanguage(language),
+		}
+		if company != nil {
+			quote.CompanyID = company.ID
 		}
 
-		quoteNumber, err := database.GetNextQuoteNumber()
-		if err != nil {
-			utils.Error("Erreur lors de la génération du numéro: %v", err)
+		if err := selectQuoteRecipients(database, quote); err != nil {
+			utils.Error("Erreur lors de la sélection du contact: %v", err)
 			return
 		}
-		quote.QuoteNumber = quoteNumber
+
+		if currency != "" {
+			quote.Currency = utils.NormalizeCurrency(currency)
+		} else {
+			quote.Currency = promptCurrency("Devise du devis", defaultQuoteCurrency(&selectedClient, company))
+		}
 
 		validityPrompt := promptui.Prompt{
-			Label:   "Durée de validité (jours)",
+			Label:   i18n.T("Durée de validité (jours)"),
 			Default: "30",
 		}
 		validityDays, _ := validityPrompt.Run()
@@ -136,13 +192,22 @@ var quoteCreateCmd = &cobra.Command{
 		quote.ValidUntil = time.Now().AddDate(0, 0, days)
 
 		notesPrompt := promptui.Prompt{
-			Label: "Notes (optionnel)",
+			Label: i18n.T("Notes (optionnel)"),
 		}
 		quote.Notes, _ = notesPrompt.Run()
 
+		defaultTerms := "Paiement à 30 jours"
+		defaultTaxRate := "20"
+		if company != nil {
+			if company.PaymentTerms != "" {
+				defaultTerms = company.PaymentTerms
+			}
+			defaultTaxRate = strconv.FormatFloat(company.TaxRate, 'f', -1, 64)
+		}
+
 		termsPrompt := promptui.Prompt{
-			Label:   "Conditions de paiement",
-			Default: "Paiement à 30 jours",
+			Label:   i18n.T("Conditions de paiement"),
+			Default: defaultTerms,
 		}
 		quote.Terms, _ = termsPrompt.Run()
 
@@ -152,14 +217,14 @@ var quoteCreateCmd = &cobra.Command{
 		itemNumber := 1
 
 		for {
-			fmt.Printf("\n--- Ligne %d ---\n", itemNumber)
+			fmt.Printf(i18n.T("\n--- Ligne %d ---\n"), itemNumber)
 
 			descPrompt := promptui.Prompt{
-				Label: "Description (ou 'fin' pour terminer)",
+				Label: i18n.T("Description (ou 'fin' pour terminer)"),
 			}
 			description, _ := descPrompt.Run()
 
-			if strings.ToLower(description) == "fin" {
+			if end := strings.ToLower(description); end == "fin" || end == "end" {
 				break
 			}
 
@@ -168,21 +233,21 @@ var quoteCreateCmd = &cobra.Command{
 			}
 
 			qtyPrompt := promptui.Prompt{
-				Label:   "Quantité",
+				Label:   i18n.T("Quantité"),
 				Default: "1",
 			}
 			qtyStr, _ := qtyPrompt.Run()
 			item.Quantity, _ = utils.ParseFloat(qtyStr)
 
 			pricePrompt := promptui.Prompt{
-				Label: "Prix unitaire HT",
+				Label: i18n.T("Prix unitaire HT"),
 			}
 			priceStr, _ := pricePrompt.Run()
 			item.UnitPrice, _ = utils.ParseFloat(priceStr)
 
 			taxPrompt := promptui.Prompt{
-				Label:   "Taux TVA (%)",
-				Default: "20",
+				Label:   i18n.T("Taux TVA (%)"),
+				Default: defaultTaxRate,
 			}
 			taxStr, _ := taxPrompt.Run()
 			item.TaxRate, _ = utils.ParseFloat(taxStr)
@@ -190,8 +255,8 @@ var quoteCreateCmd = &cobra.Command{
 			item.Amount = item.Quantity * item.UnitPrice
 			items = append(items, item)
 
-			utils.Success("Ligne ajoutée: %.2f x %.2f = %.2f EUR HT",
-				item.Quantity, item.UnitPrice, item.Amount)
+			utils.Success("Ligne ajoutée: %s x %s = %s HT",
+				utils.FormatQuantity(item.Quantity), utils.FormatAmount(item.UnitPrice, quote.Currency), utils.FormatPrice(item.Amount, quote.Currency))
 
 			itemNumber++
 		}
@@ -212,13 +277,13 @@ var quoteCreateCmd = &cobra.Command{
 		quote.TaxAmount = totalTax
 		quote.TotalAmount = subtotal + totalTax - quote.Discount
 
-		fmt.Printf("\n--- Récapitulatif ---\n")
-		fmt.Printf("Sous-total HT: %.2f EUR\n", subtotal)
-		fmt.Printf("TVA:           %.2f EUR\n", totalTax)
-		fmt.Printf("Total TTC:     %.2f EUR\n", quote.TotalAmount)
+		fmt.Print(i18n.T("\n--- Récapitulatif ---\n"))
+		fmt.Printf(i18n.T("Sous-total HT: %s\n"), utils.FormatPrice(subtotal, quote.Currency))
+		fmt.Printf(i18n.T("TVA:           %s\n"), utils.FormatPrice(totalTax, quote.Currency))
+		fmt.Printf(i18n.T("Total TTC:     %s\n"), utils.FormatPrice(quote.TotalAmount, quote.Currency))
 
 		confirm := promptui.Prompt{
-			Label:     "Confirmer la création du devis",
+			Label:     i18n.T("Confirmer la création du devis"),
 			IsConfirm: true,
 		}
 		result, _ := confirm.Run()
@@ -262,50 +327,74 @@ var quoteShowCmd = &cobra.Command{
 		}
 
 		fullNumber := fmt.Sprintf("%s-%s", quote.Date.Format("2006-01"), quote.QuoteNumber)
-		fmt.Printf("\n=== DEVIS %s ===\n", fullNumber)
-		fmt.Printf("Date: %s\n", quote.Date.Format("02/01/2006"))
-		fmt.Printf("Valide jusqu'au: %s\n", quote.ValidUntil.Format("02/01/2006"))
-		fmt.Printf("Statut: %s\n", getStatusColor(quote.Status))
+		fmt.Printf(i18n.T("\n=== DEVIS %s ===\n"), fullNumber)
+		if company, err := quoteCompany(database, quote); err == nil && company != nil {
+			fmt.Printf(i18n.T("Émetteur: %s\n"), company.Name)
+			useCompanyLocale(company)
+		}
+		fmt.Printf(i18n.T("Date: %s\n"), utils.FormatDate(quote.Date))
+		fmt.Printf(i18n.T("Valide jusqu'au: %s\n"), utils.FormatDate(quote.ValidUntil))
+		fmt.Printf(i18n.T("Statut: %s\n"), getStatusColor(quote.Status))
+		fmt.Printf(i18n.T("Devise: %s\n"), quote.Currency)
+		if quote.Language != "" {
+			fmt.Printf(i18n.T("Langue: %s\n"), i18n.LanguageName(quote.Language))
+		} else if quote.Client.Language != "" {
+			fmt.Printf(i18n.T("Langue: %s (client)\n"), i18n.LanguageName(quote.Client.Language))
+		}
 
-		fmt.Printf("\n--- Client ---\n")
-		fmt.Printf("%s\n", quote.Client.Name)
-		if quote.Client.Company != "" {
-			fmt.Printf("%s\n", quote.Client.Company)
+		fmt.Print(i18n.T("\n--- Client ---\n"))
+		for _, line := range clientBlockLines(quote, i18n.Document(i18n.DefaultLanguage)) {
+			fmt.Printf("%s\n", line)
 		}
-		fmt.Printf("%s\n", quote.Client.Address)
-		fmt.Printf("%s %s\n", quote.Client.PostalCode, quote.Client.City)
-		if quote.Client.TaxID != "" {
-			fmt.Printf("N° TVA: %s\n", quote.Client.TaxID)
+		if quote.DeliveryAddress != nil {
+			fmt.Print(i18n.T("\n--- Livraison ---\n"))
+			for _, line := range addressLines(quote.DeliveryAddress) {
+				fmt.Printf("%s\n", line)
+			}
 		}
 
-		fmt.Printf("\n--- Détail ---\n")
+		fmt.Print(i18n.T("\n--- Détail ---\n"))
 		table := utils.CreateTable()
-		table.Header("Description", "Qté", "PU HT", "TVA %", "Total HT")
+		table.Header("Description", i18n.T("Qté"), i18n.T("PU HT"), i18n.T("TVA %"), i18n.T("Total HT"))
 
 		for _, item := range quote.Items {
 			table.Append([]string{
 				item.Description,
-				fmt.Sprintf("%.2f", item.Quantity),
-				fmt.Sprintf("%.2f", item.UnitPrice),
-				fmt.Sprintf("%.0f%%", item.TaxRate),
-				fmt.Sprintf("%.2f", item.Amount),
+				utils.FormatQuantity(item.Quantity),
+				utils.FormatAmount(item.UnitPrice, quote.Currency),
+				utils.FormatPercent(item.TaxRate),
+				utils.FormatAmount(item.Amount, quote.Currency),
 			})
 		}
 		table.Render()
 
 		subtotal := quote.TotalAmount - quote.TaxAmount + quote.Discount
-		fmt.Printf("\nSous-total HT: %.2f EUR\n", subtotal)
+		fmt.Printf(i18n.T("\nSous-total HT: %s\n"), utils.FormatPrice(subtotal, quote.Currency))
 		if quote.Discount > 0 {
-			fmt.Printf("Remise:        %.2f EUR\n", quote.Discount)
+			fmt.Printf(i18n.T("Remise:        %s\n"), utils.FormatPrice(quote.Discount, quote.Currency))
 		}
-		fmt.Printf("TVA:           %.2f EUR\n", quote.TaxAmount)
-		fmt.Printf("TOTAL TTC:     %.2f EUR\n", quote.TotalAmount)
+		fmt.Printf(i18n.T("TVA:           %s\n"), utils.FormatPrice(quote.TaxAmount, quote.Currency))
+		fmt.Printf(i18n.T("TOTAL TTC:     %s\n"), utils.FormatPrice(quote.TotalAmount, quote.Currency))
 
 		if quote.Notes != "" {
-			fmt.Printf("\nNotes: %s\n", quote.Notes)
+			fmt.Printf(i18n.T("\nNotes: %s\n"), quote.Notes)
 		}
 		if quote.Terms != "" {
-			fmt.Printf("Conditions: %s\n", quote.Terms)
+			fmt.Printf(i18n.T("Conditions: %s\n"), quote.Terms)
+		}
+
+		documents, err := database.ListGeneratedDocuments(models.DocumentQuote, quote.ID)
+		if err != nil {
+			utils.Warning("Historique des fichiers indisponible: %v", err)
+		} else if len(documents) > 0 {
+			fmt.Print(i18n.T("\n--- Fichiers générés ---\n"))
+			for _, document := range documents {
+				terms := i18n.T("sans CGV")
+				if document.Terms != "" {
+					terms = fmt.Sprintf(i18n.T("CGV %s"), document.Terms)
+				}
+				fmt.Printf("%s  %s (%s)\n", utils.FormatDateTime(document.GeneratedAt.Local()), document.FilePath, terms)
+			}
 		}
 	},
 }
@@ -337,18 +426,27 @@ var quoteEditCmd = &cobra.Command{
 		fullNumber := fmt.Sprintf("%s-%s", quote.Date.Format("2006-01"), quote.QuoteNumber)
 		utils.Info("Modification du devis %s", fullNumber)
 
+		defaultTaxRate := "20"
+		if company, err := quoteCompany(database, quote); err == nil && company != nil {
+			defaultTaxRate = strconv.FormatFloat(company.TaxRate, 'f', -1, 64)
+			useCompanyLocale(company)
+		}
+
 		menuItems := []string{
-			"Modifier le client",
-			"Modifier la durée de validité",
-			"Modifier les notes",
-			"Modifier les conditions de paiement",
-			"Modifier les lignes du devis",
-			"Terminer les modifications",
+			i18n.T("Modifier le client"),
+			i18n.T("Modifier le contact et les adresses"),
+			i18n.T("Modifier la durée de validité"),
+			i18n.T("Modifier les notes"),
+			i18n.T("Modifier les conditions de paiement"),
+			i18n.T("Modifier la devise"),
+			i18n.T("Modifier la langue du document"),
+			i18n.T("Modifier les lignes du devis"),
+			i18n.T("Terminer les modifications"),
 		}
 
 		for {
 			prompt := promptui.Select{
-				Label: "Que souhaitez-vous modifier?",
+				Label: i18n.T("Que souhaitez-vous modifier?"),
 				Items: menuItems,
 			}
 
@@ -359,7 +457,7 @@ var quoteEditCmd = &cobra.Command{
 
 			switch index {
 			case 0: // Modifier le client
-				clients, err := database.ListClients()
+				clients, err := database.ListClients(false)
 				if err != nil {
 					utils.Error("Erreur lors de la récupération des clients: %v", err)
 					continue
@@ -371,7 +469,7 @@ var quoteEditCmd = &cobra.Command{
 				}
 
 				clientPrompt := promptui.Select{
-					Label: "Sélectionner un nouveau client",
+					Label: i18n.T("Sélectionner un nouveau client"),
 					Items: clientNames,
 				}
 
@@ -382,45 +480,76 @@ var quoteEditCmd = &cobra.Command{
 
 				quote.ClientID = clients[idx].ID
 				quote.Client = &clients[idx]
+				// Les contacts et adresses appartiennent à l'ancien client
+				quote.ContactID, quote.Contact = 0, nil
+				quote.BillingAddressID, quote.BillingAddress = 0, nil
+				quote.DeliveryAddressID, quote.DeliveryAddress = 0, nil
 				utils.Success("Client modifié: %s", clients[idx].Name)
 
-			case 1: // Modifier la durée de validité
+				if err := selectQuoteRecipients(database, quote); err != nil {
+					utils.Error("Erreur lors de la sélection du contact: %v", err)
+				}
+
+			case 1: // Modifier le contact et les adresses
+				if err := selectQuoteRecipients(database, quote); err != nil {
+					utils.Error("Erreur lors de la sélection du contact: %v", err)
+					continue
+				}
+				utils.Success("Contact et adresses modifiés")
+
+			case 2: // Modifier la durée de validité
 				validityPrompt := promptui.Prompt{
-					Label:   "Durée de validité (jours)",
+					Label:   i18n.T("Durée de validité (jours)"),
 					Default: fmt.Sprintf("%d", int(quote.ValidUntil.Sub(quote.Date).Hours()/24)),
 				}
 				validityDays, _ := validityPrompt.Run()
 				days, _ := strconv.Atoi(validityDays)
 				quote.ValidUntil = quote.Date.AddDate(0, 0, days)
-				utils.Success("Validité modifiée: %s", quote.ValidUntil.Format("02/01/2006"))
+				utils.Success("Validité modifiée: %s", utils.FormatDate(quote.ValidUntil))
 
-			case 2: // Modifier les notes
+			case 3: // Modifier les notes
 				notesPrompt := promptui.Prompt{
-					Label:   "Notes",
+					Label:   i18n.T("Notes"),
 					Default: quote.Notes,
 				}
 				quote.Notes, _ = notesPrompt.Run()
 				utils.Success("Notes modifiées")
 
-			case 3: // Modifier les conditions
+			case 4: // Modifier les conditions
 				termsPrompt := promptui.Prompt{
-					Label:   "Conditions de paiement",
+					Label:   i18n.T("Conditions de paiement"),
 					Default: quote.Terms,
 				}
 				quote.Terms, _ = termsPrompt.Run()
 				utils.Success("Conditions modifiées")
 
-			case 4: // Modifier les lignes
+			case 5: // Modifier la devise
+				previous := quote.Currency
+				quote.Currency = promptCurrency("Devise du devis", quote.Currency)
+				if quote.Currency != previous {
+					utils.Success("Devise modifiée: %s", quote.Currency)
+					utils.Warning("Les prix des lignes ne sont pas convertis, vérifiez-les")
+				}
+
+			case 6: // Modifier la langue du document
+				inherit := i18n.T("Langue du client")
+				if quote.Client.Language != "" {
+					inherit = fmt.Sprintf(i18n.T("Langue du client (%s)"), i18n.LanguageName(quote.Client.Language))
+				}
+				quote.Language = promptDocumentLanguage("Langue du document", quote.Language, inherit)
+				utils.Success("Langue du document modifiée")
+
+			case 7: // Modifier les lignes
 				editLinesMenu := []string{
-					"Ajouter une ligne",
-					"Modifier une ligne existante",
-					"Supprimer une ligne",
-					"Retour",
+					i18n.T("Ajouter une ligne"),
+					i18n.T("Modifier une ligne existante"),
+					i18n.T("Supprimer une ligne"),
+					i18n.T("Retour"),
 				}
 
 				for {
 					linePrompt := promptui.Select{
-						Label: "Gestion des lignes",
+						Label: i18n.T("Gestion des lignes"),
 						Items: editLinesMenu,
 					}
 
@@ -431,9 +560,9 @@ var quoteEditCmd = &cobra.Command{
 
 					switch lineIndex {
 					case 0: // Ajouter une ligne
-						fmt.Printf("\n--- Nouvelle ligne ---\n")
+						fmt.Print(i18n.T("\n--- Nouvelle ligne ---\n"))
 
-						descPrompt := promptui.Prompt{Label: "Description"}
+						descPrompt := promptui.Prompt{Label: i18n.T("Description")}
 						description, _ := descPrompt.Run()
 
 						item := models.QuoteItem{
@@ -441,15 +570,15 @@ var quoteEditCmd = &cobra.Command{
 							Description: description,
 						}
 
-						qtyPrompt := promptui.Prompt{Label: "Quantité", Default: "1"}
+						qtyPrompt := promptui.Prompt{Label: i18n.T("Quantité"), Default: "1"}
 						qtyStr, _ := qtyPrompt.Run()
 						item.Quantity, _ = utils.ParseFloat(qtyStr)
 
-						pricePrompt := promptui.Prompt{Label: "Prix unitaire HT"}
+						pricePrompt := promptui.Prompt{Label: i18n.T("Prix unitaire HT")}
 						priceStr, _ := pricePrompt.Run()
 						item.UnitPrice, _ = utils.ParseFloat(priceStr)
 
-						taxPrompt := promptui.Prompt{Label: "Taux TVA (%)", Default: "20"}
+						taxPrompt := promptui.Prompt{Label: i18n.T("Taux TVA (%)"), Default: defaultTaxRate}
 						taxStr, _ := taxPrompt.Run()
 						item.TaxRate, _ = utils.ParseFloat(taxStr)
 
@@ -465,12 +594,13 @@ var quoteEditCmd = &cobra.Command{
 
 						itemDescs := make([]string, len(quote.Items))
 						for i, item := range quote.Items {
-							itemDescs[i] = fmt.Sprintf("%s (%.2f x %.2f = %.2f EUR)",
-								item.Description, item.Quantity, item.UnitPrice, item.Amount)
+							itemDescs[i] = fmt.Sprintf("%s (%s x %s = %s)",
+								item.Description, utils.FormatQuantity(item.Quantity), utils.FormatAmount(item.UnitPrice, quote.Currency),
+								utils.FormatPrice(item.Amount, quote.Currency))
 						}
 
 						itemPrompt := promptui.Select{
-							Label: "Sélectionner la ligne à modifier",
+							Label: i18n.T("Sélectionner la ligne à modifier"),
 							Items: itemDescs,
 						}
 
@@ -482,27 +612,27 @@ var quoteEditCmd = &cobra.Command{
 						item := &quote.Items[itemIdx]
 
 						descPrompt := promptui.Prompt{
-							Label:   "Description",
+							Label:   i18n.T("Description"),
 							Default: item.Description,
 						}
 						item.Description, _ = descPrompt.Run()
 
 						qtyPrompt := promptui.Prompt{
-							Label:   "Quantité",
-							Default: fmt.Sprintf("%.2f", item.Quantity),
+							Label:   i18n.T("Quantité"),
+							Default: utils.FormatQuantity(item.Quantity),
 						}
 						qtyStr, _ := qtyPrompt.Run()
 						item.Quantity, _ = utils.ParseFloat(qtyStr)
 
 						pricePrompt := promptui.Prompt{
-							Label:   "Prix unitaire HT",
-							Default: fmt.Sprintf("%.2f", item.UnitPrice),
+							Label:   i18n.T("Prix unitaire HT"),
+							Default: utils.FormatAmount(item.UnitPrice, quote.Currency),
 						}
 						priceStr, _ := pricePrompt.Run()
 						item.UnitPrice, _ = utils.ParseFloat(priceStr)
 
 						taxPrompt := promptui.Prompt{
-							Label:   "Taux TVA (%)",
+							Label:   i18n.T("Taux TVA (%)"),
 							Default: fmt.Sprintf("%.0f", item.TaxRate),
 						}
 						taxStr, _ := taxPrompt.Run()
@@ -519,11 +649,11 @@ var quoteEditCmd = &cobra.Command{
 
 						itemDescs := make([]string, len(quote.Items))
 						for i, item := range quote.Items {
-							itemDescs[i] = fmt.Sprintf("%s (%.2f EUR)", item.Description, item.Amount)
+							itemDescs[i] = fmt.Sprintf("%s (%s)", item.Description, utils.FormatPrice(item.Amount, quote.Currency))
 						}
 
 						itemPrompt := promptui.Select{
-							Label: "Sélectionner la ligne à supprimer",
+							Label: i18n.T("Sélectionner la ligne à supprimer"),
 							Items: itemDescs,
 						}
 
@@ -537,7 +667,7 @@ var quoteEditCmd = &cobra.Command{
 					}
 				}
 
-			case 5: // Terminer
+			case 8: // Terminer
 				// Recalculer les totaux
 				var subtotal, totalTax float64
 				for _, item := range quote.Items {
@@ -548,14 +678,14 @@ var quoteEditCmd = &cobra.Command{
 				quote.TaxAmount = totalTax
 				quote.TotalAmount = subtotal + totalTax - quote.Discount
 
-				fmt.Printf("\n--- Récapitulatif des modifications ---\n")
-				fmt.Printf("Client: %s\n", quote.Client.Name)
-				fmt.Printf("Validité: %s\n", quote.ValidUntil.Format("02/01/2006"))
-				fmt.Printf("Nombre de lignes: %d\n", len(quote.Items))
-				fmt.Printf("Total TTC: %.2f EUR\n", quote.TotalAmount)
+				fmt.Print(i18n.T("\n--- Récapitulatif des modifications ---\n"))
+				fmt.Printf(i18n.T("Client: %s\n"), quote.Client.Name)
+				fmt.Printf(i18n.T("Validité: %s\n"), utils.FormatDate(quote.ValidUntil))
+				fmt.Printf(i18n.T("Nombre de lignes: %d\n"), len(quote.Items))
+				fmt.Printf(i18n.T("Total TTC: %s\n"), utils.FormatPrice(quote.TotalAmount, quote.Currency))
 
 				confirm := promptui.Prompt{
-					Label:     "Confirmer les modifications",
+					Label:     i18n.T("Confirmer les modifications"),
 					IsConfirm: true,
 				}
 				result, _ := confirm.Run()
@@ -609,7 +739,7 @@ var quoteStatusCmd = &cobra.Command{
 		}
 
 		prompt := promptui.Select{
-			Label: fmt.Sprintf("Statut actuel: %s. Nouveau statut", quote.Status),
+			Label: fmt.Sprintf(i18n.T("Statut actuel: %s. Nouveau statut"), quote.Status),
 			Items: statuses,
 		}
 
@@ -652,12 +782,16 @@ var quoteDeleteCmd = &cobra.Command{
 			return
 		}
 
+		if company, err := quoteCompany(database, quote); err == nil {
+			useCompanyLocale(company)
+		}
+
 		fullNumber := fmt.Sprintf("%s-%s", quote.Date.Format("2006-01"), quote.QuoteNumber)
-		utils.Warning("Devis à supprimer: %s - %s (%.2f EUR)",
-			fullNumber, quote.Client.Name, quote.TotalAmount)
+		utils.Warning("Devis à supprimer: %s - %s (%s)",
+			fullNumber, quote.Client.Name, utils.FormatPrice(quote.TotalAmount, quote.Currency))
 
 		confirm := promptui.Prompt{
-			Label:     "Confirmer la suppression",
+			Label:     i18n.T("Confirmer la suppression"),
 			IsConfirm: true,
 		}
 		result, _ := confirm.Run()
@@ -678,11 +812,23 @@ var quoteDeleteCmd = &cobra.Command{
 var quotePDFCmd = &cobra.Command{
 	Use:   "pdf [ID]",
 	Short: "Exporter un devis en PDF",
-	Args:  cobra.ExactArgs(1),
+	Long: `Exporter un devis en PDF, ou plusieurs devis à la fois avec une sélection
+(--all, --company, --status, --from, --to). Les PDFs d'une sélection sont générés
+en parallèle (--jobs) et peuvent être réunis dans un seul PDF (--merge) ou une
+archive ZIP (--zip).`,
+	Args: cobra.MaximumNArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
-		id, err := strconv.Atoi(args[0])
-		if err != nil {
-			utils.Error("ID invalide: %v", err)
+		batch := flagsChanged(cmd, quoteSelectionFlags)
+		if batch == (len(args) == 1) {
+			utils.Error("Indiquez soit l'ID d'un devis, soit une sélection de devis (--all, --company, --status, --from, --to)")
+			return
+		}
+		if batch {
+			runQuotePDFBatch(cmd)
+			return
+		}
+		if flagsChanged(cmd, quoteBatchFlags) {
+			utils.Error("--jobs, --merge et --zip ne s'appliquent qu'à une sélection de devis")
 			return
 		}
 
@@ -693,36 +839,189 @@ var quotePDFCmd = &cobra.Command{
 		}
 		defer database.Close()
 
-		quote, err := database.GetQuote(id)
+		quote, company, theme, ok := loadQuoteForRendering(cmd, database, args[0])
+		if !ok {
+			return
+		}
+
+		// Emplacement et nom selon --output ou les réglages de la base
+		filename, err := quoteOutputPath(cmd, database, quote, company, ".pdf", nil)
 		if err != nil {
-			utils.Error("Devis non trouvé: %v", err)
+			utils.Error("%v", err)
+			return
+		}
+		if err := os.MkdirAll(filepath.Dir(filename), 0755); err != nil {
+			utils.Error("Erreur lors de la création du dossier %s: %v", filepath.Dir(filename), err)
 			return
 		}
 
-		company, err := database.GetCompany()
+		// CGV en vigueur à la date du devis pour la catégorie du client
+		terms, err := database.FindApplicableTerms(company.ID, models.DocumentQuote, quote.Client.Category, quote.Date)
 		if err != nil {
-			utils.Error("Erreur lors de la récupération des infos société: %v", err)
+			utils.Error("Erreur lors de la sélection des CGV: %v", err)
 			return
 		}
+		if terms == nil {
+			warnLegacyTermsFile()
+		}
 
-		// Créer le dossier quotes s'il n'existe pas
-		err = os.MkdirAll("quotes", 0755)
+		archive, _ := cmd.Flags().GetBool("pdfa")
+		err = generateQuotePDF(quote, company, terms, theme, filename, archive)
 		if err != nil {
-			utils.Error("Erreur lors de la création du dossier quotes: %v", err)
+			if !printConformance(err) {
+				utils.Error("Erreur lors de la génération du PDF: %v", err)
+			}
 			return
 		}
 
-		filename := fmt.Sprintf("quotes/%d_%02d_devis_%s.pdf", quote.CreatedAt.Year(), quote.CreatedAt.Month(), quote.QuoteNumber)
-		err = generatePDFMaroto(quote, company, filename)
+		record := &models.GeneratedDocument{
+			DocumentType: models.DocumentQuote,
+			DocumentID:   quote.ID,
+			FilePath:     filename,
+		}
+		if terms != nil {
+			record.TermsID = terms.ID
+		}
+		if err := database.RecordGeneratedDocument(record); err != nil {
+			utils.Warning("Le PDF a été généré mais n'a pas pu être enregistré dans l'historique: %v", err)
+		}
+
+		utils.Success("PDF généré: %s", displayPath(filename))
+		if terms != nil {
+			utils.Info("CGV jointes: %s v%s (en vigueur depuis le %s)", terms.Title, terms.Version, utils.FormatDate(terms.EffectiveFrom))
+		}
+	},
+}
+
+var quoteHTMLCmd = &cobra.Command{
+	Use:   "html [ID]",
+	Short: "Exporter un devis en page HTML autonome",
+	Long: `Exporter un devis en page HTML autonome (logo et QR code inclus), pour un corps
+d'email ou un aperçu web. La page est produite avec le modèle HTML du thème, ou le
+modèle intégré pour les thèmes sans modèle (voir 'outbil theme template').`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		database, err := db.New(utils.GetDatabasePath())
 		if err != nil {
-			utils.Error("Erreur lors de la génération du PDF: %v", err)
+			utils.Error("Erreur d'ouverture de la base: %v", err)
+			return
+		}
+		defer database.Close()
+
+		quote, company, theme, ok := loadQuoteForRendering(cmd, database, args[0])
+		if !ok {
 			return
 		}
 
-		utils.Success("PDF généré: %s", filename)
+		page, err := render.HTML(theme, quoteDocument(quote, company))
+		if err != nil {
+			utils.Error("Erreur lors de la génération de la page HTML: %v", err)
+			return
+		}
+
+		if toStdout, _ := cmd.Flags().GetBool("stdout"); toStdout {
+			os.Stdout.Write(page)
+			return
+		}
+
+		filename, err := quoteOutputPath(cmd, database, quote, company, ".html", nil)
+		if err != nil {
+			utils.Error("%v", err)
+			return
+		}
+		if err := os.MkdirAll(filepath.Dir(filename), 0755); err != nil {
+			utils.Error("Erreur lors de la création du dossier %s: %v", filepath.Dir(filename), err)
+			return
+		}
+		if err := os.WriteFile(filename, page, 0644); err != nil {
+			utils.Error("Erreur lors de l'écriture du fichier: %v", err)
+			return
+		}
+
+		record := &models.GeneratedDocument{
+			DocumentType: models.DocumentQuote,
+			DocumentID:   quote.ID,
+			FilePath:     filename,
+		}
+		if err := database.RecordGeneratedDocument(record); err != nil {
+			utils.Warning("La page a été générée mais n'a pas pu être enregistrée dans l'historique: %v", err)
+		}
+		utils.Success("Page HTML générée: %s", displayPath(filename))
 	},
 }
 
+// loadQuoteForRendering charge un devis et son entreprise, vérifie les mentions
+// légales et applique les options --language et --theme de la commande
+func loadQuoteForRendering(cmd *cobra.Command, database *db.Database, arg string) (*models.Quote, *models.Company, *render.Theme, bool) {
+	id, err := strconv.Atoi(arg)
+	if err != nil {
+		utils.Error("ID invalide: %v", err)
+		return nil, nil, nil, false
+	}
+
+	quote, err := database.GetQuote(id)
+	if err != nil {
+		utils.Error("Devis non trouvé: %v", err)
+		return nil, nil, nil, false
+	}
+
+	company, err := quoteCompany(database, quote)
+	if err != nil {
+		utils.Error("Erreur lors de la récupération des infos société: %v", err)
+		return nil, nil, nil, false
+	}
+
+	if err := checkCompanyLegalProfile(company); err != nil {
+		utils.Error("Impossible de générer le document: %v", err)
+		utils.Info("Complétez les informations de l'entreprise avec: outbil company setup")
+		return nil, nil, nil, false
+	}
+
+	warnLegacyLogoFile(len(company.Logo) > 0)
+	useCompanyLocale(company)
+
+	// La langue demandée remplace celle du devis pour ce seul rendu
+	language, err := renderingLanguage(cmd)
+	if err != nil {
+		utils.Error("%v", err)
+		return nil, nil, nil, false
+	}
+	if language != "" {
+		quote.Language = language
+	}
+
+	theme, err := renderingTheme(cmd, company)
+	if err != nil {
+		utils.Error("Thème invalide: %v", err)
+		utils.Info("Thèmes disponibles: outbil theme list")
+		return nil, nil, nil, false
+	}
+	return quote, company, theme, true
+}
+
+// renderingLanguage retourne la langue demandée par --language, normalisée,
+// ou une chaîne vide pour garder celle du devis
+func renderingLanguage(cmd *cobra.Command) (string, error) {
+	language, _ := cmd.Flags().GetString("language")
+	if language == "" {
+		return "", nil
+	}
+	if err := i18n.ValidateLanguage(language); err != nil {
+		return "", err
+	}
+	return i18n.NormalizeLanguage(language), nil
+}
+
+// renderingTheme charge le thème de l'entreprise, sauf si un autre est
+// demandé par --theme pour ce rendu
+func renderingTheme(cmd *cobra.Command, company *models.Company) (*render.Theme, error) {
+	themeName, _ := cmd.Flags().GetString("theme")
+	if themeName == "" {
+		themeName = company.Theme
+	}
+	return render.LoadTheme(themeName, utils.GetThemesDir())
+}
+
 var quoteDuplicateCmd = &cobra.Command{
 	Use:   "duplicate [ID]",
 	Short: "Dupliquer un devis existant",
@@ -753,7 +1052,7 @@ var quoteDuplicateCmd = &cobra.Command{
 		
 		// Demander confirmation
 		confirm := promptui.Prompt{
-			Label:     "Voulez-vous dupliquer ce devis",
+			Label:     i18n.T("Voulez-vous dupliquer ce devis"),
 			IsConfirm: true,
 		}
 		result, _ := confirm.Run()
@@ -770,16 +1069,20 @@ var quoteDuplicateCmd = &cobra.Command{
 			return
 		}
 
+		if company, err := quoteCompany(database, newQuote); err == nil {
+			useCompanyLocale(company)
+		}
+
 		utils.Success("Devis dupliqué avec succès!")
 		utils.Info("Nouveau numéro: %s", newQuote.QuoteNumber)
-		utils.Info("Date: %s", newQuote.Date.Format("02/01/2006"))
-		utils.Info("Validité: %s", newQuote.ValidUntil.Format("02/01/2006"))
+		utils.Info("Date: %s", utils.FormatDate(newQuote.Date))
+		utils.Info("Validité: %s", utils.FormatDate(newQuote.ValidUntil))
 		utils.Info("Statut: %s", getStatusColor(newQuote.Status))
-		utils.Info("Montant total: %.2f €", newQuote.TotalAmount)
+		utils.Info("Montant total: %s", utils.FormatPrice(newQuote.TotalAmount, newQuote.Currency))
 		
 		// Proposer d'éditer le nouveau devis
 		confirmEdit := promptui.Prompt{
-			Label:     "Voulez-vous modifier le nouveau devis",
+			Label:     i18n.T("Voulez-vous modifier le nouveau devis"),
 			IsConfirm: true,
 		}
 		resultEdit, _ := confirmEdit.Run()
@@ -790,19 +1093,111 @@ var quoteDuplicateCmd = &cobra.Command{
 	},
 }
 
+// selectQuoteRecipients propose le contact et les adresses de facturation et de
+// livraison du client à faire figurer sur le devis
+func selectQuoteRecipients(database *db.Database, quote *models.Quote) error {
+	contacts, err := database.ListClientContacts(quote.ClientID)
+	if err != nil {
+		return err
+	}
+
+	if len(contacts) > 0 {
+		items := []string{i18n.T("Aucun contact")}
+		for _, contact := range contacts {
+			if contact.Role != "" {
+				items = append(items, fmt.Sprintf("%s (%s)", contact.Name, contact.Role))
+			} else {
+				items = append(items, contact.Name)
+			}
+		}
+
+		prompt := promptui.Select{
+			Label: i18n.T("Contact destinataire"),
+			Items: items,
+		}
+		index, _, err := prompt.Run()
+		if err != nil {
+			return err
+		}
+
+		quote.ContactID, quote.Contact = 0, nil
+		if index > 0 {
+			quote.ContactID = contacts[index-1].ID
+			quote.Contact = &contacts[index-1]
+		}
+	}
+
+	billing, err := selectClientAddress(database, quote.ClientID, models.AddressBilling,
+		"Adresse de facturation", "Adresse principale du client")
+	if err != nil {
+		return err
+	}
+	quote.BillingAddressID, quote.BillingAddress = 0, billing
+	if billing != nil {
+		quote.BillingAddressID = billing.ID
+	}
+
+	delivery, err := selectClientAddress(database, quote.ClientID, models.AddressDelivery,
+		"Adresse de livraison", "Pas d'adresse de livraison")
+	if err != nil {
+		return err
+	}
+	quote.DeliveryAddressID, quote.DeliveryAddress = 0, delivery
+	if delivery != nil {
+		quote.DeliveryAddressID = delivery.ID
+	}
+
+	return nil
+}
+
+func selectClientAddress(database *db.Database, clientID int, addressType, label, noneLabel string) (*models.ClientAddress, error) {
+	addresses, err := database.ListClientAddresses(clientID, addressType)
+	if err != nil || len(addresses) == 0 {
+		return nil, err
+	}
+
+	items := []string{i18n.T(noneLabel)}
+	for i := range addresses {
+		items = append(items, formatAddress(&addresses[i]))
+	}
+
+	prompt := promptui.Select{
+		Label: i18n.T(label),
+		Items: items,
+	}
+	index, _, err := prompt.Run()
+	if err != nil {
+		return nil, err
+	}
+
+	if index == 0 {
+		return nil, nil
+	}
+	return &addresses[index-1], nil
+}
+
 func getStatusColor(status string) string {
 	switch status {
 	case models.StatusDraft:
-		return "📝 Brouillon"
+		return i18n.T("📝 Brouillon")
 	case models.StatusSent:
-		return "📤 Envoyé"
+		return i18n.T("📤 Envoyé")
 	case models.StatusAccepted:
-		return "✅ Accepté"
+		return i18n.T("✅ Accepté")
 	case models.StatusRejected:
-		return "❌ Refusé"
+		return i18n.T("❌ Refusé")
 	case models.StatusExpired:
-		return "⏰ Expiré"
+		return i18n.T("⏰ Expiré")
 	default:
 		return status
 	}
 }
+
+// quoteCompany retourne l'entreprise émettrice du devis, ou l'entreprise par
+// défaut pour un devis qui n'y est pas rattaché
+func quoteCompany(database *db.Database, quote *models.Quote) (*models.Company, error) {
+	if quote.CompanyID != 0 {
+		return database.GetCompanyByID(quote.CompanyID)
+	}
+	return database.GetCompany()
+}
@@ -0,0 +1,497 @@
+package cmd
+
+import (
+	"archive/zip"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"outbil/db"
+	"outbil/i18n"
+	"outbil/models"
+	"outbil/render"
+	"outbil/utils"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pdfcpu/pdfcpu/pkg/api"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	quotePDFCmd.Flags().Bool("all", false, "Générer les PDFs de tous les devis")
+	quotePDFCmd.Flags().StringP("company", "c", "", "Générer les PDFs des devis de cette entreprise (ID ou nom)")
+	quotePDFCmd.Flags().String("status", "", "Générer les PDFs des devis de ce statut (draft, sent, accepted...)")
+	quotePDFCmd.Flags().String("from", "", "Générer les PDFs des devis datés de ce jour ou après (format de l'entreprise ou AAAA-MM-JJ)")
+	quotePDFCmd.Flags().String("to", "", "Générer les PDFs des devis datés de ce jour ou avant (format de l'entreprise ou AAAA-MM-JJ)")
+	quotePDFCmd.Flags().IntP("jobs", "j", runtime.NumCPU(), "Nombre de PDFs générés en parallèle")
+	quotePDFCmd.Flags().String("merge", "", "Réunir les PDFs générés dans ce fichier PDF")
+	quotePDFCmd.Flags().String("zip", "", "Réunir les PDFs générés dans cette archive ZIP")
+}
+
+// Options qui sélectionnent plusieurs devis, et celles propres aux lots
+var (
+	quoteSelectionFlags = []string{"all", "company", "status", "from", "to"}
+	quoteBatchFlags     = []string{"jobs", "merge", "zip"}
+)
+
+// quoteBatchJob est un devis d'un lot, préparé puis rendu par un worker
+type quoteBatchJob struct {
+	quote    *models.Quote
+	company  *models.Company
+	theme    *render.Theme
+	terms    *models.TermsDocument
+	filename string
+	doc      *render.Document
+	content  []byte // PDF avant finalisation, gardé pour le PDF réuni
+	err      error
+}
+
+// quoteSelection décrit les devis retenus pour un lot
+type quoteSelection struct {
+	companyID        int // 0 pour toutes les entreprises
+	defaultCompanyID int // entreprise des devis enregistrés sans entreprise
+	status           string
+	from, to         time.Time // to exclu, zéro sans borne
+}
+
+// parseQuoteSelection valide le statut et les dates de --status, --from et
+// --to ; la date de fin est incluse
+func parseQuoteSelection(status, from, to string) (quoteSelection, error) {
+	selection := quoteSelection{status: status}
+	if status != "" && !isQuoteStatus(status) {
+		return selection, fmt.Errorf(i18n.T("statut invalide %q (%s)"), status, strings.Join(quoteStatuses, ", "))
+	}
+	var err error
+	if from != "" {
+		if selection.from, err = utils.ParseDate(from); err != nil {
+			return selection, err
+		}
+	}
+	if to != "" {
+		if selection.to, err = utils.ParseDate(to); err != nil {
+			return selection, err
+		}
+		selection.to = selection.to.AddDate(0, 0, 1)
+	}
+	return selection, nil
+}
+
+// match indique si un devis fait partie de la sélection
+func (s quoteSelection) match(quote models.Quote) bool {
+	companyID := quote.CompanyID
+	if companyID == 0 {
+		companyID = s.defaultCompanyID
+	}
+	if s.companyID != 0 && companyID != s.companyID {
+		return false
+	}
+	if s.status != "" && quote.Status != s.status {
+		return false
+	}
+	return (s.from.IsZero() || !quote.Date.Before(s.from)) && (s.to.IsZero() || quote.Date.Before(s.to))
+}
+
+// apply retourne les devis sélectionnés dans l'ordre chronologique, celui
+// des fichiers réunis
+func (s quoteSelection) apply(quotes []models.Quote) []models.Quote {
+	var selected []models.Quote
+	for _, quote := range quotes {
+		if s.match(quote) {
+			selected = append(selected, quote)
+		}
+	}
+	sort.SliceStable(selected, func(i, j int) bool {
+		if !selected[i].Date.Equal(selected[j].Date) {
+			return selected[i].Date.Before(selected[j].Date)
+		}
+		return selected[i].ID < selected[j].ID
+	})
+	return selected
+}
+
+// flagsChanged indique si l'une des options a été donnée
+func flagsChanged(cmd *cobra.Command, names []string) bool {
+	for _, name := range names {
+		if cmd.Flags().Changed(name) {
+			return true
+		}
+	}
+	return false
+}
+
+// runQuotePDFBatch génère les PDFs des devis sélectionnés, en parallèle.
+// Les devis sont préparés un à un (base, CGV, emplacement), puis rendus par
+// un nombre borné de workers ; les résultats sont enregistrés au fil de l'eau.
+func runQuotePDFBatch(cmd *cobra.Command) {
+	selector, _ := cmd.Flags().GetString("company")
+	status, _ := cmd.Flags().GetString("status")
+	fromFlag, _ := cmd.Flags().GetString("from")
+	toFlag, _ := cmd.Flags().GetString("to")
+	workers, _ := cmd.Flags().GetInt("jobs")
+	mergeFile, _ := cmd.Flags().GetString("merge")
+	zipFile, _ := cmd.Flags().GetString("zip")
+	archive, _ := cmd.Flags().GetBool("pdfa")
+
+	if workers < 1 {
+		utils.Error("--jobs doit valoir au moins 1")
+		return
+	}
+	language, err := renderingLanguage(cmd)
+	if err != nil {
+		utils.Error("%v", err)
+		return
+	}
+	if conflict, _ := cmd.Flags().GetString("conflict"); conflict != "" {
+		if err := validateConflict(conflict); err != nil {
+			utils.Error("%v", err)
+			return
+		}
+	}
+
+	// Avec plusieurs devis, --output ne peut désigner qu'un dossier
+	if output, _ := cmd.Flags().GetString("output"); output != "" {
+		info, err := os.Stat(expandHome(output))
+		isDir := err == nil && info.IsDir()
+		if !isDir && (err == nil || filepath.Ext(output) != "") {
+			utils.Error("Avec plusieurs devis, --output doit désigner un dossier: %s", output)
+			return
+		}
+		if !strings.HasSuffix(output, "/") {
+			cmd.Flags().Set("output", output+"/")
+		}
+	}
+
+	database, err := db.New(utils.GetDatabasePath())
+	if err != nil {
+		utils.Error("Erreur d'ouverture de la base: %v", err)
+		return
+	}
+	defer database.Close()
+
+	// Entreprise demandée, ou entreprise par défaut pour les devis sans entreprise
+	company, err := requireCompany(database, selector)
+	if err != nil {
+		utils.Error("%v", err)
+		return
+	}
+	useCompanyLocale(company)
+
+	// Les dates se saisissent au format de l'entreprise
+	selection, err := parseQuoteSelection(status, fromFlag, toFlag)
+	if err != nil {
+		utils.Error("%v", err)
+		return
+	}
+
+	quotes, err := database.ListQuotes()
+	if err != nil {
+		utils.Error("Erreur lors de la récupération des devis: %v", err)
+		return
+	}
+	selection.defaultCompanyID = company.ID
+	if selector != "" {
+		selection.companyID = company.ID
+	}
+	selected := selection.apply(quotes)
+	if len(selected) == 0 {
+		utils.Info("Aucun devis ne correspond à la sélection")
+		return
+	}
+
+	if workers > len(selected) {
+		workers = len(selected)
+	}
+	utils.Info("Génération de %d PDF(s), %d en parallèle", len(selected), workers)
+
+	total := len(selected)
+	done := 0
+	failed := 0
+	report := func(job *quoteBatchJob, number string) {
+		done++
+		if job.err != nil {
+			failed++
+			utils.Error("[%d/%d] %s: %v", done, total, number, job.err)
+			return
+		}
+		utils.Success("[%d/%d] %s: %s", done, total, number, displayPath(job.filename))
+	}
+
+	// Préparation séquentielle : la base n'est lue que depuis ce goroutine
+	companies := map[int]*models.Company{}
+	reserved := map[string]bool{}
+	legacyTerms := false
+	var jobs []*quoteBatchJob
+	for _, summary := range selected {
+		job := prepareBatchQuote(cmd, database, summary.ID, language, companies, reserved)
+		if job.err != nil {
+			report(job, summary.QuoteNumber)
+			continue
+		}
+		legacyTerms = legacyTerms || job.terms == nil
+		jobs = append(jobs, job)
+	}
+	if legacyTerms {
+		warnLegacyTermsFile()
+	}
+
+	// La configuration de pdfcpu est chargée une fois avant le rendu parallèle
+	model.NewDefaultConfiguration()
+
+	pending := make(chan *quoteBatchJob)
+	results := make(chan *quoteBatchJob)
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for job := range pending {
+				var content []byte
+				job.doc, content, job.err = renderQuotePDF(job.quote, job.company, job.terms, job.theme, archive)
+				if job.err == nil {
+					if mergeFile != "" {
+						job.content = content
+					}
+					job.err = saveQuotePDF(job.quote, job.company, job.doc, content, job.filename, archive)
+				}
+				results <- job
+			}
+		}()
+	}
+	go func() {
+		for _, job := range jobs {
+			pending <- job
+		}
+		close(pending)
+		wg.Wait()
+		close(results)
+	}()
+
+	for job := range results {
+		if job.err == nil {
+			record := &models.GeneratedDocument{
+				DocumentType: models.DocumentQuote,
+				DocumentID:   job.quote.ID,
+				FilePath:     job.filename,
+			}
+			if job.terms != nil {
+				record.TermsID = job.terms.ID
+			}
+			if err := database.RecordGeneratedDocument(record); err != nil {
+				utils.Warning("Le PDF a été généré mais n'a pas pu être enregistré dans l'historique: %v", err)
+			}
+		}
+		report(job, job.quote.QuoteNumber)
+	}
+
+	if failed > 0 {
+		utils.Warning("%d PDF(s) générés, %d en erreur", total-failed, failed)
+	} else {
+		utils.Success("%d PDF(s) générés", total)
+	}
+
+	// Fichiers réunis, dans l'ordre de la sélection
+	var generated []*quoteBatchJob
+	var files []string
+	for _, job := range jobs {
+		if job.err == nil {
+			generated = append(generated, job)
+			files = append(files, job.filename)
+		}
+	}
+	if len(files) == 0 {
+		return
+	}
+	if mergeFile != "" {
+		if err := mergeBatchPDFs(generated, company, expandHome(mergeFile), archive); err != nil {
+			if !printConformance(err) {
+				utils.Error("Erreur lors de la fusion des PDFs: %v", err)
+			}
+		} else {
+			utils.Success("PDFs réunis: %s", mergeFile)
+		}
+	}
+	if zipFile != "" {
+		if err := zipBatchPDFs(files, expandHome(zipFile)); err != nil {
+			utils.Error("Erreur lors de la création de l'archive ZIP: %v", err)
+		} else {
+			utils.Success("Archive ZIP créée: %s", zipFile)
+		}
+	}
+}
+
+// prepareBatchQuote charge un devis et tout ce que son rendu demande. Les
+// entreprises sont lues une fois par lot ; le chemin attribué est réservé
+// pour que deux devis du lot n'écrivent pas le même fichier.
+func prepareBatchQuote(cmd *cobra.Command, database *db.Database, id int, language string, companies map[int]*models.Company, reserved map[string]bool) *quoteBatchJob {
+	job := &quoteBatchJob{}
+	quote, err := database.GetQuote(id)
+	if err != nil {
+		job.err = fmt.Errorf(i18n.T("devis non trouvé: %v"), err)
+		return job
+	}
+	job.quote = quote
+	if language != "" {
+		quote.Language = language
+	}
+
+	company, ok := companies[quote.CompanyID]
+	if !ok {
+		if company, err = quoteCompany(database, quote); err != nil {
+			job.err = fmt.Errorf(i18n.T("erreur lors de la récupération des infos société: %v"), err)
+			return job
+		}
+		companies[quote.CompanyID] = company
+		if company != nil {
+			warnLegacyLogoFile(len(company.Logo) > 0)
+		}
+	}
+	if err := checkCompanyLegalProfile(company); err != nil {
+		job.err = err
+		return job
+	}
+	job.company = company
+
+	// Le mot de passe du certificat est demandé maintenant, avant le rendu
+	// parallèle
+	if company.PDFCertificate != "" {
+		if _, err := companySigner(company); err != nil {
+			job.err = err
+			return job
+		}
+	}
+
+	// Un thème par devis ; l'entreprise, partagée entre les devis du lot, n'est
+	// que lue pendant le rendu
+	if job.theme, err = renderingTheme(cmd, company); err != nil {
+		job.err = fmt.Errorf(i18n.T("thème invalide: %v"), err)
+		return job
+	}
+
+	if job.terms, err = database.FindApplicableTerms(company.ID, models.DocumentQuote, quote.Client.Category, quote.Date); err != nil {
+		job.err = fmt.Errorf(i18n.T("erreur lors de la sélection des CGV: %v"), err)
+		return job
+	}
+
+	if job.filename, err = quoteOutputPath(cmd, database, quote, company, ".pdf", reserved); err != nil {
+		job.err = err
+		return job
+	}
+	reserved[job.filename] = true
+	if err := os.MkdirAll(filepath.Dir(job.filename), 0755); err != nil {
+		job.err = err
+	}
+	return job
+}
+
+// mergeBatchPDFs réunit les PDFs d'un lot en un seul fichier. Les devis sont
+// réunis tels que rendus, avant chiffrement et signature, puis le fichier
+// réuni est finalisé une fois selon l'entreprise du lot : métadonnées, PDF/A
+// avec les données de chaque devis, chiffrement et signature.
+func mergeBatchPDFs(jobs []*quoteBatchJob, company *models.Company, output string, archive bool) error {
+	if err := os.MkdirAll(filepath.Dir(output), 0755); err != nil {
+		return err
+	}
+
+	var contents []io.ReadSeeker
+	var attachments []render.Attachment
+	for _, job := range jobs {
+		contents = append(contents, bytes.NewReader(job.content))
+		if archive {
+			data, err := quoteArchiveData(job.quote, job.company, job.doc)
+			if err != nil {
+				return err
+			}
+			attachments = append(attachments, data)
+		}
+	}
+	var merged bytes.Buffer
+	if err := api.MergeRaw(contents, &merged, false, nil); err != nil {
+		return fmt.Errorf(i18n.T("erreur lors de la fusion PDF: %w"), err)
+	}
+
+	content, err := finalizePDF(merged.Bytes(), batchDocument(jobs, company), company, archive, attachments...)
+	if err != nil {
+		return err
+	}
+	return writePDF(output, content)
+}
+
+// batchDocument décrit le PDF réuni d'un lot pour ses métadonnées : les
+// numéros du premier et du dernier devis, et l'entreprise du lot
+func batchDocument(jobs []*quoteBatchJob, company *models.Company) *render.Document {
+	number := jobs[0].doc.Number
+	if last := jobs[len(jobs)-1].doc.Number; last != number {
+		number += " - " + last
+	}
+	labels := i18n.Document(companyLocale(company).Language())
+	return &render.Document{
+		Number:    number,
+		Reference: fmt.Sprintf(labels.QuoteReference, number),
+		Company:   company,
+	}
+}
+
+// zipBatchPDFs réunit les PDFs d'un lot dans une archive ZIP, avec leurs
+// chemins relatifs au dossier qui les contient tous
+func zipBatchPDFs(files []string, output string) error {
+	if err := os.MkdirAll(filepath.Dir(output), 0755); err != nil {
+		return err
+	}
+	base := filepath.Dir(files[0])
+	for _, file := range files[1:] {
+		for !strings.HasPrefix(file, base+string(filepath.Separator)) && base != filepath.Dir(base) {
+			base = filepath.Dir(base)
+		}
+	}
+
+	out, err := os.Create(output)
+	if err != nil {
+		return err
+	}
+	archive := zip.NewWriter(out)
+	for _, file := range files {
+		if err = addZipFile(archive, file, base); err != nil {
+			break
+		}
+	}
+	err = errors.Join(err, archive.Close(), out.Close())
+	if err != nil {
+		os.Remove(output)
+	}
+	return err
+}
+
+func addZipFile(archive *zip.Writer, file, base string) error {
+	in, err := os.Open(file)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+	info, err := in.Stat()
+	if err != nil {
+		return err
+	}
+	header, err := zip.FileInfoHeader(info)
+	if err != nil {
+		return err
+	}
+	name, err := filepath.Rel(base, file)
+	if err != nil {
+		return err
+	}
+	header.Name = filepath.ToSlash(name)
+	header.Method = zip.Deflate
+	w, err := archive.CreateHeader(header)
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(w, in)
+	return err
+}
@@ -0,0 +1,265 @@
+package cmd
+
+import (
+	"archive/zip"
+	"bytes"
+	"os"
+	"outbil/db"
+	"outbil/models"
+	"outbil/render"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/pdfcpu/pdfcpu/pkg/api"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+)
+
+func day(year int, month time.Month, d int) time.Time {
+	return time.Date(year, month, d, 0, 0, 0, 0, time.Local)
+}
+
+func TestQuoteSelection(t *testing.T) {
+	quotes := []models.Quote{
+		{ID: 1, CompanyID: 1, Status: models.StatusSent, Date: day(2025, 10, 1)},
+		{ID: 2, CompanyID: 2, Status: models.StatusSent, Date: day(2025, 10, 15)},
+		{ID: 3, CompanyID: 0, Status: models.StatusDraft, Date: day(2025, 10, 31)},
+		{ID: 4, CompanyID: 1, Status: models.StatusAccepted, Date: day(2025, 11, 1)},
+		{ID: 5, CompanyID: 1, Status: models.StatusSent, Date: day(2025, 9, 30)},
+		// Même date que le devis 2 : départagé par l'ID
+		{ID: 6, CompanyID: 1, Status: models.StatusDraft, Date: day(2025, 10, 15)},
+	}
+
+	tests := []struct {
+		name             string
+		status, from, to string
+		companyID        int
+		want             []int
+	}{
+		{name: "tous, ordre chronologique", want: []int{5, 1, 2, 6, 3, 4}},
+		{name: "statut", status: models.StatusSent, want: []int{5, 1, 2}},
+		{name: "début inclus", from: "01/10/2025", want: []int{1, 2, 6, 3, 4}},
+		{name: "fin incluse", to: "31/10/2025", want: []int{5, 1, 2, 6, 3}},
+		{name: "fin au format ISO", to: "2025-10-15", want: []int{5, 1, 2, 6}},
+		{name: "mois", from: "01/10/2025", to: "31/10/2025", want: []int{1, 2, 6, 3}},
+		{name: "entreprise par défaut pour les devis sans entreprise", companyID: 1, want: []int{5, 1, 6, 3, 4}},
+		{name: "autre entreprise", companyID: 2, want: []int{2}},
+		{name: "combinaison", companyID: 1, status: models.StatusDraft, from: "01/10/2025", to: "31/10/2025", want: []int{6, 3}},
+		{name: "aucun", status: models.StatusExpired, want: nil},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			selection, err := parseQuoteSelection(test.status, test.from, test.to)
+			if err != nil {
+				t.Fatal(err)
+			}
+			selection.companyID = test.companyID
+			selection.defaultCompanyID = 1
+
+			var got []int
+			for _, quote := range selection.apply(quotes) {
+				got = append(got, quote.ID)
+			}
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf("devis %v, attendu %v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestParseQuoteSelectionErrors(t *testing.T) {
+	tests := []struct{ status, from, to string }{
+		{status: "paid"},
+		{from: "31/02/2025"},
+		{to: "demain"},
+	}
+	for _, test := range tests {
+		if _, err := parseQuoteSelection(test.status, test.from, test.to); err == nil {
+			t.Errorf("%+v: erreur attendue", test)
+		}
+	}
+}
+
+func TestZipBatchPDFs(t *testing.T) {
+	tests := []struct {
+		name  string
+		files []string
+		want  []string
+	}{
+		{name: "un fichier", files: []string{"devis/a.pdf"}, want: []string{"a.pdf"}},
+		{name: "même dossier", files: []string{"devis/a.pdf", "devis/b.pdf"}, want: []string{"a.pdf", "b.pdf"}},
+		{name: "sous-dossiers", files: []string{"devis/2025/a.pdf", "devis/2025/b.pdf", "devis/2026/c.pdf"},
+			want: []string{"2025/a.pdf", "2025/b.pdf", "2026/c.pdf"}},
+		{name: "préfixe commun qui n'est pas un dossier", files: []string{"devis/acme/a.pdf", "devis/acme-2/b.pdf"},
+			want: []string{"acme-2/b.pdf", "acme/a.pdf"}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			root := t.TempDir()
+			var files []string
+			for _, file := range test.files {
+				path := filepath.Join(root, filepath.FromSlash(file))
+				if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+					t.Fatal(err)
+				}
+				if err := os.WriteFile(path, []byte(file), 0644); err != nil {
+					t.Fatal(err)
+				}
+				files = append(files, path)
+			}
+
+			output := filepath.Join(root, "lot", "devis.zip")
+			if err := zipBatchPDFs(files, output); err != nil {
+				t.Fatal(err)
+			}
+			archive, err := zip.OpenReader(output)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer archive.Close()
+
+			var got []string
+			for _, entry := range archive.File {
+				got = append(got, entry.Name)
+			}
+			sort.Strings(got)
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf("entrées %v, attendu %v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestMergeBatchPDFs(t *testing.T) {
+	company := &models.Company{
+		ID: 1, Name: "Acme Conseil", City: "Paris", Currency: "EUR", Locale: "fr-FR",
+		PDFProtection: models.PDFProtectionRead, PDFUserPassword: "secret",
+	}
+	theme, _ := render.Builtin(render.DefaultTheme)
+
+	var jobs []*quoteBatchJob
+	pages := 0
+	for i, number := range []string{"0001", "0002"} {
+		quote := &models.Quote{
+			ID: i + 1, QuoteNumber: number, Currency: "EUR", Status: models.StatusSent,
+			Date: day(2025, 10, i+1), ValidUntil: day(2025, 11, i+1),
+			Client: &models.Client{Name: "Jean Dupont"},
+			Items:  []models.QuoteItem{{Description: "Conseil", Quantity: 1, UnitPrice: 500, TaxRate: 20, Amount: 500}},
+		}
+		job := &quoteBatchJob{quote: quote, company: company}
+		var err error
+		if job.doc, job.content, err = renderQuotePDF(quote, company, nil, theme, false); err != nil {
+			t.Fatal(err)
+		}
+		count, err := api.PageCount(bytes.NewReader(job.content), nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		pages += count
+		jobs = append(jobs, job)
+	}
+
+	output := filepath.Join(t.TempDir(), "lot", "octobre.pdf")
+	if err := mergeBatchPDFs(jobs, company, output, false); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(output + ".temp"); !os.IsNotExist(err) {
+		t.Errorf("fichier temporaire laissé: %v", err)
+	}
+	content, err := os.ReadFile(output)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := api.PageCount(bytes.NewReader(content), nil); err == nil {
+		t.Error("PDF réuni lisible sans mot de passe")
+	}
+	conf := model.NewDefaultConfiguration()
+	conf.UserPW = "secret"
+	info, err := api.PDFInfo(bytes.NewReader(content), output, nil, false, conf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.PageCount != pages {
+		t.Errorf("%d pages, attendu %d", info.PageCount, pages)
+	}
+	if !info.Encrypted || info.Permissions&int(model.PermissionPrintRev2) != 0 {
+		t.Errorf("chiffré %v, permissions %#x: lecture seule attendue", info.Encrypted, info.Permissions)
+	}
+	if want := "Devis 2025-10-0001 - 2025-10-0002"; info.Title != want {
+		t.Errorf("titre %q, attendu %q", info.Title, want)
+	}
+}
+
+func TestResolveOutputConflict(t *testing.T) {
+	database, err := db.New(filepath.Join(t.TempDir(), "outbil.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer database.Close()
+
+	dir := t.TempDir()
+	path := func(name string) string { return filepath.Join(dir, name) }
+	for _, name := range []string{"autre.pdf", "meme.pdf", "lot-2.pdf"} {
+		if err := os.WriteFile(path(name), nil, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// meme.pdf a été généré pour le devis 7, autre.pdf pour le devis 8
+	for id, name := range map[int]string{7: "meme.pdf", 8: "autre.pdf"} {
+		record := &models.GeneratedDocument{DocumentType: models.DocumentQuote, DocumentID: id, FilePath: path(name)}
+		if err := database.RecordGeneratedDocument(record); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		name     string
+		file     string
+		conflict string
+		reserved []string
+		want     string // vide si une erreur est attendue
+	}{
+		{name: "fichier libre", file: "libre.pdf", conflict: conflictFail, want: "libre.pdf"},
+		{name: "fichier du même devis", file: "meme.pdf", conflict: conflictFail, want: "meme.pdf"},
+		{name: "autre devis, rename", file: "autre.pdf", conflict: conflictRename, want: "autre-2.pdf"},
+		{name: "autre devis, overwrite", file: "autre.pdf", conflict: conflictOverwrite, want: "autre.pdf"},
+		{name: "autre devis, fail", file: "autre.pdf", conflict: conflictFail},
+		{name: "réservé, rename", file: "libre.pdf", conflict: conflictRename, reserved: []string{"libre.pdf"}, want: "libre-2.pdf"},
+		{name: "réservé, fail", file: "libre.pdf", conflict: conflictFail, reserved: []string{"libre.pdf"}},
+		{name: "réservé, overwrite ne remplace pas le lot", file: "libre.pdf", conflict: conflictOverwrite,
+			reserved: []string{"libre.pdf"}, want: "libre-2.pdf"},
+		{name: "réservé puis fichier existant", file: "lot.pdf", conflict: conflictRename,
+			reserved: []string{"lot.pdf"}, want: "lot-3.pdf"},
+		{name: "plusieurs réservés", file: "libre.pdf", conflict: conflictRename,
+			reserved: []string{"libre.pdf", "libre-2.pdf"}, want: "libre-3.pdf"},
+		{name: "même devis déjà réservé", file: "meme.pdf", conflict: conflictRename,
+			reserved: []string{"meme.pdf"}, want: "meme-2.pdf"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			reserved := map[string]bool{}
+			for _, name := range test.reserved {
+				reserved[path(name)] = true
+			}
+			got, err := resolveOutputConflict(database, path(test.file), models.DocumentQuote, 7, test.conflict, reserved)
+			if test.want == "" {
+				if err == nil {
+					t.Errorf("%s: erreur attendue", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != path(test.want) {
+				t.Errorf("%s, attendu %s", got, path(test.want))
+			}
+		})
+	}
+}
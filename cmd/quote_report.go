@@ -0,0 +1,172 @@
+package cmd
+
+import (
+	"fmt"
+	"outbil/db"
+	"outbil/i18n"
+	"outbil/models"
+	"outbil/utils"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	quoteCmd.AddCommand(quoteReportCmd)
+
+	quoteReportCmd.Flags().StringP("company", "c", "", "Entreprise émettrice (ID ou nom), par défaut l'entreprise par défaut")
+	quoteReportCmd.Flags().String("status", "", "Ne retenir que les devis de ce statut (draft, sent, accepted...)")
+	quoteReportCmd.Flags().String("from", "", "Date de début (format de l'entreprise ou AAAA-MM-JJ)")
+	quoteReportCmd.Flags().String("to", "", "Date de fin (format de l'entreprise ou AAAA-MM-JJ)")
+}
+
+// reportLine cumule les devis d'une devise
+type reportLine struct {
+	count     int
+	total     float64
+	converted float64
+	missing   int
+}
+
+var quoteReportCmd = &cobra.Command{
+	Use:   "report",
+	Short: "Totaliser les devis dans la devise de l'entreprise",
+	Long: `Totaliser les devis par devise et les convertir dans la devise de référence de
+l'entreprise, avec le taux de change en vigueur à la date de chaque devis.
+Les devis sans taux connu sont signalés et exclus du total converti.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		selector, _ := cmd.Flags().GetString("company")
+		status, _ := cmd.Flags().GetString("status")
+		fromFlag, _ := cmd.Flags().GetString("from")
+		toFlag, _ := cmd.Flags().GetString("to")
+
+		if status != "" && !isQuoteStatus(status) {
+			utils.Error("Statut invalide %q (%s)", status, strings.Join(quoteStatuses, ", "))
+			return
+		}
+
+		database, err := db.New(utils.GetDatabasePath())
+		if err != nil {
+			utils.Error("Erreur d'ouverture de la base: %v", err)
+			return
+		}
+		defer database.Close()
+
+		company, err := requireCompany(database, selector)
+		if err != nil {
+			utils.Error("%v", err)
+			return
+		}
+		base := companyCurrency(company)
+		useCompanyLocale(company)
+
+		// Les dates se saisissent au format de l'entreprise
+		var from, to time.Time
+		if fromFlag != "" {
+			if from, err = utils.ParseDate(fromFlag); err != nil {
+				utils.Error("%v", err)
+				return
+			}
+		}
+		if toFlag != "" {
+			if to, err = utils.ParseDate(toFlag); err != nil {
+				utils.Error("%v", err)
+				return
+			}
+			to = to.AddDate(0, 0, 1)
+		}
+
+		quotes, err := database.ListQuotes()
+		if err != nil {
+			utils.Error("Erreur lors de la récupération des devis: %v", err)
+			return
+		}
+
+		lines := map[string]*reportLine{}
+		var total float64
+		var missing []string
+		for _, quote := range quotes {
+			if quote.CompanyID != company.ID || (status != "" && quote.Status != status) {
+				continue
+			}
+			if (!from.IsZero() && quote.Date.Before(from)) || (!to.IsZero() && !quote.Date.Before(to)) {
+				continue
+			}
+
+			currency := utils.NormalizeCurrency(quote.Currency)
+			line := lines[currency]
+			if line == nil {
+				line = &reportLine{}
+				lines[currency] = line
+			}
+			line.count++
+			line.total += quote.TotalAmount
+
+			factor, ok, err := database.FindExchangeRate(currency, base, quote.Date)
+			if err != nil {
+				utils.Error("Erreur lors de la recherche du taux de change: %v", err)
+				return
+			}
+			if !ok {
+				line.missing++
+				missing = append(missing, fmt.Sprintf("%s-%s (%s)", quote.Date.Format("2006-01"), quote.QuoteNumber, currency))
+				continue
+			}
+			converted := utils.RoundAmount(quote.TotalAmount*factor, base)
+			line.converted += converted
+			total += converted
+		}
+
+		if len(lines) == 0 {
+			utils.Info("Aucun devis trouvé")
+			return
+		}
+
+		currencies := make([]string, 0, len(lines))
+		for currency := range lines {
+			currencies = append(currencies, currency)
+		}
+		sort.Strings(currencies)
+
+		utils.Info("Devis de %s, convertis en %s", company.Name, base)
+
+		table := utils.CreateTable()
+		table.Header(i18n.T("Devise"), i18n.T("Devis"), i18n.T("Total TTC"), fmt.Sprintf(i18n.T("Total en %s"), base))
+		for _, currency := range currencies {
+			line := lines[currency]
+			converted := utils.FormatPrice(line.converted, base)
+			if line.missing > 0 {
+				converted += fmt.Sprintf(i18n.T(" (%d sans taux)"), line.missing)
+			}
+			table.Append([]string{
+				currency,
+				strconv.Itoa(line.count),
+				utils.FormatPrice(line.total, currency),
+				converted,
+			})
+		}
+		table.Render()
+
+		fmt.Printf(i18n.T("\nTotal TTC: %s\n"), utils.FormatPrice(total, base))
+
+		if len(missing) > 0 {
+			utils.Warning("Aucun taux de change vers %s pour %d devis: %v", base, len(missing), missing)
+			utils.Info("Ajoutez un taux avec 'outbil currency rate set <DEVISE> <TAUX>'")
+		}
+	},
+}
+
+// quoteStatuses liste les statuts acceptés par --status
+var quoteStatuses = []string{models.StatusDraft, models.StatusSent, models.StatusAccepted, models.StatusRejected, models.StatusExpired}
+
+func isQuoteStatus(status string) bool {
+	for _, candidate := range quoteStatuses {
+		if candidate == status {
+			return true
+		}
+	}
+	return false
+}
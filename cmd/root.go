@@ -1,7 +1,13 @@
 package cmd
 
 import (
+	"strings"
+
+	"outbil/i18n"
+	"outbil/utils"
+
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
 )
 
 var rootCmd = &cobra.Command{
@@ -18,5 +24,27 @@ Fonctionnalités principales:
 }
 
 func Execute() error {
+	i18n.SetLanguage(i18n.DetectLanguage(utils.GetLanguage()))
+	translateCommand(rootCmd)
 	return rootCmd.Execute()
+}
+
+// translateCommand traduit l'aide d'une commande, de ses options et de ses
+// sous-commandes dans la langue des messages
+func translateCommand(cmd *cobra.Command) {
+	if name, arguments, ok := strings.Cut(cmd.Use, " "); ok {
+		cmd.Use = name + " " + i18n.T(arguments)
+	}
+	cmd.Short = i18n.T(cmd.Short)
+	cmd.Long = i18n.T(cmd.Long)
+
+	translateFlag := func(flag *pflag.Flag) {
+		flag.Usage = i18n.T(flag.Usage)
+	}
+	cmd.Flags().VisitAll(translateFlag)
+	cmd.PersistentFlags().VisitAll(translateFlag)
+
+	for _, child := range cmd.Commands() {
+		translateCommand(child)
+	}
 }
\ No newline at end of file
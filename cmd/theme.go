@@ -0,0 +1,136 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"outbil/db"
+	"outbil/i18n"
+	"outbil/render"
+	"outbil/utils"
+
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	rootCmd.AddCommand(themeCmd)
+	themeCmd.AddCommand(themeListCmd)
+	themeCmd.AddCommand(themeShowCmd)
+	themeCmd.AddCommand(themeTemplateCmd)
+	companyCmd.AddCommand(companyThemeCmd)
+}
+
+var themeCmd = &cobra.Command{
+	Use:   "theme",
+	Short: "Consulter les thèmes de mise en page des PDFs",
+	Long: `Un thème règle la mise en page des PDFs : polices, couleurs, colonnes du tableau
+et ordre des blocs. Les thèmes classic, modern et minimal sont intégrés, ainsi que le
+thème html qui convertit un modèle HTML en PDF ; un fichier <nom>.json placé dans
+~/.outbil/themes ajoute un thème utilisateur.
+
+Pour créer un thème, partez d'un thème existant :
+  outbil theme show modern > ~/.outbil/themes/maison.json`,
+}
+
+var themeListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "Lister les thèmes disponibles",
+	Run: func(cmd *cobra.Command, args []string) {
+		fmt.Print(i18n.T("\n--- Thèmes intégrés ---\n"))
+		for _, name := range render.BuiltinThemes() {
+			theme, _ := render.Builtin(name)
+			fmt.Printf("%-10s %s\n", name, i18n.T(theme.Description))
+		}
+
+		dir := utils.GetThemesDir()
+		names := render.UserThemes(dir)
+		fmt.Printf(i18n.T("\n--- Thèmes utilisateur (%s) ---\n"), dir)
+		if len(names) == 0 {
+			utils.Info("Aucun thème utilisateur")
+			return
+		}
+		for _, name := range names {
+			if _, err := render.LoadTheme(name, dir); err != nil {
+				fmt.Printf(i18n.T("%-10s invalide: %v\n"), name, err)
+				continue
+			}
+			fmt.Println(name)
+		}
+	},
+}
+
+var themeShowCmd = &cobra.Command{
+	Use:   "show [NOM]",
+	Short: "Afficher la définition JSON d'un thème",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		theme, err := render.LoadTheme(args[0], utils.GetThemesDir())
+		if err != nil {
+			utils.Error("%v", err)
+			return
+		}
+
+		data, err := theme.JSON()
+		if err != nil {
+			utils.Error("%v", err)
+			return
+		}
+		os.Stdout.Write(append(data, '\n'))
+	},
+}
+
+var themeTemplateCmd = &cobra.Command{
+	Use:   "template",
+	Short: "Afficher le modèle HTML intégré",
+	Long: `Afficher le modèle HTML intégré, point de départ d'un modèle personnalisé.
+
+Un thème utilise un modèle HTML avec "engine": "html" et "template": "<fichier>" :
+  outbil theme template > ~/.outbil/themes/maison.html
+  echo '{"engine": "html", "template": "maison.html"}' > ~/.outbil/themes/maison.json`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		fmt.Print(render.DefaultTemplate())
+	},
+}
+
+var companyThemeCmd = &cobra.Command{
+	Use:   "theme [NOM]",
+	Short: "Afficher ou choisir le thème des PDFs de l'entreprise",
+	Long: `Afficher ou choisir le thème utilisé pour les PDFs de l'entreprise : un thème
+intégré, un thème utilisateur de ~/.outbil/themes ou le chemin d'un fichier JSON.
+L'option --theme de 'outbil quote pdf' le remplace pour un seul rendu.`,
+	Args: cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		database, err := db.New(utils.GetDatabasePath())
+		if err != nil {
+			utils.Error("Erreur d'ouverture de la base: %v", err)
+			return
+		}
+		defer database.Close()
+
+		selector, _ := cmd.Flags().GetString("company")
+		company, err := requireCompany(database, selector)
+		if err != nil {
+			utils.Error("%v", err)
+			return
+		}
+
+		if len(args) == 0 {
+			fmt.Printf(i18n.T("Thème: %s\n"), company.Theme)
+			return
+		}
+
+		// Le thème est chargé pour le valider avant d'être enregistré
+		theme, err := render.LoadTheme(args[0], utils.GetThemesDir())
+		if err != nil {
+			utils.Error("Thème invalide: %v", err)
+			return
+		}
+
+		company.Theme = args[0]
+		if err := database.SaveCompany(company); err != nil {
+			utils.Error("Erreur lors de la sauvegarde: %v", err)
+			return
+		}
+		utils.Success("Thème des PDFs de %s: %s", company.Name, theme.Name)
+	},
+}
@@ -0,0 +1,143 @@
+package db
+
+import (
+	"database/sql"
+	"fmt"
+	"outbil/models"
+	"time"
+)
+
+// CreateBankAccount enregistre un compte bancaire. Le premier compte d'une
+// entreprise devient son compte par défaut.
+func (db *Database) CreateBankAccount(account *models.BankAccount) error {
+	tx, err := db.conn.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var count int
+	if err := tx.QueryRow(`SELECT COUNT(*) FROM bank_accounts WHERE company_id = ?`, account.CompanyID).Scan(&count); err != nil {
+		return err
+	}
+	if count == 0 {
+		account.IsDefault = true
+	}
+
+	if account.IsDefault {
+		if _, err := tx.Exec(`UPDATE bank_accounts SET is_default = 0 WHERE company_id = ?`, account.CompanyID); err != nil {
+			return err
+		}
+	}
+
+	query := `INSERT INTO bank_accounts (company_id, label, holder, iban, bic, is_default) VALUES (?, ?, ?, ?, ?, ?)`
+
+	result, err := tx.Exec(query, account.CompanyID, account.Label, account.Holder, account.IBAN, account.BIC, account.IsDefault)
+	if err != nil {
+		return err
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return err
+	}
+
+	account.ID = int(id)
+	account.CreatedAt = time.Now()
+
+	return tx.Commit()
+}
+
+func (db *Database) GetBankAccount(id int) (*models.BankAccount, error) {
+	query := `SELECT id, company_id, COALESCE(label, ''), holder, iban, COALESCE(bic, ''), is_default, created_at
+			  FROM bank_accounts WHERE id = ?`
+
+	account := &models.BankAccount{}
+	err := db.conn.QueryRow(query, id).Scan(
+		&account.ID, &account.CompanyID, &account.Label, &account.Holder,
+		&account.IBAN, &account.BIC, &account.IsDefault, &account.CreatedAt,
+	)
+
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("bank account not found")
+	}
+
+	return account, err
+}
+
+func (db *Database) ListBankAccounts(companyID int) ([]models.BankAccount, error) {
+	query := `SELECT id, company_id, COALESCE(label, ''), holder, iban, COALESCE(bic, ''), is_default, created_at
+			  FROM bank_accounts WHERE company_id = ? ORDER BY is_default DESC, id`
+
+	rows, err := db.conn.Query(query, companyID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var accounts []models.BankAccount
+	for rows.Next() {
+		var account models.BankAccount
+		err := rows.Scan(
+			&account.ID, &account.CompanyID, &account.Label, &account.Holder,
+			&account.IBAN, &account.BIC, &account.IsDefault, &account.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		accounts = append(accounts, account)
+	}
+
+	return accounts, rows.Err()
+}
+
+func (db *Database) SetDefaultBankAccount(id int) error {
+	account, err := db.GetBankAccount(id)
+	if err != nil {
+		return err
+	}
+
+	tx, err := db.conn.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec(`UPDATE bank_accounts SET is_default = 0 WHERE company_id = ?`, account.CompanyID); err != nil {
+		return err
+	}
+	if _, err := tx.Exec(`UPDATE bank_accounts SET is_default = 1 WHERE id = ?`, id); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// DeleteBankAccount supprime un compte ; si c'était le compte par défaut, le
+// plus ancien compte restant le remplace
+func (db *Database) DeleteBankAccount(id int) error {
+	account, err := db.GetBankAccount(id)
+	if err != nil {
+		return err
+	}
+
+	tx, err := db.conn.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec(`DELETE FROM bank_accounts WHERE id = ?`, id); err != nil {
+		return err
+	}
+
+	if account.IsDefault {
+		query := `UPDATE bank_accounts SET is_default = 1
+				  WHERE id = (SELECT MIN(id) FROM bank_accounts WHERE company_id = ?)`
+		if _, err := tx.Exec(query, account.CompanyID); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
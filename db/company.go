@@ -0,0 +1,294 @@
+package db
+
+import (
+	"database/sql"
+	"fmt"
+	"outbil/i18n"
+	"outbil/models"
+	"strconv"
+	"strings"
+)
+
+const companyColumns = `id, name, email, phone, address, city, postal_code, country, tax_id, COALESCE(siret, ''),
+			  logo, website, currency, tax_rate, COALESCE(legal_form, ''), COALESCE(share_capital, 0),
+			  COALESCE(registry_type, ''), COALESCE(registry_city, ''), COALESCE(ape_code, ''),
+			  COALESCE(insurer_name, ''), COALESCE(insurance_policy, ''), COALESCE(insurance_coverage, ''),
+			  COALESCE(payment_terms, ''), COALESCE(is_default, 0), COALESCE(locale, 'fr-FR'),
+			  COALESCE(theme, 'classic'), COALESCE(pdf_protection, 'print'), COALESCE(pdf_owner_password, ''),
+			  COALESCE(pdf_user_password, ''), COALESCE(pdf_certificate, '')`
+
+func scanCompany(row rowScanner) (*models.Company, error) {
+	company := &models.Company{}
+	err := row.Scan(
+		&company.ID, &company.Name, &company.Email, &company.Phone, &company.Address,
+		&company.City, &company.PostalCode, &company.Country, &company.TaxID, &company.SIRET,
+		&company.Logo, &company.Website, &company.Currency, &company.TaxRate,
+		&company.LegalForm, &company.ShareCapital, &company.RegistryType, &company.RegistryCity,
+		&company.APECode, &company.InsurerName, &company.InsurancePolicy, &company.InsuranceCoverage,
+		&company.PaymentTerms, &company.IsDefault, &company.Locale, &company.Theme,
+		&company.PDFProtection, &company.PDFOwnerPassword, &company.PDFUserPassword,
+		&company.PDFCertificate,
+	)
+	return company, err
+}
+
+// GetCompany retourne l'entreprise par défaut, ou nil si aucune n'est configurée
+func (db *Database) GetCompany() (*models.Company, error) {
+	query := `SELECT ` + companyColumns + ` FROM companies ORDER BY is_default DESC, id LIMIT 1`
+
+	company, err := scanCompany(db.conn.QueryRow(query))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	company.BankAccounts, err = db.ListBankAccounts(company.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return company, nil
+}
+
+func (db *Database) GetCompanyByID(id int) (*models.Company, error) {
+	query := `SELECT ` + companyColumns + ` FROM companies WHERE id = ?`
+
+	company, err := scanCompany(db.conn.QueryRow(query, id))
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("company not found")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	company.BankAccounts, err = db.ListBankAccounts(company.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return company, nil
+}
+
+// FindCompany retrouve une entreprise par son ID ou son nom (sans tenir compte de
+// la casse). Un sélecteur vide désigne l'entreprise par défaut.
+func (db *Database) FindCompany(selector string) (*models.Company, error) {
+	selector = strings.TrimSpace(selector)
+	if selector == "" {
+		return db.GetCompany()
+	}
+	if id, err := strconv.Atoi(selector); err == nil {
+		return db.GetCompanyByID(id)
+	}
+
+	var id int
+	err := db.conn.QueryRow(`SELECT id FROM companies WHERE LOWER(name) = LOWER(?)`, selector).Scan(&id)
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf(i18n.T("aucune entreprise nommée %q"), selector)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return db.GetCompanyByID(id)
+}
+
+func (db *Database) ListCompanies() ([]models.Company, error) {
+	query := `SELECT ` + companyColumns + ` FROM companies ORDER BY is_default DESC, name`
+
+	rows, err := db.conn.Query(query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var companies []models.Company
+	for rows.Next() {
+		company, err := scanCompany(rows)
+		if err != nil {
+			return nil, err
+		}
+		companies = append(companies, *company)
+	}
+
+	return companies, rows.Err()
+}
+
+// SaveCompany crée l'entreprise si elle n'a pas encore d'ID, la met à jour sinon.
+// La première entreprise créée devient l'entreprise par défaut.
+func (db *Database) SaveCompany(company *models.Company) error {
+	if company.ID == 0 {
+		var count int
+		if err := db.conn.QueryRow(`SELECT COUNT(*) FROM companies`).Scan(&count); err != nil {
+			return err
+		}
+		company.IsDefault = count == 0
+
+		query := `INSERT INTO companies (name, email, phone, address, city, postal_code, country, tax_id, siret, logo, website, currency, tax_rate,
+				  legal_form, share_capital, registry_type, registry_city, ape_code, insurer_name, insurance_policy, insurance_coverage, payment_terms, is_default, locale, theme,
+				  pdf_protection, pdf_owner_password, pdf_user_password, pdf_certificate)
+				  VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
+
+		result, err := db.conn.Exec(query, company.Name, company.Email, company.Phone, company.Address,
+			company.City, company.PostalCode, company.Country, company.TaxID, company.SIRET,
+			company.Logo, company.Website, company.Currency, company.TaxRate,
+			company.LegalForm, company.ShareCapital, company.RegistryType, company.RegistryCity, company.APECode,
+			company.InsurerName, company.InsurancePolicy, company.InsuranceCoverage, company.PaymentTerms, company.IsDefault, company.Locale, company.Theme,
+			company.PDFProtection, company.PDFOwnerPassword, company.PDFUserPassword, company.PDFCertificate)
+		if err != nil {
+			return err
+		}
+
+		id, _ := result.LastInsertId()
+		company.ID = int(id)
+		return nil
+	}
+
+	query := `UPDATE companies SET name=?, email=?, phone=?, address=?, city=?, postal_code=?,
+			  country=?, tax_id=?, siret=?, logo=?, website=?, currency=?, tax_rate=?,
+			  legal_form=?, share_capital=?, registry_type=?, registry_city=?, ape_code=?,
+			  insurer_name=?, insurance_policy=?, insurance_coverage=?, payment_terms=?, locale=?, theme=?,
+			  pdf_protection=?, pdf_owner_password=?, pdf_user_password=?, pdf_certificate=? WHERE id=?`
+
+	_, err := db.conn.Exec(query, company.Name, company.Email, company.Phone, company.Address,
+		company.City, company.PostalCode, company.Country, company.TaxID, company.SIRET,
+		company.Logo, company.Website, company.Currency, company.TaxRate,
+		company.LegalForm, company.ShareCapital, company.RegistryType, company.RegistryCity, company.APECode,
+		company.InsurerName, company.InsurancePolicy, company.InsuranceCoverage, company.PaymentTerms,
+		company.Locale, company.Theme, company.PDFProtection, company.PDFOwnerPassword, company.PDFUserPassword,
+		company.PDFCertificate, company.ID)
+
+	return err
+}
+
+func (db *Database) SetDefaultCompany(id int) error {
+	if _, err := db.GetCompanyByID(id); err != nil {
+		return err
+	}
+
+	tx, err := db.conn.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec(`UPDATE companies SET is_default = (id = ?)`, id); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (db *Database) CountCompanyQuotes(id int) (int, error) {
+	var count int
+	err := db.conn.QueryRow("SELECT COUNT(*) FROM quotes WHERE company_id = ?", id).Scan(&count)
+	return count, err
+}
+
+// DeleteCompany supprime une entreprise sans devis, avec ses comptes bancaires,
+// CGV et numérotations. Si c'était l'entreprise par défaut, la plus ancienne
+// entreprise restante la remplace.
+func (db *Database) DeleteCompany(id int) error {
+	company, err := db.GetCompanyByID(id)
+	if err != nil {
+		return err
+	}
+
+	count, err := db.CountCompanyQuotes(id)
+	if err != nil {
+		return err
+	}
+	if count > 0 {
+		return fmt.Errorf(i18n.T("l'entreprise a émis %d devis"), count)
+	}
+
+	tx, err := db.conn.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec("DELETE FROM companies WHERE id = ?", id); err != nil {
+		return err
+	}
+
+	if company.IsDefault {
+		query := `UPDATE companies SET is_default = 1 WHERE id = (SELECT MIN(id) FROM companies)`
+		if _, err := tx.Exec(query); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// GetNumberSequence retourne la numérotation d'un type de document, ou nil si
+// l'entreprise utilise les identifiants aléatoires
+func (db *Database) GetNumberSequence(companyID int, documentType string) (*models.NumberSequence, error) {
+	query := `SELECT company_id, document_type, prefix, next_value, padding
+			  FROM number_sequences WHERE company_id = ? AND document_type = ?`
+
+	seq := &models.NumberSequence{}
+	err := db.conn.QueryRow(query, companyID, documentType).Scan(
+		&seq.CompanyID, &seq.DocumentType, &seq.Prefix, &seq.NextValue, &seq.Padding,
+	)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+
+	return seq, err
+}
+
+func (db *Database) SaveNumberSequence(seq *models.NumberSequence) error {
+	query := `INSERT INTO number_sequences (company_id, document_type, prefix, next_value, padding)
+			  VALUES (?, ?, ?, ?, ?)
+			  ON CONFLICT (company_id, document_type)
+			  DO UPDATE SET prefix = excluded.prefix, next_value = excluded.next_value, padding = excluded.padding`
+
+	_, err := db.conn.Exec(query, seq.CompanyID, seq.DocumentType, seq.Prefix, seq.NextValue, seq.Padding)
+	return err
+}
+
+// DeleteNumberSequence revient aux identifiants aléatoires
+func (db *Database) DeleteNumberSequence(companyID int, documentType string) error {
+	_, err := db.conn.Exec(`DELETE FROM number_sequences WHERE company_id = ? AND document_type = ?`, companyID, documentType)
+	return err
+}
+
+// allocateNumber attribue le prochain numéro de la séquence de l'entreprise dans
+// la transaction, ou un identifiant aléatoire si aucune séquence n'est définie.
+// Les numéros déjà utilisés (préfixe partagé entre entreprises) sont sautés.
+func (db *Database) allocateNumber(tx *sql.Tx, companyID int, documentType string) (string, error) {
+	seq := &models.NumberSequence{}
+	err := tx.QueryRow(`SELECT company_id, document_type, prefix, next_value, padding
+			  FROM number_sequences WHERE company_id = ? AND document_type = ?`, companyID, documentType).Scan(
+		&seq.CompanyID, &seq.DocumentType, &seq.Prefix, &seq.NextValue, &seq.Padding,
+	)
+	if err == sql.ErrNoRows {
+		return db.GetNextQuoteNumber()
+	}
+	if err != nil {
+		return "", err
+	}
+
+	value := seq.NextValue
+	for {
+		var exists int
+		if err := tx.QueryRow(`SELECT COUNT(*) FROM quotes WHERE quote_number = ?`, seq.Format(value)).Scan(&exists); err != nil {
+			return "", err
+		}
+		if exists == 0 {
+			break
+		}
+		value++
+	}
+
+	_, err = tx.Exec(`UPDATE number_sequences SET next_value = ? WHERE company_id = ? AND document_type = ?`,
+		value+1, companyID, documentType)
+	if err != nil {
+		return "", err
+	}
+
+	return seq.Format(value), nil
+}
@@ -0,0 +1,189 @@
+package db
+
+import (
+	"database/sql"
+	"fmt"
+	"outbil/models"
+	"time"
+)
+
+func (db *Database) CreateClientContact(contact *models.ClientContact) error {
+	query := `INSERT INTO client_contacts (client_id, name, role, email, phone) VALUES (?, ?, ?, ?, ?)`
+
+	result, err := db.conn.Exec(query, contact.ClientID, contact.Name, contact.Role, contact.Email, contact.Phone)
+	if err != nil {
+		return err
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return err
+	}
+
+	contact.ID = int(id)
+	contact.CreatedAt = time.Now()
+
+	return nil
+}
+
+func (db *Database) GetClientContact(id int) (*models.ClientContact, error) {
+	query := `SELECT id, client_id, name, role, email, phone, created_at FROM client_contacts WHERE id = ?`
+
+	contact := &models.ClientContact{}
+	err := db.conn.QueryRow(query, id).Scan(
+		&contact.ID, &contact.ClientID, &contact.Name, &contact.Role,
+		&contact.Email, &contact.Phone, &contact.CreatedAt,
+	)
+
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("contact not found")
+	}
+
+	return contact, err
+}
+
+func (db *Database) ListClientContacts(clientID int) ([]models.ClientContact, error) {
+	query := `SELECT id, client_id, name, role, email, phone, created_at 
+			  FROM client_contacts WHERE client_id = ? ORDER BY name`
+
+	rows, err := db.conn.Query(query, clientID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var contacts []models.ClientContact
+	for rows.Next() {
+		var contact models.ClientContact
+		err := rows.Scan(
+			&contact.ID, &contact.ClientID, &contact.Name, &contact.Role,
+			&contact.Email, &contact.Phone, &contact.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		contacts = append(contacts, contact)
+	}
+
+	return contacts, nil
+}
+
+func (db *Database) UpdateClientContact(contact *models.ClientContact) error {
+	query := `UPDATE client_contacts SET name=?, role=?, email=?, phone=? WHERE id=?`
+
+	_, err := db.conn.Exec(query, contact.Name, contact.Role, contact.Email, contact.Phone, contact.ID)
+	return err
+}
+
+func (db *Database) DeleteClientContact(id int) error {
+	// Détacher le contact des devis qui le citent avant de le supprimer
+	tx, err := db.conn.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec("UPDATE quotes SET contact_id = NULL WHERE contact_id = ?", id); err != nil {
+		return err
+	}
+	if _, err := tx.Exec("DELETE FROM client_contacts WHERE id = ?", id); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (db *Database) CreateClientAddress(address *models.ClientAddress) error {
+	query := `INSERT INTO client_addresses (client_id, type, label, address, city, postal_code, country) 
+			  VALUES (?, ?, ?, ?, ?, ?, ?)`
+
+	result, err := db.conn.Exec(query, address.ClientID, address.Type, address.Label, address.Address,
+		address.City, address.PostalCode, address.Country)
+	if err != nil {
+		return err
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return err
+	}
+
+	address.ID = int(id)
+	address.CreatedAt = time.Now()
+
+	return nil
+}
+
+func (db *Database) GetClientAddress(id int) (*models.ClientAddress, error) {
+	query := `SELECT id, client_id, type, label, address, city, postal_code, country, created_at 
+			  FROM client_addresses WHERE id = ?`
+
+	address := &models.ClientAddress{}
+	err := db.conn.QueryRow(query, id).Scan(
+		&address.ID, &address.ClientID, &address.Type, &address.Label, &address.Address,
+		&address.City, &address.PostalCode, &address.Country, &address.CreatedAt,
+	)
+
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("address not found")
+	}
+
+	return address, err
+}
+
+// ListClientAddresses retourne les adresses d'un client, filtrées par type si addressType n'est pas vide
+func (db *Database) ListClientAddresses(clientID int, addressType string) ([]models.ClientAddress, error) {
+	query := `SELECT id, client_id, type, label, address, city, postal_code, country, created_at 
+			  FROM client_addresses WHERE client_id = ? AND (? = '' OR type = ?) ORDER BY type, label`
+
+	rows, err := db.conn.Query(query, clientID, addressType, addressType)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var addresses []models.ClientAddress
+	for rows.Next() {
+		var address models.ClientAddress
+		err := rows.Scan(
+			&address.ID, &address.ClientID, &address.Type, &address.Label, &address.Address,
+			&address.City, &address.PostalCode, &address.Country, &address.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		addresses = append(addresses, address)
+	}
+
+	return addresses, nil
+}
+
+func (db *Database) UpdateClientAddress(address *models.ClientAddress) error {
+	query := `UPDATE client_addresses SET type=?, label=?, address=?, city=?, postal_code=?, country=? WHERE id=?`
+
+	_, err := db.conn.Exec(query, address.Type, address.Label, address.Address,
+		address.City, address.PostalCode, address.Country, address.ID)
+	return err
+}
+
+func (db *Database) DeleteClientAddress(id int) error {
+	// Détacher l'adresse des devis qui la citent avant de la supprimer
+	tx, err := db.conn.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	queries := []string{
+		"UPDATE quotes SET billing_address_id = NULL WHERE billing_address_id = ?",
+		"UPDATE quotes SET delivery_address_id = NULL WHERE delivery_address_id = ?",
+		"DELETE FROM client_addresses WHERE id = ?",
+	}
+	for _, query := range queries {
+		if _, err := tx.Exec(query, id); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
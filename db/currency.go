@@ -0,0 +1,109 @@
+package db
+
+import (
+	"database/sql"
+	"fmt"
+	"outbil/i18n"
+	"outbil/models"
+	"time"
+)
+
+// SaveExchangeRate enregistre un taux ; un taux existant pour le même couple de
+// devises et la même date d'effet est remplacé
+func (db *Database) SaveExchangeRate(rate *models.ExchangeRate) error {
+	query := `INSERT INTO exchange_rates (currency, base_currency, rate, effective_from) VALUES (?, ?, ?, ?)
+			  ON CONFLICT (currency, base_currency, effective_from) DO UPDATE SET rate = excluded.rate`
+
+	_, err := db.conn.Exec(query, rate.Currency, rate.BaseCurrency, rate.Rate, rate.EffectiveFrom.Format(termsDateLayout))
+	if err != nil {
+		return err
+	}
+
+	err = db.conn.QueryRow(`SELECT id, created_at FROM exchange_rates
+			  WHERE currency = ? AND base_currency = ? AND effective_from = ?`,
+		rate.Currency, rate.BaseCurrency, rate.EffectiveFrom.Format(termsDateLayout)).Scan(&rate.ID, &rate.CreatedAt)
+
+	return err
+}
+
+func (db *Database) ListExchangeRates() ([]models.ExchangeRate, error) {
+	query := `SELECT id, currency, base_currency, rate, effective_from, created_at
+			  FROM exchange_rates ORDER BY base_currency, currency, effective_from DESC`
+
+	rows, err := db.conn.Query(query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var rates []models.ExchangeRate
+	for rows.Next() {
+		rate, err := scanExchangeRate(rows)
+		if err != nil {
+			return nil, err
+		}
+		rates = append(rates, *rate)
+	}
+
+	return rates, rows.Err()
+}
+
+func (db *Database) DeleteExchangeRate(id int) error {
+	result, err := db.conn.Exec("DELETE FROM exchange_rates WHERE id = ?", id)
+	if err != nil {
+		return err
+	}
+	if count, _ := result.RowsAffected(); count == 0 {
+		return fmt.Errorf("exchange rate not found")
+	}
+	return nil
+}
+
+// FindExchangeRate retourne le facteur de conversion de from vers to en vigueur
+// à une date. Un taux saisi dans le sens inverse est utilisé s'il n'existe pas
+// de taux direct. ok vaut false si aucun taux n'est connu.
+func (db *Database) FindExchangeRate(from, to string, at time.Time) (factor float64, ok bool, err error) {
+	if from == to {
+		return 1, true, nil
+	}
+
+	query := `SELECT id, currency, base_currency, rate, effective_from, created_at FROM exchange_rates
+			  WHERE currency = ? AND base_currency = ? AND effective_from <= ?
+			  ORDER BY effective_from DESC, id DESC LIMIT 1`
+	day := at.Format(termsDateLayout)
+
+	rate, err := scanExchangeRate(db.conn.QueryRow(query, from, to, day))
+	if err == nil {
+		return rate.Rate, true, nil
+	}
+	if err != sql.ErrNoRows {
+		return 0, false, err
+	}
+
+	rate, err = scanExchangeRate(db.conn.QueryRow(query, to, from, day))
+	if err == nil && rate.Rate != 0 {
+		return 1 / rate.Rate, true, nil
+	}
+	if err != nil && err != sql.ErrNoRows {
+		return 0, false, err
+	}
+
+	return 0, false, nil
+}
+
+func scanExchangeRate(row rowScanner) (*models.ExchangeRate, error) {
+	rate := &models.ExchangeRate{}
+	var effectiveFrom string
+
+	err := row.Scan(&rate.ID, &rate.Currency, &rate.BaseCurrency, &rate.Rate, &effectiveFrom, &rate.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	rate.EffectiveFrom, err = time.ParseInLocation(termsDateLayout, effectiveFrom, time.Local)
+	if err != nil {
+		return nil, fmt.Errorf(i18n.T("date d'effet invalide %q: %w"), effectiveFrom, err)
+	}
+
+	return rate, nil
+}
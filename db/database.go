@@ -3,7 +3,10 @@ package db
 import (
 	"crypto/rand"
 	"database/sql"
+	"errors"
 	"fmt"
+	"net/url"
+	"outbil/i18n"
 	"outbil/models"
 	"time"
 
@@ -15,7 +18,8 @@ type Database struct {
 }
 
 func New(dbPath string) (*Database, error) {
-	conn, err := sql.Open("sqlite3", dbPath)
+	// Activer les clés étrangères sur chaque connexion du pool
+	conn, err := sql.Open("sqlite3", dataSourceName(dbPath))
 	if err != nil {
 		return nil, fmt.Errorf("failed to open database: %w", err)
 	}
@@ -24,10 +28,19 @@ func New(dbPath string) (*Database, error) {
 	if err := db.createTables(); err != nil {
 		return nil, fmt.Errorf("failed to create tables: %w", err)
 	}
+	if err := db.migrate(); err != nil {
+		return nil, fmt.Errorf("failed to migrate database: %w", err)
+	}
 
 	return db, nil
 }
 
+// dataSourceName construit l'URI SQLite du fichier : le chemin est échappé
+// pour que ses éventuels ? ou # ne soient pas lus comme des paramètres
+func dataSourceName(dbPath string) string {
+	return "file:" + url.PathEscape(dbPath) + "?_foreign_keys=on"
+}
+
 func (db *Database) Close() error {
 	return db.conn.Close()
 }
@@ -44,10 +57,27 @@ func (db *Database) createTables() error {
 			postal_code TEXT,
 			country TEXT,
 			tax_id TEXT,
+			siret TEXT,
 			logo BLOB,
 			website TEXT,
 			currency TEXT DEFAULT 'EUR',
-			tax_rate REAL DEFAULT 20.0
+			tax_rate REAL DEFAULT 20.0,
+			legal_form TEXT,
+			share_capital REAL DEFAULT 0,
+			registry_type TEXT,
+			registry_city TEXT,
+			ape_code TEXT,
+			insurer_name TEXT,
+			insurance_policy TEXT,
+			insurance_coverage TEXT,
+			payment_terms TEXT,
+			is_default INTEGER DEFAULT 0,
+			locale TEXT DEFAULT 'fr-FR',
+			theme TEXT DEFAULT 'classic',
+			pdf_protection TEXT DEFAULT 'print',
+			pdf_owner_password TEXT,
+			pdf_user_password TEXT,
+			pdf_certificate TEXT
 		)`,
 		`CREATE TABLE IF NOT EXISTS clients (
 			id INTEGER PRIMARY KEY AUTOINCREMENT,
@@ -60,6 +90,12 @@ func (db *Database) createTables() error {
 			country TEXT,
 			company TEXT,
 			tax_id TEXT,
+			siren TEXT,
+			siret TEXT,
+			category TEXT DEFAULT 'b2b',
+			currency TEXT,
+			language TEXT,
+			archived_at TIMESTAMP,
 			created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
 			updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
 		)`,
@@ -75,6 +111,12 @@ func (db *Database) createTables() error {
 			total_amount REAL DEFAULT 0,
 			tax_amount REAL DEFAULT 0,
 			discount REAL DEFAULT 0,
+			contact_id INTEGER REFERENCES client_contacts(id) ON DELETE SET NULL,
+			billing_address_id INTEGER REFERENCES client_addresses(id) ON DELETE SET NULL,
+			delivery_address_id INTEGER REFERENCES client_addresses(id) ON DELETE SET NULL,
+			company_id INTEGER REFERENCES companies(id),
+			currency TEXT,
+			language TEXT,
 			created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
 			updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
 			FOREIGN KEY (client_id) REFERENCES clients(id)
@@ -90,6 +132,93 @@ func (db *Database) createTables() error {
 			created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
 			FOREIGN KEY (quote_id) REFERENCES quotes(id) ON DELETE CASCADE
 		)`,
+		`CREATE TABLE IF NOT EXISTS client_contacts (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			client_id INTEGER NOT NULL,
+			name TEXT NOT NULL,
+			role TEXT,
+			email TEXT,
+			phone TEXT,
+			created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+			FOREIGN KEY (client_id) REFERENCES clients(id) ON DELETE CASCADE
+		)`,
+		`CREATE TABLE IF NOT EXISTS client_addresses (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			client_id INTEGER NOT NULL,
+			type TEXT NOT NULL DEFAULT 'billing',
+			label TEXT,
+			address TEXT,
+			city TEXT,
+			postal_code TEXT,
+			country TEXT,
+			created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+			FOREIGN KEY (client_id) REFERENCES clients(id) ON DELETE CASCADE
+		)`,
+		`CREATE TABLE IF NOT EXISTS client_merges (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			target_client_id INTEGER NOT NULL,
+			source_client_id INTEGER NOT NULL,
+			source_name TEXT NOT NULL,
+			source_data TEXT,
+			quotes_moved INTEGER DEFAULT 0,
+			merged_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+			FOREIGN KEY (target_client_id) REFERENCES clients(id) ON DELETE CASCADE
+		)`,
+		`CREATE TABLE IF NOT EXISTS bank_accounts (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			company_id INTEGER NOT NULL,
+			label TEXT,
+			holder TEXT NOT NULL,
+			iban TEXT NOT NULL,
+			bic TEXT,
+			is_default INTEGER DEFAULT 0,
+			created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+			FOREIGN KEY (company_id) REFERENCES companies(id) ON DELETE CASCADE
+		)`,
+		`CREATE TABLE IF NOT EXISTS number_sequences (
+			company_id INTEGER NOT NULL,
+			document_type TEXT NOT NULL,
+			prefix TEXT NOT NULL DEFAULT '',
+			next_value INTEGER NOT NULL DEFAULT 1,
+			padding INTEGER NOT NULL DEFAULT 4,
+			PRIMARY KEY (company_id, document_type),
+			FOREIGN KEY (company_id) REFERENCES companies(id) ON DELETE CASCADE
+		)`,
+		`CREATE TABLE IF NOT EXISTS terms_documents (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			company_id INTEGER NOT NULL,
+			title TEXT NOT NULL,
+			version TEXT NOT NULL,
+			document_type TEXT NOT NULL DEFAULT '',
+			client_category TEXT NOT NULL DEFAULT '',
+			effective_from TEXT NOT NULL,
+			content BLOB NOT NULL,
+			pages INTEGER DEFAULT 0,
+			sha256 TEXT NOT NULL,
+			created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+			FOREIGN KEY (company_id) REFERENCES companies(id) ON DELETE CASCADE
+		)`,
+		`CREATE TABLE IF NOT EXISTS exchange_rates (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			currency TEXT NOT NULL,
+			base_currency TEXT NOT NULL,
+			rate REAL NOT NULL,
+			effective_from TEXT NOT NULL,
+			created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+			UNIQUE (currency, base_currency, effective_from)
+		)`,
+		`CREATE TABLE IF NOT EXISTS generated_documents (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			document_type TEXT NOT NULL,
+			document_id INTEGER NOT NULL,
+			terms_id INTEGER REFERENCES terms_documents(id) ON DELETE RESTRICT,
+			file_path TEXT NOT NULL,
+			generated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+		)`,
+		`CREATE TABLE IF NOT EXISTS settings (
+			key TEXT PRIMARY KEY,
+			value TEXT NOT NULL
+		)`,
 	}
 
 	for _, query := range queries {
@@ -101,12 +230,136 @@ func (db *Database) createTables() error {
 	return nil
 }
 
+// migrate ajoute aux bases existantes les colonnes apparues après leur création
+func (db *Database) migrate() error {
+	columns := []struct {
+		table, name, definition string
+	}{
+		{"quotes", "contact_id", "INTEGER REFERENCES client_contacts(id) ON DELETE SET NULL"},
+		{"quotes", "billing_address_id", "INTEGER REFERENCES client_addresses(id) ON DELETE SET NULL"},
+		{"quotes", "delivery_address_id", "INTEGER REFERENCES client_addresses(id) ON DELETE SET NULL"},
+		{"clients", "archived_at", "TIMESTAMP"},
+		{"clients", "siren", "TEXT"},
+		{"clients", "siret", "TEXT"},
+		{"clients", "category", "TEXT DEFAULT 'b2b'"},
+		{"companies", "siret", "TEXT"},
+		{"companies", "legal_form", "TEXT"},
+		{"companies", "share_capital", "REAL DEFAULT 0"},
+		{"companies", "registry_type", "TEXT"},
+		{"companies", "registry_city", "TEXT"},
+		{"companies", "ape_code", "TEXT"},
+		{"companies", "insurer_name", "TEXT"},
+		{"companies", "insurance_policy", "TEXT"},
+		{"companies", "insurance_coverage", "TEXT"},
+		{"companies", "payment_terms", "TEXT"},
+		{"companies", "is_default", "INTEGER DEFAULT 0"},
+		{"quotes", "company_id", "INTEGER REFERENCES companies(id)"},
+		{"clients", "currency", "TEXT"},
+		{"quotes", "currency", "TEXT"},
+		{"companies", "locale", "TEXT DEFAULT 'fr-FR'"},
+		{"clients", "language", "TEXT"},
+		{"quotes", "language", "TEXT"},
+		{"companies", "theme", "TEXT DEFAULT 'classic'"},
+		{"companies", "pdf_protection", "TEXT DEFAULT 'print'"},
+		{"companies", "pdf_owner_password", "TEXT"},
+		{"companies", "pdf_user_password", "TEXT"},
+		{"companies", "pdf_certificate", "TEXT"},
+	}
+
+	for _, column := range columns {
+		exists, err := db.columnExists(column.table, column.name)
+		if err != nil {
+			return err
+		}
+		if exists {
+			continue
+		}
+
+		query := fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s %s", column.table, column.name, column.definition)
+		if _, err := db.conn.Exec(query); err != nil {
+			return fmt.Errorf("%s.%s: %w", column.table, column.name, err)
+		}
+	}
+
+	// Bases créées avec une seule entreprise : elle devient l'entreprise par
+	// défaut et les devis existants lui sont rattachés
+	backfill := []string{
+		`UPDATE companies SET is_default = 1
+		 WHERE id = (SELECT MIN(id) FROM companies)
+		 AND NOT EXISTS (SELECT 1 FROM companies WHERE is_default = 1)`,
+		`UPDATE quotes SET company_id = (SELECT id FROM companies WHERE is_default = 1)
+		 WHERE company_id IS NULL`,
+		// Les devis antérieurs aux devises par devis sont dans la devise de leur entreprise
+		`UPDATE quotes SET currency = COALESCE(NULLIF((SELECT currency FROM companies WHERE id = quotes.company_id), ''), 'EUR')
+		 WHERE currency IS NULL OR currency = ''`,
+	}
+	for _, query := range backfill {
+		if _, err := db.conn.Exec(query); err != nil {
+			return err
+		}
+	}
+
+	// Le mot de passe des certificats de signature n'est plus enregistré : il
+	// est effacé des bases qui le contiennent encore
+	exists, err := db.columnExists("companies", "pdf_certificate_password")
+	if err != nil {
+		return err
+	}
+	if exists {
+		if _, err := db.conn.Exec(`UPDATE companies SET pdf_certificate_password = NULL`); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (db *Database) columnExists(table, column string) (bool, error) {
+	rows, err := db.conn.Query(fmt.Sprintf("PRAGMA table_info(%s)", table))
+	if err != nil {
+		return false, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			cid        int
+			name       string
+			columnType string
+			notNull    int
+			defaultVal sql.NullString
+			pk         int
+		)
+		if err := rows.Scan(&cid, &name, &columnType, &notNull, &defaultVal, &pk); err != nil {
+			return false, err
+		}
+		if name == column {
+			return true, nil
+		}
+	}
+
+	return false, rows.Err()
+}
+
+// nullableID convertit un identifiant optionnel (0 = aucun) en valeur SQL
+func nullableID(id int) interface{} {
+	if id == 0 {
+		return nil
+	}
+	return id
+}
+
 func (db *Database) CreateClient(client *models.Client) error {
-	query := `INSERT INTO clients (name, email, phone, address, city, postal_code, country, company, tax_id) 
-			  VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`
+	if client.Category == "" {
+		client.Category = models.ClientB2B
+	}
+
+	query := `INSERT INTO clients (name, email, phone, address, city, postal_code, country, company, tax_id, siren, siret, category, currency, language) 
+			  VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
 	
 	result, err := db.conn.Exec(query, client.Name, client.Email, client.Phone, client.Address, 
-		client.City, client.PostalCode, client.Country, client.Company, client.TaxID)
+		client.City, client.PostalCode, client.Country, client.Company, client.TaxID, client.SIREN, client.SIRET, client.Category,
+		client.Currency, client.Language)
 	if err != nil {
 		return err
 	}
@@ -124,28 +377,45 @@ func (db *Database) CreateClient(client *models.Client) error {
 }
 
 func (db *Database) GetClient(id int) (*models.Client, error) {
-	query := `SELECT id, name, email, phone, address, city, postal_code, country, company, tax_id, created_at, updated_at 
+	query := `SELECT id, name, email, phone, address, city, postal_code, country, company, tax_id, 
+			  COALESCE(siren, ''), COALESCE(siret, ''), COALESCE(category, 'b2b'), COALESCE(currency, ''), COALESCE(language, ''), archived_at, created_at, updated_at 
 			  FROM clients WHERE id = ?`
 	
 	client := &models.Client{}
+	var archivedAt sql.NullTime
 	err := db.conn.QueryRow(query, id).Scan(
 		&client.ID, &client.Name, &client.Email, &client.Phone, &client.Address,
 		&client.City, &client.PostalCode, &client.Country, &client.Company, &client.TaxID,
-		&client.CreatedAt, &client.UpdatedAt,
+		&client.SIREN, &client.SIRET, &client.Category, &client.Currency, &client.Language, &archivedAt, &client.CreatedAt, &client.UpdatedAt,
 	)
 	
 	if err == sql.ErrNoRows {
 		return nil, fmt.Errorf("client not found")
 	}
-	
-	return client, err
+	if err != nil {
+		return nil, err
+	}
+	if archivedAt.Valid {
+		client.ArchivedAt = &archivedAt.Time
+	}
+
+	if client.Contacts, err = db.ListClientContacts(id); err != nil {
+		return nil, err
+	}
+	if client.Addresses, err = db.ListClientAddresses(id, ""); err != nil {
+		return nil, err
+	}
+
+	return client, nil
 }
 
-func (db *Database) ListClients() ([]models.Client, error) {
-	query := `SELECT id, name, email, phone, address, city, postal_code, country, company, tax_id, created_at, updated_at 
-			  FROM clients ORDER BY name`
+// ListClients retourne les clients actifs, ou tous les clients (archivés compris) si includeArchived est vrai
+func (db *Database) ListClients(includeArchived bool) ([]models.Client, error) {
+	query := `SELECT id, name, email, phone, address, city, postal_code, country, company, tax_id, 
+			  COALESCE(siren, ''), COALESCE(siret, ''), COALESCE(category, 'b2b'), COALESCE(currency, ''), COALESCE(language, ''), archived_at, created_at, updated_at 
+			  FROM clients WHERE ? OR archived_at IS NULL ORDER BY name`
 	
-	rows, err := db.conn.Query(query)
+	rows, err := db.conn.Query(query, includeArchived)
 	if err != nil {
 		return nil, err
 	}
@@ -154,14 +424,18 @@ func (db *Database) ListClients() ([]models.Client, error) {
 	var clients []models.Client
 	for rows.Next() {
 		var client models.Client
+		var archivedAt sql.NullTime
 		err := rows.Scan(
 			&client.ID, &client.Name, &client.Email, &client.Phone, &client.Address,
 			&client.City, &client.PostalCode, &client.Country, &client.Company, &client.TaxID,
-			&client.CreatedAt, &client.UpdatedAt,
+			&client.SIREN, &client.SIRET, &client.Category, &client.Currency, &client.Language, &archivedAt, &client.CreatedAt, &client.UpdatedAt,
 		)
 		if err != nil {
 			return nil, err
 		}
+		if archivedAt.Valid {
+			client.ArchivedAt = &archivedAt.Time
+		}
 		clients = append(clients, client)
 	}
 
@@ -170,16 +444,58 @@ func (db *Database) ListClients() ([]models.Client, error) {
 
 func (db *Database) UpdateClient(client *models.Client) error {
 	query := `UPDATE clients SET name=?, email=?, phone=?, address=?, city=?, postal_code=?, 
-			  country=?, company=?, tax_id=?, updated_at=CURRENT_TIMESTAMP WHERE id=?`
+			  country=?, company=?, tax_id=?, siren=?, siret=?, category=?, currency=?, language=?, updated_at=CURRENT_TIMESTAMP WHERE id=?`
 	
 	_, err := db.conn.Exec(query, client.Name, client.Email, client.Phone, client.Address,
-		client.City, client.PostalCode, client.Country, client.Company, client.TaxID, client.ID)
+		client.City, client.PostalCode, client.Country, client.Company, client.TaxID,
+		client.SIREN, client.SIRET, client.Category, client.Currency, client.Language, client.ID)
 	
 	return err
 }
 
-func (db *Database) DeleteClient(id int) error {
-	_, err := db.conn.Exec("DELETE FROM clients WHERE id = ?", id)
+func (db *Database) CountClientQuotes(id int) (int, error) {
+	var count int
+	err := db.conn.QueryRow("SELECT COUNT(*) FROM quotes WHERE client_id = ?", id).Scan(&count)
+	return count, err
+}
+
+// DeleteClient supprime un client. S'il possède des devis, la suppression est
+// refusée sauf si cascade est vrai, auquel cas ses devis sont supprimés avec lui.
+func (db *Database) DeleteClient(id int, cascade bool) error {
+	tx, err := db.conn.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var count int
+	if err := tx.QueryRow("SELECT COUNT(*) FROM quotes WHERE client_id = ?", id).Scan(&count); err != nil {
+		return err
+	}
+
+	if count > 0 {
+		if !cascade {
+			return fmt.Errorf(i18n.T("le client possède %d devis"), count)
+		}
+		if _, err := tx.Exec("DELETE FROM quotes WHERE client_id = ?", id); err != nil {
+			return err
+		}
+	}
+
+	if _, err := tx.Exec("DELETE FROM clients WHERE id = ?", id); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (db *Database) ArchiveClient(id int) error {
+	_, err := db.conn.Exec("UPDATE clients SET archived_at=CURRENT_TIMESTAMP, updated_at=CURRENT_TIMESTAMP WHERE id=?", id)
+	return err
+}
+
+func (db *Database) UnarchiveClient(id int) error {
+	_, err := db.conn.Exec("UPDATE clients SET archived_at=NULL, updated_at=CURRENT_TIMESTAMP WHERE id=?", id)
 	return err
 }
 
@@ -190,11 +506,23 @@ func (db *Database) CreateQuote(quote *models.Quote) error {
 	}
 	defer tx.Rollback()
 
-	quoteQuery := `INSERT INTO quotes (quote_number, client_id, date, valid_until, status, notes, terms, total_amount, tax_amount, discount) 
-				   VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
+	// Le numéro est attribué dans la transaction pour ne pas créer de trou dans
+	// la numérotation de l'entreprise si la création échoue
+	if quote.QuoteNumber == "" {
+		quote.QuoteNumber, err = db.allocateNumber(tx, quote.CompanyID, models.DocumentQuote)
+		if err != nil {
+			return err
+		}
+	}
+
+	quoteQuery := `INSERT INTO quotes (quote_number, client_id, date, valid_until, status, notes, terms, total_amount, tax_amount, discount,
+				   contact_id, billing_address_id, delivery_address_id, company_id, currency, language) 
+				   VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
 	
 	result, err := tx.Exec(quoteQuery, quote.QuoteNumber, quote.ClientID, quote.Date, quote.ValidUntil,
-		quote.Status, quote.Notes, quote.Terms, quote.TotalAmount, quote.TaxAmount, quote.Discount)
+		quote.Status, quote.Notes, quote.Terms, quote.TotalAmount, quote.TaxAmount, quote.Discount,
+		nullableID(quote.ContactID), nullableID(quote.BillingAddressID), nullableID(quote.DeliveryAddressID),
+		nullableID(quote.CompanyID), quote.Currency, quote.Language)
 	if err != nil {
 		return err
 	}
@@ -221,19 +549,25 @@ func (db *Database) CreateQuote(quote *models.Quote) error {
 func (db *Database) GetQuote(id int) (*models.Quote, error) {
 	query := `SELECT q.id, q.quote_number, q.client_id, q.date, q.valid_until, q.status, q.notes, q.terms, 
 			  q.total_amount, q.tax_amount, q.discount, q.created_at, q.updated_at,
-			  c.id, c.name, c.email, c.phone, c.address, c.city, c.postal_code, c.country, c.company, c.tax_id
+			  q.contact_id, q.billing_address_id, q.delivery_address_id, q.company_id, COALESCE(q.currency, 'EUR'), COALESCE(q.language, ''),
+			  COALESCE(c.id, 0), COALESCE(c.name, ''), COALESCE(c.email, ''), COALESCE(c.phone, ''), COALESCE(c.address, ''),
+			  COALESCE(c.city, ''), COALESCE(c.postal_code, ''), COALESCE(c.country, ''), COALESCE(c.company, ''), COALESCE(c.tax_id, ''),
+			  COALESCE(c.siren, ''), COALESCE(c.siret, ''), COALESCE(c.category, 'b2b'), COALESCE(c.currency, ''), COALESCE(c.language, '')
 			  FROM quotes q
-			  JOIN clients c ON q.client_id = c.id
+			  LEFT JOIN clients c ON q.client_id = c.id
 			  WHERE q.id = ?`
 	
 	quote := &models.Quote{Client: &models.Client{}}
+	var contactID, billingAddressID, deliveryAddressID, companyID sql.NullInt64
 	err := db.conn.QueryRow(query, id).Scan(
 		&quote.ID, &quote.QuoteNumber, &quote.ClientID, &quote.Date, &quote.ValidUntil,
 		&quote.Status, &quote.Notes, &quote.Terms, &quote.TotalAmount, &quote.TaxAmount, &quote.Discount,
 		&quote.CreatedAt, &quote.UpdatedAt,
+		&contactID, &billingAddressID, &deliveryAddressID, &companyID, &quote.Currency, &quote.Language,
 		&quote.Client.ID, &quote.Client.Name, &quote.Client.Email, &quote.Client.Phone,
 		&quote.Client.Address, &quote.Client.City, &quote.Client.PostalCode, &quote.Client.Country,
-		&quote.Client.Company, &quote.Client.TaxID,
+		&quote.Client.Company, &quote.Client.TaxID, &quote.Client.SIREN, &quote.Client.SIRET,
+		&quote.Client.Category, &quote.Client.Currency, &quote.Client.Language,
 	)
 	
 	if err == sql.ErrNoRows {
@@ -243,6 +577,34 @@ func (db *Database) GetQuote(id int) (*models.Quote, error) {
 		return nil, err
 	}
 
+	// Client supprimé avant l'activation des clés étrangères : le devis reste
+	// consultable avec un client de substitution
+	if quote.Client.ID == 0 {
+		quote.Client.ID = quote.ClientID
+		quote.Client.Name = fmt.Sprintf(i18n.T("Client supprimé #%d"), quote.ClientID)
+	}
+
+	quote.ContactID = int(contactID.Int64)
+	quote.BillingAddressID = int(billingAddressID.Int64)
+	quote.DeliveryAddressID = int(deliveryAddressID.Int64)
+	quote.CompanyID = int(companyID.Int64)
+
+	if quote.ContactID != 0 {
+		if quote.Contact, err = db.GetClientContact(quote.ContactID); err != nil {
+			return nil, err
+		}
+	}
+	if quote.BillingAddressID != 0 {
+		if quote.BillingAddress, err = db.GetClientAddress(quote.BillingAddressID); err != nil {
+			return nil, err
+		}
+	}
+	if quote.DeliveryAddressID != 0 {
+		if quote.DeliveryAddress, err = db.GetClientAddress(quote.DeliveryAddressID); err != nil {
+			return nil, err
+		}
+	}
+
 	itemsQuery := `SELECT id, quote_id, description, quantity, unit_price, tax_rate, amount, created_at 
 				   FROM quote_items WHERE quote_id = ?`
 	
@@ -267,9 +629,9 @@ func (db *Database) GetQuote(id int) (*models.Quote, error) {
 
 func (db *Database) ListQuotes() ([]models.Quote, error) {
 	query := `SELECT q.id, q.quote_number, q.client_id, q.date, q.valid_until, q.status, 
-			  q.total_amount, q.created_at, c.name
+			  q.total_amount, q.created_at, COALESCE(c.name, ''), COALESCE(q.company_id, 0), COALESCE(q.currency, 'EUR')
 			  FROM quotes q
-			  JOIN clients c ON q.client_id = c.id
+			  LEFT JOIN clients c ON q.client_id = c.id
 			  ORDER BY q.created_at DESC`
 	
 	rows, err := db.conn.Query(query)
@@ -284,6 +646,7 @@ func (db *Database) ListQuotes() ([]models.Quote, error) {
 		err := rows.Scan(
 			&quote.ID, &quote.QuoteNumber, &quote.ClientID, &quote.Date, &quote.ValidUntil,
 			&quote.Status, &quote.TotalAmount, &quote.CreatedAt, &quote.Client.Name,
+			&quote.CompanyID, &quote.Currency,
 		)
 		if err != nil {
 			return nil, err
@@ -307,11 +670,14 @@ func (db *Database) UpdateQuote(quote *models.Quote) error {
 	defer tx.Rollback()
 
 	quoteQuery := `UPDATE quotes SET client_id=?, valid_until=?, notes=?, terms=?, 
-				   total_amount=?, tax_amount=?, discount=?, updated_at=CURRENT_TIMESTAMP 
+				   total_amount=?, tax_amount=?, discount=?, contact_id=?, billing_address_id=?, delivery_address_id=?,
+				   currency=?, language=?, updated_at=CURRENT_TIMESTAMP 
 				   WHERE id=?`
 	
 	_, err = tx.Exec(quoteQuery, quote.ClientID, quote.ValidUntil, quote.Notes, quote.Terms,
-		quote.TotalAmount, quote.TaxAmount, quote.Discount, quote.ID)
+		quote.TotalAmount, quote.TaxAmount, quote.Discount,
+		nullableID(quote.ContactID), nullableID(quote.BillingAddressID), nullableID(quote.DeliveryAddressID),
+		quote.Currency, quote.Language, quote.ID)
 	if err != nil {
 		return err
 	}
@@ -343,19 +709,15 @@ func (db *Database) DuplicateQuote(id int) (*models.Quote, error) {
 	// Charger le devis source avec tous ses items
 	sourceQuote, err := db.GetQuote(id)
 	if err != nil {
-		return nil, fmt.Errorf("impossible de charger le devis source: %w", err)
-	}
-
-	// Générer un nouveau numéro de devis
-	newQuoteNumber, err := db.GetNextQuoteNumber()
-	if err != nil {
-		return nil, fmt.Errorf("impossible de générer un nouveau numéro de devis: %w", err)
+		return nil, fmt.Errorf(i18n.T("impossible de charger le devis source: %w"), err)
 	}
 
 	// Créer le nouveau devis avec les données copiées
 	newQuote := &models.Quote{
-		QuoteNumber: newQuoteNumber,
 		ClientID:    sourceQuote.ClientID,
+		CompanyID:   sourceQuote.CompanyID,
+		Currency:    sourceQuote.Currency,
+		Language:    sourceQuote.Language,
 		Date:        time.Now(),
 		ValidUntil:  time.Now().AddDate(0, 1, 0), // Validité d'un mois par défaut
 		Status:      "draft",
@@ -364,13 +726,16 @@ func (db *Database) DuplicateQuote(id int) (*models.Quote, error) {
 		TotalAmount: sourceQuote.TotalAmount,
 		TaxAmount:   sourceQuote.TaxAmount,
 		Discount:    sourceQuote.Discount,
+		ContactID:         sourceQuote.ContactID,
+		BillingAddressID:  sourceQuote.BillingAddressID,
+		DeliveryAddressID: sourceQuote.DeliveryAddressID,
 		Items:       []models.QuoteItem{}, // On va copier les items après création
 	}
 
 	// Créer le nouveau devis dans la base
 	err = db.CreateQuote(newQuote)
 	if err != nil {
-		return nil, fmt.Errorf("impossible de créer le devis dupliqué: %w", err)
+		return nil, fmt.Errorf(i18n.T("impossible de créer le devis dupliqué: %w"), err)
 	}
 
 	// Copier tous les items du devis source
@@ -391,7 +756,7 @@ func (db *Database) DuplicateQuote(id int) (*models.Quote, error) {
 	if err != nil {
 		// En cas d'erreur, supprimer le devis créé
 		db.DeleteQuote(newQuote.ID)
-		return nil, fmt.Errorf("impossible de copier les items: %w", err)
+		return nil, fmt.Errorf(i18n.T("impossible de copier les items: %w"), err)
 	}
 
 	// Recharger le devis complet avec le client
@@ -409,7 +774,7 @@ func (db *Database) GetNextQuoteNumber() (string, error) {
 		randomBytes := make([]byte, length)
 		_, err := rand.Read(randomBytes)
 		if err != nil {
-			return "", fmt.Errorf("erreur lors de la génération aléatoire: %w", err)
+			return "", fmt.Errorf(i18n.T("erreur lors de la génération aléatoire: %w"), err)
 		}
 		
 		for i := range b {
@@ -429,55 +794,11 @@ func (db *Database) GetNextQuoteNumber() (string, error) {
 		}
 	}
 	
-	return "", fmt.Errorf("impossible de générer un numéro unique après 100 tentatives")
+	return "", errors.New(i18n.T("impossible de générer un numéro unique après 100 tentatives"))
 }
 
-func (db *Database) GetCompany() (*models.Company, error) {
-	query := `SELECT id, name, email, phone, address, city, postal_code, country, tax_id, logo, website, currency, tax_rate 
-			  FROM companies LIMIT 1`
-	
-	company := &models.Company{}
-	err := db.conn.QueryRow(query).Scan(
-		&company.ID, &company.Name, &company.Email, &company.Phone, &company.Address,
-		&company.City, &company.PostalCode, &company.Country, &company.TaxID,
-		&company.Logo, &company.Website, &company.Currency, &company.TaxRate,
-	)
-	
-	if err == sql.ErrNoRows {
-		return nil, nil
-	}
-	
-	return company, err
-}
-
-func (db *Database) SaveCompany(company *models.Company) error {
-	existingCompany, err := db.GetCompany()
-	if err != nil {
-		return err
-	}
-
-	if existingCompany == nil {
-		query := `INSERT INTO companies (name, email, phone, address, city, postal_code, country, tax_id, logo, website, currency, tax_rate) 
-				  VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
-		
-		result, err := db.conn.Exec(query, company.Name, company.Email, company.Phone, company.Address,
-			company.City, company.PostalCode, company.Country, company.TaxID,
-			company.Logo, company.Website, company.Currency, company.TaxRate)
-		if err != nil {
-			return err
-		}
-
-		id, _ := result.LastInsertId()
-		company.ID = int(id)
-		return nil
-	}
-
-	query := `UPDATE companies SET name=?, email=?, phone=?, address=?, city=?, postal_code=?, 
-			  country=?, tax_id=?, logo=?, website=?, currency=?, tax_rate=? WHERE id=?`
-	
-	_, err = db.conn.Exec(query, company.Name, company.Email, company.Phone, company.Address,
-		company.City, company.PostalCode, company.Country, company.TaxID,
-		company.Logo, company.Website, company.Currency, company.TaxRate, existingCompany.ID)
-	
+// SetCompanyLogo remplace (ou supprime avec nil) le logo d'une entreprise
+func (db *Database) SetCompanyLogo(companyID int, logo []byte) error {
+	_, err := db.conn.Exec(`UPDATE companies SET logo = ? WHERE id = ?`, logo, companyID)
 	return err
-}
\ No newline at end of file
+}
@@ -0,0 +1,138 @@
+package db
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"outbil/i18n"
+	"outbil/models"
+)
+
+// MergeClients rattache au client cible les devis, contacts et adresses du
+// client source, complète les champs vides de la cible avec ceux de la source,
+// puis supprime la source. Une trace de la fusion est conservée.
+func (db *Database) MergeClients(targetID, sourceID int) (*models.ClientMerge, error) {
+	if targetID == sourceID {
+		return nil, errors.New(i18n.T("impossible de fusionner un client avec lui-même"))
+	}
+
+	target, err := db.GetClient(targetID)
+	if err != nil {
+		return nil, fmt.Errorf(i18n.T("client cible: %w"), err)
+	}
+	source, err := db.GetClient(sourceID)
+	if err != nil {
+		return nil, fmt.Errorf(i18n.T("client source: %w"), err)
+	}
+
+	fillEmpty := func(dst *string, src string) {
+		if *dst == "" {
+			*dst = src
+		}
+	}
+	fillEmpty(&target.Email, source.Email)
+	fillEmpty(&target.Phone, source.Phone)
+	fillEmpty(&target.Address, source.Address)
+	fillEmpty(&target.City, source.City)
+	fillEmpty(&target.PostalCode, source.PostalCode)
+	fillEmpty(&target.Country, source.Country)
+	fillEmpty(&target.Company, source.Company)
+	fillEmpty(&target.TaxID, source.TaxID)
+	fillEmpty(&target.SIREN, source.SIREN)
+	fillEmpty(&target.SIRET, source.SIRET)
+	fillEmpty(&target.Currency, source.Currency)
+	fillEmpty(&target.Language, source.Language)
+
+	snapshot, err := json.Marshal(source)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := db.conn.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	_, err = tx.Exec(`UPDATE clients SET email=?, phone=?, address=?, city=?, postal_code=?, country=?, 
+			  company=?, tax_id=?, siren=?, siret=?, currency=?, language=?, updated_at=CURRENT_TIMESTAMP WHERE id=?`,
+		target.Email, target.Phone, target.Address, target.City, target.PostalCode, target.Country,
+		target.Company, target.TaxID, target.SIREN, target.SIRET, target.Currency, target.Language, target.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := tx.Exec("UPDATE quotes SET client_id=?, updated_at=CURRENT_TIMESTAMP WHERE client_id=?", target.ID, source.ID)
+	if err != nil {
+		return nil, err
+	}
+	moved, err := result.RowsAffected()
+	if err != nil {
+		return nil, err
+	}
+
+	queries := []string{
+		"UPDATE client_contacts SET client_id=? WHERE client_id=?",
+		"UPDATE client_addresses SET client_id=? WHERE client_id=?",
+		"UPDATE client_merges SET target_client_id=? WHERE target_client_id=?",
+	}
+	for _, query := range queries {
+		if _, err := tx.Exec(query, target.ID, source.ID); err != nil {
+			return nil, err
+		}
+	}
+
+	merge := &models.ClientMerge{
+		TargetClientID: target.ID,
+		SourceClientID: source.ID,
+		SourceName:     source.Name,
+		SourceData:     string(snapshot),
+		QuotesMoved:    int(moved),
+	}
+
+	result, err = tx.Exec(`INSERT INTO client_merges (target_client_id, source_client_id, source_name, source_data, quotes_moved) 
+			  VALUES (?, ?, ?, ?, ?)`,
+		merge.TargetClientID, merge.SourceClientID, merge.SourceName, merge.SourceData, merge.QuotesMoved)
+	if err != nil {
+		return nil, err
+	}
+	id, err := result.LastInsertId()
+	if err != nil {
+		return nil, err
+	}
+	merge.ID = int(id)
+
+	if _, err := tx.Exec("DELETE FROM clients WHERE id = ?", source.ID); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return merge, nil
+}
+
+func (db *Database) ListClientMerges(targetID int) ([]models.ClientMerge, error) {
+	query := `SELECT id, target_client_id, source_client_id, source_name, source_data, quotes_moved, merged_at 
+			  FROM client_merges WHERE target_client_id = ? ORDER BY merged_at`
+
+	rows, err := db.conn.Query(query, targetID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var merges []models.ClientMerge
+	for rows.Next() {
+		var merge models.ClientMerge
+		err := rows.Scan(&merge.ID, &merge.TargetClientID, &merge.SourceClientID, &merge.SourceName,
+			&merge.SourceData, &merge.QuotesMoved, &merge.MergedAt)
+		if err != nil {
+			return nil, err
+		}
+		merges = append(merges, merge)
+	}
+
+	return merges, nil
+}
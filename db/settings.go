@@ -0,0 +1,31 @@
+package db
+
+import "database/sql"
+
+// Réglages propres à chaque base
+const (
+	SettingOutputDir      = "output_dir"      // dossier racine des fichiers générés
+	SettingOutputName     = "output_name"     // modèle de nom des fichiers générés
+	SettingOutputConflict = "output_conflict" // conduite face à un fichier existant
+)
+
+// GetSetting retourne la valeur d'un réglage de la base, vide s'il n'est pas défini
+func (db *Database) GetSetting(key string) (string, error) {
+	var value string
+	err := db.conn.QueryRow("SELECT value FROM settings WHERE key = ?", key).Scan(&value)
+	if err == sql.ErrNoRows {
+		return "", nil
+	}
+	return value, err
+}
+
+// SetSetting enregistre un réglage de la base ; une valeur vide le supprime
+func (db *Database) SetSetting(key, value string) error {
+	if value == "" {
+		_, err := db.conn.Exec("DELETE FROM settings WHERE key = ?", key)
+		return err
+	}
+	_, err := db.conn.Exec(`INSERT INTO settings (key, value) VALUES (?, ?)
+			  ON CONFLICT (key) DO UPDATE SET value = excluded.value`, key, value)
+	return err
+}
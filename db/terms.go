@@ -0,0 +1,219 @@
+package db
+
+import (
+	"database/sql"
+	"fmt"
+	"outbil/i18n"
+	"outbil/models"
+	"strings"
+	"time"
+)
+
+// Les dates d'effet sont stockées au format AAAA-MM-JJ pour pouvoir être comparées
+const termsDateLayout = "2006-01-02"
+
+func (db *Database) CreateTermsDocument(terms *models.TermsDocument) error {
+	query := `INSERT INTO terms_documents (company_id, title, version, document_type, client_category,
+			  effective_from, content, pages, sha256) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`
+
+	result, err := db.conn.Exec(query, terms.CompanyID, terms.Title, terms.Version, terms.DocumentType,
+		terms.ClientCategory, terms.EffectiveFrom.Format(termsDateLayout), terms.Content, terms.Pages, terms.SHA256)
+	if err != nil {
+		return err
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return err
+	}
+
+	terms.ID = int(id)
+	terms.CreatedAt = time.Now()
+
+	return nil
+}
+
+// GetTermsDocument retourne une version des CGV, contenu PDF compris
+func (db *Database) GetTermsDocument(id int) (*models.TermsDocument, error) {
+	query := `SELECT id, company_id, title, version, document_type, client_category, effective_from,
+			  pages, sha256, created_at, content FROM terms_documents WHERE id = ?`
+
+	terms, err := scanTermsDocument(db.conn.QueryRow(query, id), true)
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("terms document not found")
+	}
+
+	return terms, err
+}
+
+// ListTermsDocuments retourne les versions des CGV d'une entreprise, sans leur contenu
+func (db *Database) ListTermsDocuments(companyID int) ([]models.TermsDocument, error) {
+	query := `SELECT id, company_id, title, version, document_type, client_category, effective_from,
+			  pages, sha256, created_at FROM terms_documents WHERE company_id = ?
+			  ORDER BY document_type, client_category, effective_from DESC, id DESC`
+
+	rows, err := db.conn.Query(query, companyID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var documents []models.TermsDocument
+	for rows.Next() {
+		terms, err := scanTermsDocument(rows, false)
+		if err != nil {
+			return nil, err
+		}
+		documents = append(documents, *terms)
+	}
+
+	return documents, rows.Err()
+}
+
+// FindApplicableTerms choisit la version des CGV en vigueur à une date pour un type
+// de document et une catégorie de client. Une version ciblée (type ou catégorie
+// renseignés) l'emporte sur une version générale, puis la plus récente l'emporte.
+func (db *Database) FindApplicableTerms(companyID int, documentType, clientCategory string, at time.Time) (*models.TermsDocument, error) {
+	query := `SELECT id, company_id, title, version, document_type, client_category, effective_from,
+			  pages, sha256, created_at, content FROM terms_documents
+			  WHERE company_id = ? AND document_type IN (?, '') AND client_category IN (?, '')
+			  AND effective_from <= ?
+			  ORDER BY (document_type <> '') + (client_category <> '') DESC, effective_from DESC, id DESC
+			  LIMIT 1`
+
+	terms, err := scanTermsDocument(db.conn.QueryRow(query, companyID, documentType, clientCategory,
+		at.Format(termsDateLayout)), true)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+
+	return terms, err
+}
+
+func (db *Database) CountTermsUsage(id int) (int, error) {
+	var count int
+	err := db.conn.QueryRow("SELECT COUNT(*) FROM generated_documents WHERE terms_id = ?", id).Scan(&count)
+	return count, err
+}
+
+// DeleteTermsDocument supprime une version des CGV qui n'a jamais été jointe à un document
+func (db *Database) DeleteTermsDocument(id int) error {
+	count, err := db.CountTermsUsage(id)
+	if err != nil {
+		return err
+	}
+	if count > 0 {
+		return fmt.Errorf(i18n.T("cette version a été jointe à %d document(s) et doit être conservée"), count)
+	}
+
+	_, err = db.conn.Exec("DELETE FROM terms_documents WHERE id = ?", id)
+	return err
+}
+
+// RecordGeneratedDocument enregistre un PDF produit et la version des CGV jointe
+func (db *Database) RecordGeneratedDocument(document *models.GeneratedDocument) error {
+	query := `INSERT INTO generated_documents (document_type, document_id, terms_id, file_path) VALUES (?, ?, ?, ?)`
+
+	result, err := db.conn.Exec(query, document.DocumentType, document.DocumentID,
+		nullableID(document.TermsID), document.FilePath)
+	if err != nil {
+		return err
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return err
+	}
+
+	document.ID = int(id)
+	document.GeneratedAt = time.Now()
+
+	return nil
+}
+
+// ListGeneratedDocuments retourne l'historique des PDFs d'un document, du plus récent au plus ancien
+func (db *Database) ListGeneratedDocuments(documentType string, documentID int) ([]models.GeneratedDocument, error) {
+	query := `SELECT g.id, g.document_type, g.document_id, g.terms_id,
+			  COALESCE(t.title || ' v' || t.version, ''), g.file_path, g.generated_at
+			  FROM generated_documents g
+			  LEFT JOIN terms_documents t ON g.terms_id = t.id
+			  WHERE g.document_type = ? AND g.document_id = ?
+			  ORDER BY g.generated_at DESC, g.id DESC`
+
+	rows, err := db.conn.Query(query, documentType, documentID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var documents []models.GeneratedDocument
+	for rows.Next() {
+		var document models.GeneratedDocument
+		var termsID sql.NullInt64
+		err := rows.Scan(&document.ID, &document.DocumentType, &document.DocumentID, &termsID,
+			&document.Terms, &document.FilePath, &document.GeneratedAt)
+		if err != nil {
+			return nil, err
+		}
+		document.TermsID = int(termsID.Int64)
+		documents = append(documents, document)
+	}
+
+	return documents, rows.Err()
+}
+
+// FindGeneratedDocumentByPath retourne le dernier document généré à l'un des
+// chemins donnés, nil si aucun
+func (db *Database) FindGeneratedDocumentByPath(paths ...string) (*models.GeneratedDocument, error) {
+	if len(paths) == 0 {
+		return nil, nil
+	}
+	placeholders := strings.TrimSuffix(strings.Repeat("?, ", len(paths)), ", ")
+	query := `SELECT id, document_type, document_id, file_path, generated_at
+			  FROM generated_documents WHERE file_path IN (` + placeholders + `)
+			  ORDER BY generated_at DESC, id DESC LIMIT 1`
+
+	args := make([]interface{}, len(paths))
+	for i, path := range paths {
+		args[i] = path
+	}
+	document := &models.GeneratedDocument{}
+	err := db.conn.QueryRow(query, args...).Scan(&document.ID, &document.DocumentType,
+		&document.DocumentID, &document.FilePath, &document.GeneratedAt)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return document, nil
+}
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanTermsDocument(row rowScanner, withContent bool) (*models.TermsDocument, error) {
+	terms := &models.TermsDocument{}
+	var effectiveFrom string
+
+	dest := []interface{}{
+		&terms.ID, &terms.CompanyID, &terms.Title, &terms.Version, &terms.DocumentType,
+		&terms.ClientCategory, &effectiveFrom, &terms.Pages, &terms.SHA256, &terms.CreatedAt,
+	}
+	if withContent {
+		dest = append(dest, &terms.Content)
+	}
+
+	if err := row.Scan(dest...); err != nil {
+		return nil, err
+	}
+
+	var err error
+	terms.EffectiveFrom, err = time.ParseInLocation(termsDateLayout, effectiveFrom, time.Local)
+	if err != nil {
+		return nil, fmt.Errorf(i18n.T("date d'effet invalide %q: %w"), effectiveFrom, err)
+	}
+
+	return terms, nil
+}
@@ -0,0 +1,218 @@
+package i18n
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Langues disponibles pour les documents
+const (
+	French  = "fr"
+	English = "en"
+
+	DefaultLanguage = French
+)
+
+// DocumentLabels regroupe les libellés imprimés sur les documents. Les libellés
+// contenant %s sont des formats à compléter.
+type DocumentLabels struct {
+	Language string
+	Name     string
+
+	QuoteTitle     string
+	QuoteReference string
+	Date           string
+	ValidUntil     string
+
+	Phone     string
+	Email     string
+	SIRET     string
+	SIREN     string
+	VATNumber string
+
+	Client    string
+	Delivery  string
+	Attention string
+
+	Description  string
+	Quantity     string
+	UnitPrice    string
+	TaxRate      string
+	LineTotal    string
+	Subtotal     string
+	Discount     string
+	TaxAmount    string
+	Total        string
+	BankTransfer string
+	Holder       string
+	IBAN         string
+	BIC          string
+	Reference    string
+	Notes        string
+	Conditions   string
+
+	CarriedForward string
+	BroughtForward string
+	PageNumber     string // référence du document, page courante et nombre de pages
+
+	Draft     string // filigranes
+	Cancelled string
+
+	ShareCapital      string
+	Insurance         string
+	InsuranceCoverage string
+}
+
+var documentLabels = map[string]DocumentLabels{
+	French: {
+		Language: French,
+		Name:     "Français",
+
+		QuoteTitle:     "DEVIS",
+		QuoteReference: "Devis %s",
+		Date:           "Date: %s",
+		ValidUntil:     "Valable jusqu'au: %s",
+
+		Phone:     "Tél: %s",
+		Email:     "Email: %s",
+		SIRET:     "SIRET: %s",
+		SIREN:     "SIREN: %s",
+		VATNumber: "N° TVA: %s",
+
+		Client:    "CLIENT:",
+		Delivery:  "LIVRAISON:",
+		Attention: "À l'attention de %s",
+
+		Description:  "Description",
+		Quantity:     "Qté",
+		UnitPrice:    "PU HT",
+		TaxRate:      "TVA",
+		LineTotal:    "Total HT",
+		Subtotal:     "Sous-total HT:",
+		Discount:     "Remise:",
+		TaxAmount:    "TVA:",
+		Total:        "TOTAL TTC:",
+		BankTransfer: "Paiement par virement",
+		Holder:       "Titulaire: %s",
+		IBAN:         "IBAN: %s",
+		BIC:          "BIC: %s",
+		Reference:    "Référence à rappeler: %s",
+		Notes:        "Notes:",
+		Conditions:   "Conditions:",
+
+		CarriedForward: "À reporter:",
+		BroughtForward: "Report:",
+		PageNumber:     "%s - page %s/%s",
+
+		Draft:     "BROUILLON",
+		Cancelled: "ANNULÉ",
+
+		ShareCapital:      "%s au capital de %s",
+		Insurance:         "Assurance professionnelle: %s, police n° %s",
+		InsuranceCoverage: ", couverture: %s",
+	},
+	English: {
+		Language: English,
+		Name:     "English",
+
+		QuoteTitle:     "QUOTE",
+		QuoteReference: "Quote %s",
+		Date:           "Date: %s",
+		ValidUntil:     "Valid until: %s",
+
+		Phone:     "Phone: %s",
+		Email:     "Email: %s",
+		SIRET:     "SIRET: %s",
+		SIREN:     "SIREN: %s",
+		VATNumber: "VAT no.: %s",
+
+		Client:    "BILL TO:",
+		Delivery:  "SHIP TO:",
+		Attention: "For the attention of %s",
+
+		Description:  "Description",
+		Quantity:     "Qty",
+		UnitPrice:    "Unit price",
+		TaxRate:      "VAT",
+		LineTotal:    "Amount excl. VAT",
+		Subtotal:     "Subtotal excl. VAT:",
+		Discount:     "Discount:",
+		TaxAmount:    "VAT:",
+		Total:        "TOTAL INCL. VAT:",
+		BankTransfer: "Payment by bank transfer",
+		Holder:       "Account holder: %s",
+		IBAN:         "IBAN: %s",
+		BIC:          "BIC: %s",
+		Reference:    "Payment reference: %s",
+		Notes:        "Notes:",
+		Conditions:   "Terms:",
+
+		CarriedForward: "Carried forward:",
+		BroughtForward: "Brought forward:",
+		PageNumber:     "%s - page %s of %s",
+
+		Draft:     "DRAFT",
+		Cancelled: "CANCELLED",
+
+		ShareCapital:      "%s with share capital of %s",
+		Insurance:         "Professional indemnity insurance: %s, policy no. %s",
+		InsuranceCoverage: ", coverage: %s",
+	},
+}
+
+// languageAliases reconnaît les noms de langues et les étiquettes régionales
+var languageAliases = map[string]string{
+	"français": French,
+	"francais": French,
+	"french":   French,
+	"anglais":  English,
+	"english":  English,
+}
+
+// Document retourne les libellés des documents dans une langue (français à défaut)
+func Document(language string) DocumentLabels {
+	if labels, ok := documentLabels[NormalizeLanguage(language)]; ok {
+		return labels
+	}
+	return documentLabels[DefaultLanguage]
+}
+
+// NormalizeLanguage ramène une langue à son code ISO 639-1 (en-GB, English -> en).
+// Une valeur vide reste vide.
+func NormalizeLanguage(language string) string {
+	language = strings.ToLower(strings.TrimSpace(language))
+	if alias, ok := languageAliases[language]; ok {
+		return alias
+	}
+	if i := strings.IndexAny(language, "-_"); i > 0 {
+		language = language[:i]
+	}
+	return language
+}
+
+// ValidateLanguage vérifie qu'une langue de document est disponible
+func ValidateLanguage(language string) error {
+	if _, ok := documentLabels[NormalizeLanguage(language)]; !ok {
+		return fmt.Errorf("langue %q non disponible (%s)", strings.TrimSpace(language), strings.Join(Languages(), ", "))
+	}
+	return nil
+}
+
+// Languages retourne les langues disponibles pour les documents
+func Languages() []string {
+	languages := make([]string, 0, len(documentLabels))
+	for language := range documentLabels {
+		languages = append(languages, language)
+	}
+	sort.Strings(languages)
+	return languages
+}
+
+// LanguageName retourne le nom d'une langue dans cette langue
+func LanguageName(language string) string {
+	if labels, ok := documentLabels[NormalizeLanguage(language)]; ok {
+		return labels.Name
+	}
+	return language
+}
@@ -0,0 +1,93 @@
+package i18n
+
+import (
+	"os"
+	"strings"
+)
+
+// Les messages de la ligne de commande sont rédigés en français dans le code :
+// le texte français sert de clé aux traductions. Un message absent d'un
+// catalogue reste affiché en français.
+var messageCatalogs = map[string]map[string]string{
+	English: englishMessages,
+}
+
+var currentLanguage = DefaultLanguage
+
+// SetLanguage choisit la langue des messages de la ligne de commande
+func SetLanguage(language string) {
+	language = NormalizeLanguage(language)
+	if _, ok := messageCatalogs[language]; ok || language == French {
+		currentLanguage = language
+		return
+	}
+	currentLanguage = DefaultLanguage
+}
+
+// CurrentLanguage retourne la langue des messages choisie avec SetLanguage
+func CurrentLanguage() string {
+	return currentLanguage
+}
+
+// MessageLanguages retourne les langues disponibles pour les messages
+func MessageLanguages() []string {
+	languages := []string{French}
+	for language := range messageCatalogs {
+		languages = append(languages, language)
+	}
+	return languages
+}
+
+// IsMessageLanguage indique si les messages sont disponibles dans une langue
+func IsMessageLanguage(language string) bool {
+	language = NormalizeLanguage(language)
+	_, ok := messageCatalogs[language]
+	return ok || language == French
+}
+
+// DetectLanguage choisit la langue des messages : celle de la configuration si
+// elle est renseignée, sinon celle de l'environnement (LC_ALL, LC_MESSAGES,
+// LANG), sinon le français
+func DetectLanguage(configured string) string {
+	if configured = NormalizeLanguage(configured); configured != "" {
+		if IsMessageLanguage(configured) {
+			return configured
+		}
+		return DefaultLanguage
+	}
+	for _, name := range []string{"LC_ALL", "LC_MESSAGES", "LANG"} {
+		value := os.Getenv(name)
+		if value == "" {
+			continue
+		}
+		// fr_FR.UTF-8 -> fr ; C et POSIX ne désignent pas de langue
+		if i := strings.IndexAny(value, ".@"); i >= 0 {
+			value = value[:i]
+		}
+		language := NormalizeLanguage(value)
+		if language == "c" || language == "posix" {
+			return DefaultLanguage
+		}
+		if IsMessageLanguage(language) {
+			return language
+		}
+		return DefaultLanguage
+	}
+	return DefaultLanguage
+}
+
+// T traduit un message dans la langue courante. Les espaces et retours à la
+// ligne qui l'entourent sont conservés et ne font pas partie de la clé.
+func T(message string) string {
+	catalog, ok := messageCatalogs[currentLanguage]
+	if !ok {
+		return message
+	}
+	key := strings.TrimSpace(message)
+	translated, ok := catalog[key]
+	if !ok || key == "" {
+		return message
+	}
+	start := strings.Index(message, key)
+	return message[:start] + translated + message[start+len(key):]
+}
@@ -0,0 +1,850 @@
+package i18n
+
+// englishMessages traduit en anglais les messages de la ligne de commande,
+// indexés par leur texte français
+var englishMessages = map[string]string{
+	// Conditions générales de vente
+	"Entreprise concernée (ID ou nom), par défaut l'entreprise par défaut": "Company concerned (ID or name), defaults to the default company",
+	"Titre du document":                       "Document title",
+	"Numéro de version (ex: 2024.1)":          "Version number (e.g. 2024.1)",
+	"Type de document: quote, invoice ou all": "Document type: quote, invoice or all",
+	"Catégorie de client: b2b, b2c ou all":    "Client category: b2b, b2c or all",
+	"Date d'effet, au format de l'entreprise ou AAAA-MM-JJ (par défaut aujourd'hui)": "Effective date, in the company's format or YYYY-MM-DD (defaults to today)",
+	"Gérer les conditions générales de vente":                                        "Manage terms and conditions of sale",
+	"Les CGV sont enregistrées dans la base, versionnées et datées. À la génération d'un PDF,\nla version en vigueur à la date du document est choisie selon le type de document (devis,\nfacture) et la catégorie du client (B2B, B2C), puis jointe au PDF et enregistrée dans\nl'historique du document.": "Terms and conditions are stored in the database, versioned and dated. When a PDF is generated,\nthe version in force on the document date is selected according to the document type (quote,\ninvoice) and the client category (B2B, B2C), then attached to the PDF and recorded in\nthe document history.",
+	"Lister les versions des CGV":                                                         "List terms and conditions versions",
+	"Erreur d'ouverture de la base: %v":                                                   "Error opening the database: %v",
+	"Erreur lors de la récupération des CGV: %v":                                          "Error retrieving terms and conditions: %v",
+	"Aucune CGV enregistrée. Utilisez 'outbil cgv add <fichier.pdf> --version <version>'": "No terms and conditions recorded. Use 'outbil cgv add <file.pdf> --version <version>'",
+	"Titre":         "Title",
+	"En vigueur le": "Effective from",
+	"Utilisations":  "Uses",
+	"[FICHIER.pdf]": "[FILE.pdf]",
+	"Enregistrer une nouvelle version des CGV": "Record a new version of the terms and conditions",
+	"Enregistrer un PDF comme nouvelle version des CGV. Les versions existantes ne sont\njamais modifiées : une version remplace la précédente à partir de sa date d'effet.\n\nExemples:\n  outbil cgv add cgv-2025.pdf --version 2025.1 --from 01/01/2025\n  outbil cgv add cgv-particuliers.pdf --version 3 --category b2c --type quote": "Record a PDF as a new version of the terms and conditions. Existing versions are\nnever modified: a version replaces the previous one from its effective date.\n\nExamples:\n  outbil cgv add cgv-2025.pdf --version 2025.1 --from 01/01/2025\n  outbil cgv add cgv-individuals.pdf --version 3 --category b2c --type quote",
+	"Impossible de lire le fichier: %v":                       "Unable to read the file: %v",
+	"Le fichier n'est pas un PDF valide: %v":                  "The file is not a valid PDF: %v",
+	"Numéro de version":                                       "Version number",
+	"la version est obligatoire":                              "the version is required",
+	"Erreur lors de l'enregistrement des CGV: %v":             "Error saving the terms and conditions: %v",
+	"CGV %s v%s enregistrées (ID: %d, %d page(s))":            "Terms %s v%s recorded (ID: %d, %d page(s))",
+	"Documents: %s - Clients: %s - En vigueur à partir du %s": "Documents: %s - Clients: %s - Effective from %s",
+	"[ID] [FICHIER.pdf]":                                      "[ID] [FILE.pdf]",
+	"Extraire une version des CGV":                            "Extract a version of the terms and conditions",
+	"Extraire le PDF exact d'une version des CGV, par exemple pour prouver la version remise à un client": "Extract the exact PDF of a terms and conditions version, for instance to prove which version a client received",
+	"ID invalide: %v":                                      "Invalid ID: %v",
+	"CGV non trouvées: %v":                                 "Terms and conditions not found: %v",
+	"Erreur lors de l'écriture du fichier: %v":             "Error writing the file: %v",
+	"CGV %s v%s exportées dans %s":                         "Terms %s v%s exported to %s",
+	"Empreinte SHA-256: %s":                                "SHA-256 fingerprint: %s",
+	"Supprimer une version des CGV jamais utilisée":        "Delete a terms and conditions version that was never used",
+	"CGV à supprimer: %s v%s":                              "Terms to delete: %s v%s",
+	"Confirmer la suppression":                             "Confirm deletion",
+	"Suppression impossible: %v":                           "Unable to delete: %v",
+	"CGV supprimées":                                       "Terms and conditions deleted",
+	"Suppression annulée":                                  "Deletion cancelled",
+	"type de document invalide %q (quote, invoice ou all)": "invalid document type %q (quote, invoice or all)",
+	"catégorie de client invalide %q (b2b, b2c ou all)":    "invalid client category %q (b2b, b2c or all)",
+	"Devis":                                "Quotes",
+	"Factures":                             "Invoices",
+	"Tous documents":                       "All documents",
+	"Tous":                                 "All",
+	"date invalide %q (exemple: %s ou %s)": "invalid date %q (example: %s or %s)",
+	"Le fichier cgv.pdf n'est plus joint automatiquement. Importez-le avec: outbil cgv add cgv.pdf --version 1": "The cgv.pdf file is no longer attached automatically. Import it with: outbil cgv add cgv.pdf --version 1",
+	"cette version a été jointe à %d document(s) et doit être conservée":                                        "this version was attached to %d document(s) and must be kept",
+
+	// Clients
+	"Inclure les clients archivés":                                    "Include archived clients",
+	"Supprimer aussi les devis du client":                             "Also delete the client's quotes",
+	"Gérer les clients":                                               "Manage clients",
+	"Commandes pour créer, lister, modifier et supprimer des clients": "Commands to create, list, edit and delete clients",
+	"Lister tous les clients":                                         "List all clients",
+	"Erreur lors de la récupération des clients: %v":                  "Error retrieving clients: %v",
+	"Aucun client trouvé":                                             "No client found",
+	"Nom":                                                             "Name",
+	"Entreprise":                                                      "Company",
+	"Téléphone":                                                       "Phone",
+	"Ville":                                                           "City",
+	"Statut":                                                          "Status",
+	"📦 Archivé":                                                       "📦 Archived",
+	"Actif":                                                           "Active",
+	"Ajouter un nouveau client":                                       "Add a new client",
+	"Nom du client":                                                   "Client name",
+	"le nom doit contenir au moins 2 caractères": "the name must be at least 2 characters long",
+	"Adresse":                         "Address",
+	"Code postal":                     "Postal code",
+	"Pays":                            "Country",
+	"Langue des documents":            "Document language",
+	"Langue de l'entreprise":          "Company language",
+	"Identifiants invalides: %v":      "Invalid identifiers: %v",
+	"Confirmer la création du client": "Confirm client creation",
+	"Erreur lors de la création du client: %v":                "Error creating the client: %v",
+	"Client créé avec succès (ID: %d)":                        "Client created successfully (ID: %d)",
+	"Création annulée":                                        "Creation cancelled",
+	"Modifier un client existant":                             "Edit an existing client",
+	"Client non trouvé: %v":                                   "Client not found: %v",
+	"Confirmer les modifications":                             "Confirm changes",
+	"Erreur lors de la mise à jour: %v":                       "Error while updating: %v",
+	"Client mis à jour avec succès":                           "Client updated successfully",
+	"Modifications annulées":                                  "Changes cancelled",
+	"Supprimer un client":                                     "Delete a client",
+	"Erreur lors de la vérification des devis: %v":            "Error checking quotes: %v",
+	"Impossible de supprimer %s : le client possède %d devis": "Cannot delete %s: the client has %d quote(s)",
+	"Archivez-le pour le masquer sans perdre l'historique: outbil client archive %d": "Archive it to hide it without losing its history: outbil client archive %d",
+	"Ou supprimez-le avec ses devis: outbil client delete %d --cascade":              "Or delete it along with its quotes: outbil client delete %d --cascade",
+	"Client à supprimer: %s (%s)":                                                    "Client to delete: %s (%s)",
+	"Ses %d devis seront également supprimés définitivement!":                        "Its %d quote(s) will also be permanently deleted!",
+	"Erreur lors de la suppression: %v":                                              "Error while deleting: %v",
+	"Client supprimé avec succès":                                                    "Client deleted successfully",
+	"Afficher les détails d'un client":                                               "Show a client's details",
+	"--- Détails du client ---":                                                      "--- Client details ---",
+	"ID:         %d":                                                                 "ID:          %d",
+	"Nom:        %s":                                                                 "Name:        %s",
+	"Entreprise: %s":                                                                 "Company:     %s",
+	"Email:      %s":                                                                 "Email:       %s",
+	"Téléphone:  %s":                                                                 "Phone:       %s",
+	"Adresse:    %s":                                                                 "Address:     %s",
+	"Ville:      %s":                                                                 "City:        %s",
+	"Code postal: %s":                                                                "Postal code: %s",
+	"Pays:       %s":                                                                 "Country:     %s",
+	"N° TVA:     %s":                                                                 "VAT no.:     %s",
+	"Catégorie:  %s":                                                                 "Category:    %s",
+	"Devise:     %s":                                                                 "Currency:    %s",
+	"Langue:     %s":                                                                 "Language:    %s",
+	"SIREN:      %s":                                                                 "SIREN:       %s",
+	"SIRET:      %s":                                                                 "SIRET:       %s",
+	"Créé le:    %s":                                                                 "Created:     %s",
+	"Modifié le: %s":                                                                 "Updated:     %s",
+	"Archivé le: %s":                                                                 "Archived:    %s",
+	"--- Adresses ---":                                                               "--- Addresses ---",
+	"Erreur lors de la récupération des fusions: %v":                                 "Error retrieving merges: %v",
+	"--- Clients fusionnés ---":                                                      "--- Merged clients ---",
+	"%s: #%d %s (%d devis rattachés)":                                                "%s: #%d %s (%d quote(s) moved)",
+	"Archiver un client":                                                             "Archive a client",
+	"Masquer un client des listes et sélections sans supprimer son historique de devis": "Hide a client from lists and selections without deleting its quote history",
+	"Le client %s est déjà archivé":                                                     "Client %s is already archived",
+	"Erreur lors de l'archivage: %v":                                                    "Error while archiving: %v",
+	"Client %s archivé":                                                                 "Client %s archived",
+	"Réactiver un client archivé":                                                       "Reactivate an archived client",
+	"Le client %s n'est pas archivé":                                                    "Client %s is not archived",
+	"Erreur lors de la réactivation: %v":                                                "Error while reactivating: %v",
+	"Client %s réactivé":                                                                "Client %s reactivated",
+	"SIREN (optionnel)":                                                                 "SIREN (optional)",
+	"SIRET (optionnel)":                                                                 "SIRET (optional)",
+	"Numéro TVA":                                                                        "VAT number",
+	"Catégorie de client":                                                               "Client category",
+	"Particulier (B2C)":                                                                 "Individual (B2C)",
+	"Professionnel (B2B)":                                                               "Business (B2B)",
+	"Devise (code ISO, vide = devise de l'entreprise)":                                  "Currency (ISO code, empty = company currency)",
+	"le client possède %d devis":                                                        "the client has %d quote(s)",
+
+	// Contacts et adresses des clients
+	"Gérer les contacts d'un client": "Manage a client's contacts",
+	"Commandes pour gérer les interlocuteurs (nom, fonction, email, téléphone) d'un client": "Commands to manage a client's contacts (name, role, email, phone)",
+	"Lister les contacts d'un client":                 "List a client's contacts",
+	"Erreur lors de la récupération des contacts: %v": "Error retrieving contacts: %v",
+	"Aucun contact trouvé":                            "No contact found",
+	"Fonction":                                        "Role",
+	"Ajouter un contact à un client":                  "Add a contact to a client",
+	"Nouveau contact pour %s":                         "New contact for %s",
+	"Confirmer la création du contact":                "Confirm contact creation",
+	"Erreur lors de la création du contact: %v":       "Error creating the contact: %v",
+	"Contact créé avec succès (ID: %d)":               "Contact created successfully (ID: %d)",
+	"Modifier un contact":                             "Edit a contact",
+	"Contact non trouvé: %v":                          "Contact not found: %v",
+	"Contact mis à jour avec succès":                  "Contact updated successfully",
+	"Supprimer un contact":                            "Delete a contact",
+	"Contact à supprimer: %s (%s)":                    "Contact to delete: %s (%s)",
+	"Contact supprimé avec succès":                    "Contact deleted successfully",
+	"Gérer les adresses d'un client":                  "Manage a client's addresses",
+	"Commandes pour gérer les adresses de facturation et de livraison d'un client": "Commands to manage a client's billing and delivery addresses",
+	"Lister les adresses d'un client":                                              "List a client's addresses",
+	"Erreur lors de la récupération des adresses: %v":                              "Error retrieving addresses: %v",
+	"Aucune adresse trouvée":                                                       "No address found",
+	"Libellé":                                                                      "Label",
+	"Ajouter une adresse à un client":                                              "Add an address to a client",
+	"Nouvelle adresse pour %s":                                                     "New address for %s",
+	"Confirmer la création de l'adresse":                                           "Confirm address creation",
+	"Erreur lors de la création de l'adresse: %v":                                  "Error creating the address: %v",
+	"Adresse créée avec succès (ID: %d)":                                           "Address created successfully (ID: %d)",
+	"Modifier une adresse":                                                         "Edit an address",
+	"Adresse non trouvée: %v":                                                      "Address not found: %v",
+	"Adresse mise à jour avec succès":                                              "Address updated successfully",
+	"Supprimer une adresse":                                                        "Delete an address",
+	"Adresse à supprimer: %s":                                                      "Address to delete: %s",
+	"Adresse supprimée avec succès":                                                "Address deleted successfully",
+	"Nom du contact":                                                               "Contact name",
+	"Type d'adresse":                                                               "Address type",
+	"Libellé (ex: Siège, Entrepôt)":                                                "Label (e.g. Head office, Warehouse)",
+	"Facturation":                                                                  "Billing",
+	"Livraison":                                                                    "Delivery",
+
+	// Import et export des clients
+	"Format du fichier: csv ou vcard (déduit de l'extension par défaut)":       "File format: csv or vcard (inferred from the extension by default)",
+	"Correspondance des colonnes CSV, ex: \"name=Nom complet,email=Courriel\"": "CSV column mapping, e.g. \"name=Full name,email=E-mail\"",
+	"Séparateur CSV (détecté automatiquement par défaut)":                      "CSV separator (detected automatically by default)",
+	"Afficher le rapport d'import sans rien enregistrer":                       "Show the import report without saving anything",
+	"Version vCard à produire (3 ou 4)":                                        "vCard version to produce (3 or 4)",
+	"[FICHIER]":                                                                "[FILE]",
+	"Importer des clients depuis un fichier CSV ou vCard":                      "Import clients from a CSV or vCard file",
+	"Importer des clients depuis un fichier CSV (export de CRM, tableur) ou vCard 3/4 (contacts).\n\nLes clients dont l'email ou le numéro de TVA existe déjà sont signalés comme doublons\net ne sont pas importés. Utilisez --dry-run pour consulter le rapport sans rien enregistrer.": "Import clients from a CSV file (CRM or spreadsheet export) or vCard 3/4 (contacts).\n\nClients whose email or VAT number already exists are reported as duplicates\nand are not imported. Use --dry-run to review the report without saving anything.",
+	"Impossible d'ouvrir le fichier: %v":          "Unable to open the file: %v",
+	"Erreur de lecture du fichier: %v":            "Error reading the file: %v",
+	"Aucun client trouvé dans le fichier":         "No client found in the file",
+	"Simulation: aucun client ne sera enregistré": "Dry run: no client will be saved",
+	"N° TVA":               "VAT no.",
+	"Résultat":             "Result",
+	"ignoré: nom manquant": "skipped: missing name",
+	"ignoré: %v":           "skipped: %v",
+	"doublon (email, %s)":  "duplicate (email, %s)",
+	"doublon (TVA, %s)":    "duplicate (VAT, %s)",
+	"erreur: %v":           "error: %v",
+	"créé (ID: %d)":        "created (ID: %d)",
+	"à créer":              "to create",
+	"ligne %d du fichier":  "line %d of the file",
+	"%d client(s) seraient importés, %d ignoré(s)":                        "%d client(s) would be imported, %d skipped",
+	"%d client(s) importé(s), %d ignoré(s)":                               "%d client(s) imported, %d skipped",
+	"Exporter les clients vers un fichier CSV ou vCard":                   "Export clients to a CSV or vCard file",
+	"Version vCard non supportée: %d (3 ou 4)":                            "Unsupported vCard version: %d (3 or 4)",
+	"Aucun client à exporter":                                             "No client to export",
+	"Impossible de créer le fichier: %v":                                  "Unable to create the file: %v",
+	"Erreur lors de l'export: %v":                                         "Error during export: %v",
+	"%d client(s) exporté(s) vers %s":                                     "%d client(s) exported to %s",
+	"format non reconnu pour %s, précisez --format csv ou --format vcard": "unrecognised format for %s, specify --format csv or --format vcard",
+	"format non supporté: %s (csv ou vcard)":                              "unsupported format: %s (csv or vcard)",
+	"correspondance invalide: %q (attendu champ=colonne)":                 "invalid mapping: %q (expected field=column)",
+	"champ inconnu: %s (champs possibles: %s)":                            "unknown field: %s (possible fields: %s)",
+	"colonne introuvable dans l'en-tête: %s":                              "column not found in the header: %s",
+	"aucune colonne de nom trouvée, utilisez --map name=<colonne>":        "no name column found, use --map name=<column>",
+
+	// Doublons et fusion de clients
+	"Ne pas demander de confirmation": "Do not ask for confirmation",
+	"Détecter les clients en doublon": "Detect duplicate clients",
+	"Rechercher les clients probablement en doublon en comparant le nom normalisé\n(sans casse, accents ni forme juridique), l'email, le numéro de TVA, le SIREN et le téléphone.": "Look for probable duplicate clients by comparing the normalised name\n(ignoring case, accents and legal form), email, VAT number, SIREN and phone.",
+	"Aucun doublon détecté":                     "No duplicates detected",
+	"%d groupe(s) de doublons probables":        "%d group(s) of probable duplicates",
+	"--- Groupe %d (%s) ---":                    "--- Group %d (%s) ---",
+	"Pour fusionner: outbil client merge %d %d": "To merge: outbil client merge %d %d",
+	"[ID_CIBLE] [ID_SOURCE]":                    "[TARGET_ID] [SOURCE_ID]",
+	"Fusionner deux clients":                    "Merge two clients",
+	"Fusionner le client source dans le client cible : tous les devis, contacts et adresses\ndu client source sont rattachés au client cible, les champs vides du client cible sont\ncomplétés, puis le client source est supprimé. La fusion est enregistrée dans l'historique.": "Merge the source client into the target client: all quotes, contacts and addresses\nof the source client are moved to the target client, empty fields of the target client are\nfilled in, then the source client is deleted. The merge is recorded in the history.",
+	"Client cible non trouvé: %v":                                             "Target client not found: %v",
+	"Client source non trouvé: %v":                                            "Source client not found: %v",
+	"Client conservé: #%d %s (%s)":                                            "Client kept: #%d %s (%s)",
+	"Client fusionné puis supprimé: #%d %s (%s)":                              "Client merged then deleted: #%d %s (%s)",
+	"%d devis, %d contact(s) et %d adresse(s) seront rattachés au client #%d": "%d quote(s), %d contact(s) and %d address(es) will be moved to client #%d",
+	"Confirmer la fusion":                                                     "Confirm merge",
+	"Fusion annulée":                                                          "Merge cancelled",
+	"Erreur lors de la fusion: %v":                                            "Error during merge: %v",
+	"Client %s fusionné dans %s (%d devis rattachés)":                         "Client %s merged into %s (%d quote(s) moved)",
+	"nom":       "name",
+	"TVA":       "VAT",
+	"téléphone": "phone",
+	"impossible de fusionner un client avec lui-même": "cannot merge a client with itself",
+	"client cible: %w":  "target client: %w",
+	"client source: %w": "source client: %w",
+
+	// Entreprises
+	"Préfixe des numéros (ex: AC-)":                    "Number prefix (e.g. AC-)",
+	"Prochain numéro attribué":                         "Next number to assign",
+	"Nombre minimal de chiffres":                       "Minimum number of digits",
+	"Revenir aux identifiants aléatoires de 8 lettres": "Go back to random 8-letter identifiers",
+	"Gérer les informations de votre entreprise":       "Manage your company information",
+	"Commandes pour configurer les informations de vos entreprises qui apparaîtront sur les devis.\n\nUne base peut contenir plusieurs entreprises émettrices, chacune avec sa numérotation, son logo,\nses CGV, ses comptes bancaires et son taux de TVA par défaut. L'option --company (ID ou nom)\nsélectionne l'entreprise concernée, sinon l'entreprise par défaut est utilisée.": "Commands to configure the information about your companies that appears on quotes.\n\nA database can hold several issuing companies, each with its own numbering, logo,\nterms and conditions, bank accounts and default VAT rate. The --company option (ID or name)\nselects the company concerned, otherwise the default company is used.",
+	"Configurer les informations de l'entreprise":                                 "Configure the company information",
+	"Erreur lors de la récupération: %v":                                          "Error while retrieving: %v",
+	"Enregistrer les modifications":                                               "Save changes",
+	"Erreur lors de l'enregistrement: %v":                                         "Error while saving: %v",
+	"Informations de l'entreprise enregistrées":                                   "Company information saved",
+	"Afficher les informations de l'entreprise":                                   "Show the company information",
+	"Aucune information d'entreprise configurée. Utilisez 'outbil company setup'": "No company information configured. Use 'outbil company setup'",
+	"--- Informations de l'entreprise ---":                                        "--- Company information ---",
+	"(par défaut)":                                                                "(default)",
+	"Nom:         %s":                                                             "Name:        %s",
+	"Téléphone:   %s":                                                             "Phone:       %s",
+	"Adresse:     %s":                                                             "Address:     %s",
+	"Ville:       %s":                                                             "City:        %s",
+	"Pays:        %s":                                                             "Country:     %s",
+	"N° TVA:      %s":                                                             "VAT no.:     %s",
+	"Site web:    %s":                                                             "Website:     %s",
+	"Devise:      %s":                                                             "Currency:    %s",
+	"TVA défaut:  %s":                                                             "Default VAT: %s",
+	"--- Mentions légales ---":                                                    "--- Legal information ---",
+	"Forme:       %s":                                                             "Legal form:  %s",
+	"Registre:    %s %s":                                                          "Registry:    %s %s",
+	"Code APE:    %s":                                                             "APE code:    %s",
+	"Assurance:   %s, police n° %s (%s)":                                          "Insurance:   %s, policy no. %s (%s)",
+	"Paiement:    %s":                                                             "Payment:     %s",
+	"Numérotation: %s (prochain devis: %s)":                                       "Numbering:   %s (next quote: %s)",
+	"Numérotation: identifiants aléatoires":                                       "Numbering:   random identifiers",
+	"--- Coordonnées bancaires ---":                                               "--- Bank details ---",
+	"Titulaire:   %s":                                                             "Holder:      %s",
+	"%d comptes enregistrés, voir: outbil company bank list":                      "%d accounts recorded, see: outbil company bank list",
+	"Complétez-les avec: outbil company setup":                                    "Complete them with: outbil company setup",
+	"Lister les entreprises émettrices":                                           "List issuing companies",
+	"Erreur lors de la récupération des entreprises: %v":                          "Error retrieving companies: %v",
+	"Aucune entreprise configurée. Utilisez 'outbil company setup'":               "No company configured. Use 'outbil company setup'",
+	"Devise":                                "Currency",
+	"TVA défaut":                            "Default VAT",
+	"Défaut":                                "Default",
+	"Ajouter une entreprise émettrice":      "Add an issuing company",
+	"Confirmer la création de l'entreprise": "Confirm company creation",
+	"Entreprise %s créée (ID: %d)":          "Company %s created (ID: %d)",
+	"Pour l'utiliser par défaut: outbil company default %d":                                               "To use it by default: outbil company default %d",
+	"Définir l'entreprise utilisée par défaut":                                                            "Set the default company",
+	"Entreprise par défaut mise à jour":                                                                   "Default company updated",
+	"Supprimer une entreprise sans devis":                                                                 "Delete a company without quotes",
+	"Supprimer une entreprise qui n'a émis aucun devis, avec ses comptes bancaires, CGV et numérotations": "Delete a company that has issued no quote, along with its bank accounts, terms and conditions and numbering",
+	"Entreprise non trouvée: %v":                                                                          "Company not found: %v",
+	"Entreprise à supprimer: %s":                                                                          "Company to delete: %s",
+	"Entreprise supprimée":                                                                                "Company deleted",
+	"Configurer la numérotation des devis de l'entreprise":                                                "Configure the company's quote numbering",
+	"Définir une numérotation séquentielle propre à l'entreprise (préfixe, prochain numéro,\nnombre de chiffres). Sans numérotation, les devis reçoivent un identifiant aléatoire de 8 lettres.\n\nExemples:\n  outbil company numbering --company 2 --prefix AC- --next 1 --padding 4   # AC-0001, AC-0002...\n  outbil company numbering --random": "Set up sequential numbering specific to the company (prefix, next number,\nnumber of digits). Without numbering, quotes get a random 8-letter identifier.\n\nExamples:\n  outbil company numbering --company 2 --prefix AC- --next 1 --padding 4   # AC-0001, AC-0002...\n  outbil company numbering --random",
+	"Les devis de %s recevront des identifiants aléatoires":                          "Quotes from %s will get random identifiers",
+	"Valeurs invalides: --next doit être positif et --padding compris entre 0 et 12": "Invalid values: --next must be positive and --padding between 0 and 12",
+	"Numérotation des devis de %s: prochain numéro %s":                               "Quote numbering for %s: next number %s",
+	"Nom de l'entreprise":             "Company name",
+	"identifiants invalides: %v":      "invalid identifiers: %v",
+	"Site web":                        "Website",
+	"Devise de référence":             "Reference currency",
+	"Taux de TVA par défaut (%)":      "Default VAT rate (%)",
+	"Format des nombres et des dates": "Number and date format",
+	"Afficher ou choisir le format des nombres et des dates de l'entreprise": "Show or choose the company's number and date format",
+	"Afficher ou choisir la locale de l'entreprise : le format des montants et des\ndates de ses devis, dans les commandes comme dans les PDFs, et la langue par défaut\nde ses documents.\n\nExemples:\n  outbil company locale\n  outbil company locale en-US": "Show or choose the company's locale: the format of amounts and dates of its\nquotes, in commands as in PDFs, and the default language of its documents.\n\nExamples:\n  outbil company locale\n  outbil company locale en-US",
+	"Format de %s: %s":                   "Format for %s: %s",
+	"aucune entreprise nommée %q":        "no company named %q",
+	"l'entreprise a émis %d devis":       "the company has issued %d quote(s)",
+	"locale %q non prise en charge (%s)": "unsupported locale %q (%s)",
+
+	// Comptes bancaires
+	"Gérer les comptes bancaires de l'entreprise":                                                               "Manage the company's bank accounts",
+	"Commandes pour gérer les comptes bancaires imprimés sur les devis avec un QR code\nde virement SEPA (EPC)": "Commands to manage the bank accounts printed on quotes with a SEPA credit transfer\nQR code (EPC)",
+	"Lister les comptes bancaires":                                                                              "List bank accounts",
+	"Aucun compte bancaire enregistré":                                                                          "No bank account recorded",
+	"Titulaire":                                                                                                 "Holder",
+	"Ajouter un compte bancaire":                                                                                "Add a bank account",
+	"Libellé (ex: Compte courant)":                                                                              "Label (e.g. Current account)",
+	"Titulaire du compte":                                                                                       "Account holder",
+	"le titulaire est obligatoire":                                                                              "the holder is required",
+	"70 caractères maximum":                                                                                     "70 characters maximum",
+	"Utiliser ce compte par défaut":                                                                             "Use this account by default",
+	"Erreur lors de l'enregistrement du compte: %v":                                                             "Error saving the account: %v",
+	"Compte bancaire enregistré (ID: %d)":                                                                       "Bank account saved (ID: %d)",
+	"Définir le compte bancaire imprimé sur les documents":                                                      "Set the bank account printed on documents",
+	"Compte bancaire par défaut mis à jour":                                                                     "Default bank account updated",
+	"Supprimer un compte bancaire":                                                                              "Delete a bank account",
+	"Compte non trouvé: %v":                                                                                     "Account not found: %v",
+	"Compte à supprimer: %s %s":                                                                                 "Account to delete: %s %s",
+	"Compte bancaire supprimé":                                                                                  "Bank account deleted",
+	"erreur lors de la récupération de l'entreprise: %v":                                                        "error retrieving the company: %v",
+	"aucune entreprise configurée. Utilisez 'outbil company setup' d'abord":                                     "no company configured. Use 'outbil company setup' first",
+
+	// Mentions légales
+	"aucune entreprise configurée":    "no company configured",
+	"adresse":                         "address",
+	"code postal":                     "postal code",
+	"ville":                           "city",
+	"forme juridique":                 "legal form",
+	"capital social":                  "share capital",
+	"immatriculation RCS/RM":          "RCS/RM registration",
+	"numéro de police d'assurance":    "insurance policy number",
+	"mentions légales manquantes: %s": "missing legal information: %s",
+	"Forme juridique (EI, EURL, SARL, SAS, SASU...)": "Legal form (EI, EURL, SARL, SAS, SASU...)",
+	"Capital social":   "Share capital",
+	"montant invalide": "invalid amount",
+	"Registre d'immatriculation (RCS, RM ou vide)":            "Registration register (RCS, RM or empty)",
+	"valeurs possibles: RCS, RM ou vide":                      "possible values: RCS, RM or empty",
+	"Ville d'immatriculation (greffe ou chambre des métiers)": "Registration city (court registry or chamber of trades)",
+	"Code APE/NAF (ex: 6201Z)":                                "APE/NAF code (e.g. 6201Z)",
+	"format attendu: 4 chiffres et une lettre (ex: 6201Z)":    "expected format: 4 digits and a letter (e.g. 6201Z)",
+	"Assureur professionnel (optionnel)":                      "Professional insurer (optional)",
+	"Numéro de police d'assurance":                            "Insurance policy number",
+	"Couverture géographique de l'assurance":                  "Geographical coverage of the insurance",
+	"Conditions de paiement par défaut":                       "Default payment terms",
+	"SIREN invalide (clé de contrôle incorrecte)":             "invalid SIREN (wrong check digit)",
+	"le SIREN doit comporter 9 chiffres":                      "the SIREN must have 9 digits",
+	"le SIRET doit comporter 14 chiffres":                     "the SIRET must have 14 digits",
+	"SIRET invalide (clé de contrôle incorrecte)":             "invalid SIRET (wrong check digit)",
+	"numéro de TVA trop court":                                "VAT number too short",
+	"format de numéro de TVA %s invalide":                     "invalid %s VAT number format",
+	"numéro de TVA FR invalide: %v":                           "invalid FR VAT number: %v",
+	"clé de TVA incorrecte, attendu %s":                       "wrong VAT key, expected %s",
+	"le SIRET %s ne correspond pas au SIREN %s":               "the SIRET %s does not match the SIREN %s",
+	"le numéro de TVA %s ne correspond pas au SIREN %s":       "the VAT number %s does not match the SIREN %s",
+	"longueur d'IBAN invalide":                                "invalid IBAN length",
+	"l'IBAN contient des caractères invalides":                "the IBAN contains invalid characters",
+	"un IBAN %s doit comporter %d caractères":                 "a %s IBAN must have %d characters",
+	"IBAN invalide (clé de contrôle incorrecte)":              "invalid IBAN (wrong check digits)",
+	"BIC invalide (8 ou 11 caractères, ex: BNPAFRPPXXX)":      "invalid BIC (8 or 11 characters, e.g. BNPAFRPPXXX)",
+
+	// Logo
+	"Gérer le logo imprimé sur les devis": "Manage the logo printed on quotes",
+	"Le logo est enregistré dans la base de données avec les informations de l'entreprise,\nles PDFs sont donc identiques quel que soit le dossier depuis lequel outbil est lancé.": "The logo is stored in the database with the company information,\nso PDFs are identical whatever the folder outbil is run from.",
+	"Importer un logo (PNG, JPEG ou GIF)": "Import a logo (PNG, JPEG or GIF)",
+	"Importer une image comme logo de l'entreprise. L'image est contrôlée, réduite\nsi elle dépasse 600x300 pixels puis enregistrée au format PNG dans la base.": "Import an image as the company logo. The image is checked, scaled down\nif it exceeds 600x300 pixels, then stored as PNG in the database.",
+	"Logo invalide: %v":                                                   "Invalid logo: %v",
+	"Erreur lors de l'enregistrement du logo: %v":                         "Error saving the logo: %v",
+	"Logo enregistré (%dx%d pixels, %d Ko)":                               "Logo saved (%dx%d pixels, %d KB)",
+	"Afficher les caractéristiques du logo enregistré":                    "Show the properties of the stored logo",
+	"Aucun logo enregistré. Utilisez 'outbil company logo set <fichier>'": "No logo stored. Use 'outbil company logo set <file>'",
+	"Le logo enregistré est illisible: %v":                                "The stored logo is unreadable: %v",
+	"Logo: %dx%d pixels, %d Ko":                                           "Logo: %dx%d pixels, %d KB",
+	"Supprimer le logo":                                                   "Delete the logo",
+	"Supprimer le logo de %s":                                             "Delete the logo of %s",
+	"Erreur lors de la suppression du logo: %v":                           "Error deleting the logo: %v",
+	"Logo supprimé":                                                       "Logo deleted",
+	"Le fichier %s n'est plus lu automatiquement. Importez-le avec: outbil company logo set %s": "The file %s is no longer read automatically. Import it with: outbil company logo set %s",
+	"fichier vide": "empty file",
+	"fichier trop volumineux (%d Ko, maximum %d Ko)":        "file too large (%d KB, maximum %d KB)",
+	"format d'image non reconnu (PNG, JPEG ou GIF attendu)": "unrecognized image format (PNG, JPEG or GIF expected)",
+	"image trop petite (%dx%d pixels)":                      "image too small (%dx%d pixels)",
+
+	// Devises et taux de change
+	"Devise de référence (par défaut la devise de l'entreprise par défaut)": "Reference currency (defaults to the currency of the default company)",
+	"Gérer les devises et les taux de change":                               "Manage currencies and exchange rates",
+	"Chaque devis est établi dans sa propre devise (par défaut celle du client, puis celle de\nl'entreprise). Les taux de change saisis localement permettent de convertir les totaux\ndans la devise de l'entreprise dans les rapports.": "Each quote is issued in its own currency (by default the client's, then the\ncompany's). Exchange rates entered locally are used to convert totals\ninto the company currency in reports.",
+	"Lister les devises prises en charge":         "List supported currencies",
+	"Symbole":                                     "Symbol",
+	"Décimales":                                   "Decimals",
+	"Gérer les taux de change":                    "Manage exchange rates",
+	"Lister les taux de change enregistrés":       "List recorded exchange rates",
+	"Erreur lors de la récupération des taux: %v": "Error retrieving rates: %v",
+	"Aucun taux enregistré. Utilisez 'outbil currency rate set USD 0.92'": "No rate recorded. Use 'outbil currency rate set USD 0.92'",
+	"Référence":                     "Reference",
+	"Taux":                          "Rate",
+	"[DEVISE] [TAUX]":               "[CURRENCY] [RATE]",
+	"Enregistrer un taux de change": "Record an exchange rate",
+	"Enregistrer la valeur d'une unité de DEVISE dans la devise de référence, à partir\nd'une date d'effet. Le taux le plus récent antérieur à la date d'un devis est utilisé\npour le convertir.\n\nExemples:\n  outbil currency rate set USD 0.92\n  outbil currency rate set GBP 1.17 --from 01/01/2025\n  outbil currency rate set EUR 0.94 --base CHF": "Record the value of one unit of CURRENCY in the reference currency, from\nan effective date. The most recent rate prior to a quote's date is used\nto convert it.\n\nExamples:\n  outbil currency rate set USD 0.92\n  outbil currency rate set GBP 1.17 --from 01/01/2025\n  outbil currency rate set EUR 0.94 --base CHF",
+	"Taux invalide: %s": "Invalid rate: %s",
+	"Erreur lors de la récupération de l'entreprise: %v":                       "Error retrieving the company: %v",
+	"La devise et la devise de référence sont identiques":                      "The currency and the reference currency are identical",
+	"Erreur lors de l'enregistrement du taux: %v":                              "Error saving the rate: %v",
+	"Taux enregistré: 1 %s = %s %s à partir du %s":                             "Rate saved: 1 %s = %s %s from %s",
+	"Supprimer un taux de change":                                              "Delete an exchange rate",
+	"Taux supprimé":                                                            "Rate deleted",
+	"devise %q non prise en charge (code ISO 4217 attendu, ex: EUR, USD, GBP)": "unsupported currency %q (ISO 4217 code expected, e.g. EUR, USD, GBP)",
+	"date d'effet invalide %q: %w":                                             "invalid effective date %q: %w",
+
+	// Bases de données
+	"Gérer les bases de données":                                      "Manage databases",
+	"Gérer plusieurs bases de données (production, demo, test, etc.)": "Manage several databases (production, demo, test, etc.)",
+	"Lister toutes les bases de données disponibles":                  "List all available databases",
+	"Erreur lors de la lecture des bases: %v":                         "Error reading databases: %v",
+	"Aucune base de données trouvée":                                  "No database found",
+	"Créez-en une avec: outbil db create <nom>":                       "Create one with: outbil db create <name>",
+	"Bases de données disponibles:":                                   "Available databases:",
+	"Taille: %d octets":                                               "Size: %d bytes",
+	"Taille: %.1f Ko":                                                 "Size: %.1f KB",
+	"Taille: %.1f Mo":                                                 "Size: %.1f MB",
+	"[nom]":                                                           "[name]",
+	"Créer une nouvelle base de données":                              "Create a new database",
+	"Le nom de base ne doit pas contenir de caractères spéciaux":      "The database name must not contain special characters",
+	"La base de données '%s' existe déjà":                             "Database '%s' already exists",
+	"Impossible de créer le répertoire: %v":                           "Unable to create the directory: %v",
+	"Impossible de créer la base: %v":                                 "Unable to create the database: %v",
+	"Base de données '%s' créée avec succès":                          "Database '%s' created successfully",
+	"Pour utiliser cette base, exécutez:":                             "To use this database, run:",
+	"Changer de base de données active":                               "Switch the active database",
+	"La base de données '%s' n'existe pas":                            "Database '%s' does not exist",
+	"Bases disponibles:":                                              "Available databases:",
+	"Impossible de sauvegarder la configuration: %v":                  "Unable to save the configuration: %v",
+	"Base de données active: %s":                                      "Active database: %s",
+	"Cette base n'a pas encore d'entreprise configurée":               "This database has no company configured yet",
+	"Configurez-la avec: outbil company setup":                        "Configure it with: outbil company setup",
+	"Afficher la base de données active":                              "Show the active database",
+	"Chemin: %s":                                                      "Path: %s",
+	"Dernière modification: %s":                                       "Last modified: %s",
+	"Supprimer une base de données":                                   "Delete a database",
+	"Impossible de supprimer la base active":                          "Cannot delete the active database",
+	"Changez d'abord de base avec: outbil db switch <autre-base>":     "Switch to another database first with: outbil db switch <other-db>",
+	"Êtes-vous sûr de vouloir supprimer la base '%s' ?":               "Are you sure you want to delete database '%s'?",
+	"Cette action est irréversible!":                                  "This action cannot be undone!",
+	"Tapez le nom de la base pour confirmer:":                         "Type the database name to confirm:",
+	"Base de données '%s' supprimée":                                  "Database '%s' deleted",
+
+	// Langue des messages
+	"Afficher ou choisir la langue des messages": "Show or choose the message language",
+	"Afficher ou choisir la langue des messages de la ligne de commande.\n\nSans réglage (auto), la langue suit les variables LC_ALL, LC_MESSAGES et LANG.\nLa langue des PDFs se règle séparément, par client ou par devis.": "Show or choose the language of command-line messages.\n\nWithout a setting (auto), the language follows the LC_ALL, LC_MESSAGES and LANG variables.\nThe PDF language is set separately, per client or per quote.",
+	"Langue des messages: %s":                                    "Message language: %s",
+	"Langue détectée depuis l'environnement (LANG)":              "Language detected from the environment (LANG)",
+	"Langues disponibles: %s":                                    "Available languages: %s",
+	"Langue non disponible: %s (%s)":                             "Language not available: %s (%s)",
+	"La langue des messages suit désormais l'environnement (%s)": "The message language now follows the environment (%s)",
+
+	// Génération des PDFs
+	"erreur lors de la fusion avec les CGV: %w":      "error merging with the terms and conditions: %w",
+	"erreur lors de la création du pied de page: %w": "error creating the footer: %w",
+	"erreur lors de la génération du PDF: %w":        "error generating the PDF: %w",
+	"erreur lors de la fusion PDF: %w":               "error merging PDFs: %w",
+	"erreur lors de la sauvegarde du PDF: %w":        "error saving the PDF: %w",
+	"filigrane invalide: %w":                         "invalid watermark: %w",
+	"erreur lors de l'ajout du filigrane: %w":        "error adding the watermark: %w",
+	"police %s introuvable dans %s":                  "font %s not found in %s",
+
+	// Thèmes des PDFs
+	"erreur lors du chargement des polices: %w":     "error loading fonts: %w",
+	"Consulter les thèmes de mise en page des PDFs": "Browse the PDF layout themes",
+	"Un thème règle la mise en page des PDFs : polices, couleurs, colonnes du tableau\net ordre des blocs. Les thèmes classic, modern et minimal sont intégrés, ainsi que le\nthème html qui convertit un modèle HTML en PDF ; un fichier <nom>.json placé dans\n~/.outbil/themes ajoute un thème utilisateur.\n\nPour créer un thème, partez d'un thème existant :\n  outbil theme show modern > ~/.outbil/themes/maison.json": "A theme sets the PDF layout: fonts, colours, table columns and block order.\nThe classic, modern and minimal themes are built in, as well as the html theme\nthat converts an HTML template to PDF; a <name>.json file placed in\n~/.outbil/themes adds a user theme.\n\nTo create a theme, start from an existing one:\n  outbil theme show modern > ~/.outbil/themes/mine.json",
+	"Lister les thèmes disponibles":          "List the available themes",
+	"--- Thèmes intégrés ---":                "--- Built-in themes ---",
+	"--- Thèmes utilisateur (%s) ---":        "--- User themes (%s) ---",
+	"Aucun thème utilisateur":                "No user theme",
+	"%-10s invalide: %v":                     "%-10s invalid: %v",
+	"Afficher la définition JSON d'un thème": "Show the JSON definition of a theme",
+	"[NOM]": "[NAME]",
+	"Afficher ou choisir le thème des PDFs de l'entreprise": "Show or choose the company's PDF theme",
+	"Afficher ou choisir le thème utilisé pour les PDFs de l'entreprise : un thème\nintégré, un thème utilisateur de ~/.outbil/themes ou le chemin d'un fichier JSON.\nL'option --theme de 'outbil quote pdf' le remplace pour un seul rendu.": "Show or choose the theme used for the company's PDFs: a built-in theme,\na user theme from ~/.outbil/themes or the path of a JSON file.\nThe --theme option of 'outbil quote pdf' overrides it for a single rendering.",
+	"Thème: %s":                             "Theme: %s",
+	"Thème PDF:   %s":                       "PDF theme:   %s",
+	"Thème invalide: %v":                    "Invalid theme: %v",
+	"Thèmes disponibles: outbil theme list": "Available themes: outbil theme list",
+	"Thème des PDFs de %s: %s":              "PDF theme of %s: %s",
+	"Thème de mise en page (nom ou fichier JSON) à la place de celui de l'entreprise":           "Layout theme (name or JSON file) instead of the company's",
+	"Mise en page historique : titre centré, tableau encadré, montants en police à chasse fixe": "Original layout: centred title, boxed table, amounts in a fixed-width font",
+	"Titre à gauche, en-tête de tableau coloré, lignes séparées par un simple trait":            "Title on the left, coloured table header, rows separated by a thin line",
+	"Sobre : sans couleur ni bordure, client avant les dates, paiement en fin de document":      "Plain: no colour or border, client before the dates, payment at the end",
+	"couleur invalide %q (format attendu #RRGGBB)":                                              "invalid colour %q (expected format #RRGGBB)",
+	"thème %q introuvable (thèmes intégrés: %s)":                                                "theme %q not found (built-in themes: %s)",
+	"%s: thème de base %q inconnu (%s)":                                                         "%s: unknown base theme %q (%s)",
+	"font_files: le fichier \"regular\" est obligatoire":                                        "font_files: the \"regular\" file is required",
+	"font: choisissez un nom propre à la police TrueType (pas %s)":                              "font: choose a name of its own for the TrueType font (not %s)",
+	"police %q inconnue (%s, ou une police déclarée dans font_files)":                           "unknown font %q (%s, or a font declared in font_files)",
+	"font_size doit être compris entre 6 et 16":                                                 "font_size must be between 6 and 16",
+	"title_size doit être compris entre font_size et 40":                                        "title_size must be between font_size and 40",
+	"margin doit être compris entre 5 et 40 mm":                                                 "margin must be between 5 and 40 mm",
+	"title_align %q invalide (left, center ou right)":                                           "invalid title_align %q (left, center or right)",
+	"table_border %q invalide (full, rows ou none)":                                             "invalid table_border %q (full, rows or none)",
+	"colonne %q inconnue (%s)":                                                                  "unknown column %q (%s)",
+	"colonne %q en double":                                                                      "duplicate column %q",
+	"colonne %q: largeur invalide":                                                              "column %q: invalid width",
+	"les colonnes %s et %s sont obligatoires":                                                   "the %s and %s columns are required",
+	"la somme des largeurs de colonnes doit valoir 12 (actuellement %d)":                        "column widths must add up to 12 (currently %d)",
+	"bloc %q inconnu (%s)":                                                                      "unknown block %q (%s)",
+	"bloc %q en double":                                                                         "duplicate block %q",
+	"le bloc %q est obligatoire":                                                                "the %q block is required",
+	"watermark: statut %q inconnu (%s)":                                                         "watermark: unknown status %q (%s)",
+	"watermark: filigrane %q inconnu (%s)":                                                      "watermark: unknown watermark %q (%s)",
+	"watermark: opacity doit être comprise entre 0.05 et 1":                                     "watermark: opacity must be between 0.05 and 1",
+	"watermark: scale doit être compris entre 0.1 et 1":                                         "watermark: scale must be between 0.1 and 1",
+
+	"Erreur lors de la sauvegarde: %v":                                    "Error while saving: %v",
+	"Modèle HTML intégré converti en PDF, base des modèles personnalisés": "Built-in HTML template converted to PDF, a starting point for custom templates",
+	"Afficher le modèle HTML intégré":                                     "Show the built-in HTML template",
+	"Afficher le modèle HTML intégré, point de départ d'un modèle personnalisé.\n\nUn thème utilise un modèle HTML avec \"engine\": \"html\" et \"template\": \"<fichier>\" :\n  outbil theme template > ~/.outbil/themes/maison.html\n  echo '{\"engine\": \"html\", \"template\": \"maison.html\"}' > ~/.outbil/themes/maison.json": "Show the built-in HTML template, a starting point for a custom template.\n\nA theme uses an HTML template with \"engine\": \"html\" and \"template\": \"<file>\":\n  outbil theme template > ~/.outbil/themes/mine.html\n  echo '{\"engine\": \"html\", \"template\": \"mine.html\"}' > ~/.outbil/themes/mine.json",
+	"erreur lors de la lecture du modèle HTML: %w":          "error reading the HTML template: %w",
+	"modèle HTML invalide: %w":                              "invalid HTML template: %w",
+	"erreur dans le modèle HTML: %w":                        "error in the HTML template: %w",
+	"page HTML illisible: %w":                               "unreadable HTML page: %w",
+	"template: un modèle HTML demande \"engine\": \"html\"": "template: an HTML template requires \"engine\": \"html\"",
+	"engine %q inconnu (maroto ou html)":                    "unknown engine %q (maroto or html)",
+
+	// Protection des PDFs
+	"Afficher ou choisir la protection des PDFs de l'entreprise": "Show or choose the protection of the company's PDFs",
+	"Afficher ou choisir la protection appliquée aux PDFs générés, par chiffrement :\n  off     aucune protection\n  print   lecture et impression, modification interdite (par défaut)\n  read    lecture seule, ni impression ni modification\n  all     aucune restriction (avec --user-password pour exiger un mot de passe)\n\nLes mots de passe sont saisis sans être affichés. Sans mot de passe\npropriétaire, un mot de passe aléatoire est tiré pour chaque PDF : personne ne\npeut alors lever les restrictions. Une saisie vide supprime le mot de passe\nenregistré.\n\nLes mots de passe sont enregistrés en clair dans la base, pour protéger les\nPDFs générés sans nouvelle saisie : quiconque lit la base peut ouvrir les PDFs.\n\nExemples:\n  outbil company protection print\n  outbil company protection all --user-password\n  outbil company protection print --owner-password": "Show or choose the protection applied to generated PDFs, by encryption:\n  off     no protection\n  print   reading and printing, no modification (default)\n  read    read only, no printing or modification\n  all     no restriction (with --user-password to require a password)\n\nPasswords are typed without being displayed. Without an owner password, a\nrandom password is drawn for each PDF: nobody can then lift the restrictions.\nAn empty entry removes the saved password.\n\nPasswords are saved in clear in the database, to protect generated PDFs\nwithout typing them again: anyone who can read the database can open the PDFs.\n\nExamples:\n  outbil company protection print\n  outbil company protection all --user-password\n  outbil company protection print --owner-password",
+	"Saisir le mot de passe propriétaire (vide: aléatoire à chaque PDF)":     "Type the owner password (empty: random for each PDF)",
+	"Saisir le mot de passe d'ouverture des PDFs (vide: aucun)":              "Type the password required to open the PDFs (empty: none)",
+	"Protection %q inconnue (%s)":                                            "Unknown protection %q (%s)",
+	"Sans mot de passe d'ouverture, la protection \"all\" ne restreint rien": "Without an open password, the \"all\" protection restricts nothing",
+	"Protection des PDFs de %s: %s":                                          "PDF protection of %s: %s",
+	"Les mots de passe des PDFs sont enregistrés en clair dans %s":           "PDF passwords are saved in clear in %s",
+	"Mot de passe propriétaire":                                              "Owner password",
+	"Mot de passe d'ouverture":                                               "Open password",
+	"aucune":                                                                 "none",
+	"lecture seule":                                                          "read only",
+	"sans restriction":                                                       "no restriction",
+	"impression autorisée, modification interdite":                           "printing allowed, modification forbidden",
+	", mot de passe d'ouverture":                                             ", open password",
+	", mot de passe propriétaire":                                            ", owner password",
+	"erreur lors de l'écriture des métadonnées du PDF: %w":                   "error writing the PDF metadata: %w",
+	"erreur lors du chiffrement du PDF: %w":                                  "error encrypting the PDF: %w",
+
+	// Signature des PDFs
+	"Afficher ou choisir le certificat de signature des PDFs de l'entreprise": "Show or choose the certificate signing the company's PDFs",
+	"Afficher ou choisir le certificat PKCS#12 (.p12, .pfx) avec lequel les PDFs\ngénérés sont signés. La signature, au format PAdES, est ajoutée après la jonction\ndes CGV et le chiffrement : toute modification ultérieure du fichier est détectée\npar les lecteurs PDF et par 'outbil pdf verify'.\n\nLe certificat reste à son emplacement ; seul son chemin est enregistré. Son mot\nde passe, saisi sans être affiché, n'est jamais enregistré : il est demandé pour\nvalider le certificat, puis une fois par commande qui signe des PDFs. \"off\"\ndésactive la signature.\n\nExemples:\n  outbil company signature ~/certificats/entreprise.p12\n  outbil company signature off": "Show or choose the PKCS#12 certificate (.p12, .pfx) used to sign generated\nPDFs. The PAdES signature is added after the terms are appended and the file is\nencrypted: any later change to the file is detected by PDF readers and by\n'outbil pdf verify'.\n\nThe certificate stays where it is; only its path is saved. Its password, typed\nwithout being displayed, is never saved: it is asked to check the certificate,\nthen once per command that signs PDFs. \"off\" disables signing.\n\nExamples:\n  outbil company signature ~/certificates/company.p12\n  outbil company signature off",
+	"Mot de passe du certificat %s":                              "Password of the certificate %s",
+	"Certificat invalide: %v":                                    "Invalid certificate: %v",
+	"Signature des PDFs de %s: %s":                               "PDF signature of %s: %s",
+	"%s (illisible: %v)":                                         "%s (unreadable: %v)",
+	"%s (mot de passe demandé à la signature)":                   "%s (password asked when signing)",
+	"%s, émis par %s, valable jusqu'au %s (%s)":                  "%s, issued by %s, valid until %s (%s)",
+	"Contrôler les PDFs générés":                                 "Check generated PDFs",
+	"Vérifier hors ligne les signatures et l'intégrité d'un PDF": "Check the signatures and integrity of a PDF offline",
+	"Vérifier, sans connexion, les signatures d'un PDF : intégrité des octets\nsignés, validité de la signature et confiance dans le certificat du signataire.\n\nLe certificat est reconnu s'il remonte à une autorité du système ou à un\ncertificat donné avec --trust, par exemple son propre certificat auto-signé.\nLa commande échoue (code de sortie 1) si le PDF n'est pas signé, si une\nsignature est invalide ou si le document a été modifié après la signature.\n\nExemples:\n  outbil pdf verify devis.pdf\n  outbil pdf verify devis.pdf --trust entreprise.pem": "Check the signatures of a PDF without a connection: integrity of the signed\nbytes, validity of the signature and trust in the signer's certificate.\n\nThe certificate is trusted if it chains up to a system authority or to a\ncertificate given with --trust, for instance your own self-signed certificate.\nThe command fails (exit code 1) if the PDF is not signed, if a signature is\ninvalid or if the document was changed after signing.\n\nExamples:\n  outbil pdf verify quote.pdf\n  outbil pdf verify quote.pdf --trust company.pem",
+	"Mot de passe d'ouverture du PDF":                                "Password required to open the PDF",
+	"Certificat d'autorité de confiance supplémentaire (PEM ou DER)": "Additional trusted authority certificate (PEM or DER)",
+	"Erreur de lecture: %v":                                          "Read error: %v",
+	"%s n'est pas signé":                                             "%s is not signed",
+	"%s: signature invalide ou document modifié":                     "%s: invalid signature or modified document",
+	"%s: document intact, signé par %s":                              "%s: document intact, signed by %s",
+	"Signataire:  %s":                                                "Signer:      %s",
+	"Émetteur:    %s":                                                "Issuer:      %s",
+	"Validité:    %s - %s":                                           "Validity:    %s - %s",
+	"Lieu:        %s":                                                "Location:    %s",
+	"Motif:       %s":                                                "Reason:      %s",
+	"Signature:   invalide (%v)":                                     "Signature:   invalid (%v)",
+	"Signature:   valide, mais le document a été complété après la signature": "Signature:   valid, but the document was extended after signing",
+	"Signature:   valide, document intact":                                    "Signature:   valid, document intact",
+	"Confiance:   certificat non reconnu (%v)":                                "Trust:       untrusted certificate (%v)",
+	"Confiance:   certificat reconnu":                                         "Trust:       trusted certificate",
+	"erreur de lecture du certificat: %w":                                     "error reading the certificate: %w",
+	"certificat %s illisible: %w":                                             "unreadable certificate %s: %w",
+	"certificat PKCS#12 illisible: %w":                                        "unreadable PKCS#12 certificate: %w",
+	"clé privée du certificat non prise en charge":                            "unsupported certificate private key",
+	"le certificat %s n'est pas destiné à la signature":                       "certificate %s is not meant for signing",
+	"le certificat %s a expiré le %s":                                         "certificate %s expired on %s",
+	"erreur lors de la signature du PDF: %w":                                  "error signing the PDF: %w",
+	"mot de passe d'ouverture du PDF manquant ou incorrect (--password)":      "missing or wrong PDF open password (--password)",
+	"PDF illisible: %w":                                                       "unreadable PDF: %w",
+	"dictionnaire de signature illisible":                                     "unreadable signature dictionary",
+	"signature absente des octets réservés":                                   "signature missing from the reserved bytes",
+	"signature CMS illisible: %w":                                             "unreadable CMS signature: %w",
+	"le document a été modifié après la signature":                            "the document was modified after signing",
+	"signature invalide: %w":                                                  "invalid signature: %w",
+	"plages d'octets signées (/ByteRange) invalides":                          "invalid signed byte ranges (/ByteRange)",
+	"certificat du signataire absent":                                         "signer certificate missing",
+	"certificat auto-signé":                                                   "self-signed certificate",
+	"autorité de certification inconnue":                                      "unknown certificate authority",
+
+	// Archivage PDF/A
+	"Générer un PDF/A-3b d'archivage (polices incorporées, données du devis jointes)": "Generate an archival PDF/A-3b (embedded fonts, quote data attached)",
+	"PDF/A: protection %s ignorée, la norme interdit le chiffrement":                  "PDF/A: protection %s ignored, the standard forbids encryption",
+	"PDF non conforme à PDF/A-3b, il n'a pas été écrit:":                              "PDF not compliant with PDF/A-3b, it was not written:",
+	"PDF non conforme à PDF/A-3b: %s":                                                 "PDF not compliant with PDF/A-3b: %s",
+	"le PDF est chiffré":                                                              "the PDF is encrypted",
+	"arbre des fichiers joints du PDF non pris en charge":                             "unsupported PDF attachment tree",
+	"identifiant du fichier (/ID) absent":                                             "missing file identifier (/ID)",
+	"métadonnées XMP absentes":                                                        "missing XMP metadata",
+	"identification PDF/A-3 absente des métadonnées XMP":                              "PDF/A-3 identification missing from XMP metadata",
+	"intention de sortie PDF/A (profil de couleurs) absente":                          "missing PDF/A output intent (colour profile)",
+	"le PDF contient du JavaScript ou des actions automatiques":                       "the PDF contains JavaScript or automatic actions",
+	"configuration des calques non conforme (/Name absent ou /AS présent)":            "non-compliant layer configuration (/Name missing or /AS present)",
+	"fichier joint %s sans relation au document (/AFRelationship)":                    "attachment %s has no relationship to the document (/AFRelationship)",
+	"fichier joint %s sans type MIME":                                                 "attachment %s has no MIME type",
+	"police %s non incorporée (page %d)":                                              "font %s not embedded (page %d)",
+	"annotation %s non imprimable page %d":                                            "annotation %s not printable on page %d",
+
+	// Emplacement des fichiers générés
+	"Dossier racine des fichiers générés (relatif au dossier courant ou absolu)": "Root folder of generated files (relative to the current folder or absolute)",
+	"Modèle de nom des fichiers, par exemple {year}/{client}/{number}.pdf":       "File name template, for example {year}/{client}/{number}.pdf",
+	"Fichier existant d'un autre document: rename, overwrite ou fail":            "Existing file of another document: rename, overwrite or fail",
+	"Revenir aux réglages par défaut":                                            "Restore the default settings",
+	"Afficher ou choisir l'emplacement et le nom des fichiers générés":           "Show or choose the location and name of generated files",
+	"Afficher ou choisir, pour la base active, le dossier et le modèle de nom des\nPDFs et pages HTML générés. L'option --output de 'quote pdf' et 'quote html' les\nremplace pour une génération.\n\nVariables du modèle de nom (l'extension est ajoutée si besoin):\n  {year} {month} {day}   date du devis\n  {number}               numéro complet (2025-01-0001)\n  {seq}                  numéro dans la séquence (0001)\n  {client}               client (société ou nom)\n  {company}              entreprise émettrice\n  {status}               statut du devis\n\nUn fichier existant généré pour le même devis est remplacé. Pour un fichier\nd'un autre document, --conflict choisit de numéroter le nouveau fichier\n(rename, par défaut), de le remplacer (overwrite) ou d'échouer (fail).\n\nExemples:\n  outbil db output --dir ~/Documents/devis --name \"{year}/{client}/{number}.pdf\"\n  outbil db output --conflict fail\n  outbil db output --reset": "Show or choose, for the active database, the folder and name template of\ngenerated PDFs and HTML pages. The --output option of 'quote pdf' and 'quote html'\noverrides them for one generation.\n\nName template variables (the extension is added if needed):\n  {year} {month} {day}   quote date\n  {number}               full number (2025-01-0001)\n  {seq}                  number in the sequence (0001)\n  {client}               client (company or name)\n  {company}              issuing company\n  {status}               quote status\n\nAn existing file generated for the same quote is replaced. For a file of\nanother document, --conflict chooses to number the new file (rename, the\ndefault), to replace it (overwrite) or to fail (fail).\n\nExamples:\n  outbil db output --dir ~/Documents/quotes --name \"{year}/{client}/{number}.pdf\"\n  outbil db output --conflict fail\n  outbil db output --reset",
+	"Le modèle ne contient ni {number} ni {seq}: des devis différents risquent de porter le même nom": "The template contains neither {number} nor {seq}: different quotes may get the same name",
+	"Réglages des fichiers générés de la base %s enregistrés":                                         "Generated file settings of database %s saved",
+	"Dossier:     %s":                                        "Folder:      %s",
+	"Conflits:    %s":                                        "Conflicts:   %s",
+	"Exemple:     %s":                                        "Example:     %s",
+	"erreur de lecture des réglages: %w":                     "error reading settings: %w",
+	"variable inconnue %s dans le modèle de nom (%s)":        "unknown variable %s in the name template (%s)",
+	"le modèle de nom est vide":                              "the name template is empty",
+	"le modèle de nom doit être relatif au dossier (--dir)":  "the name template must be relative to the folder (--dir)",
+	"le modèle de nom ne peut pas sortir du dossier (--dir)": "the name template cannot leave the folder (--dir)",
+	"conduite invalide %q (%s)":                              "invalid conflict policy %q (%s)",
+	"le fichier %s existe déjà pour un autre document (--conflict overwrite pour le remplacer)": "the file %s already exists for another document (--conflict overwrite to replace it)",
+	"Fichier ou dossier de destination, à la place des réglages de la base (outbil db output)":  "Destination file or folder, instead of the database settings (outbil db output)",
+
+	// Génération de devis par lots
+	"Exporter un devis en PDF, ou plusieurs devis à la fois avec une sélection\n(--all, --company, --status, --from, --to). Les PDFs d'une sélection sont générés\nen parallèle (--jobs) et peuvent être réunis dans un seul PDF (--merge) ou une\narchive ZIP (--zip).": "Export a quote as PDF, or several quotes at once with a selection\n(--all, --company, --status, --from, --to). The PDFs of a selection are generated\nin parallel (--jobs) and can be combined into a single PDF (--merge) or a\nZIP archive (--zip).",
+	"Générer les PDFs de tous les devis":                                                                    "Generate the PDFs of all quotes",
+	"Générer les PDFs des devis de cette entreprise (ID ou nom)":                                            "Generate the PDFs of this company's quotes (ID or name)",
+	"Générer les PDFs des devis de ce statut (draft, sent, accepted...)":                                    "Generate the PDFs of quotes with this status (draft, sent, accepted...)",
+	"Générer les PDFs des devis datés de ce jour ou après (format de l'entreprise ou AAAA-MM-JJ)":           "Generate the PDFs of quotes dated on or after this day (company's format or YYYY-MM-DD)",
+	"Générer les PDFs des devis datés de ce jour ou avant (format de l'entreprise ou AAAA-MM-JJ)":           "Generate the PDFs of quotes dated on or before this day (company's format or YYYY-MM-DD)",
+	"Nombre de PDFs générés en parallèle":                                                                   "Number of PDFs generated in parallel",
+	"Réunir les PDFs générés dans ce fichier PDF":                                                           "Combine the generated PDFs into this PDF file",
+	"Réunir les PDFs générés dans cette archive ZIP":                                                        "Combine the generated PDFs into this ZIP archive",
+	"Indiquez soit l'ID d'un devis, soit une sélection de devis (--all, --company, --status, --from, --to)": "Give either a quote ID or a selection of quotes (--all, --company, --status, --from, --to)",
+	"--jobs, --merge et --zip ne s'appliquent qu'à une sélection de devis":                                  "--jobs, --merge and --zip only apply to a selection of quotes",
+	"--jobs doit valoir au moins 1":                                                                         "--jobs must be at least 1",
+	"Avec plusieurs devis, --output doit désigner un dossier: %s":                                           "With several quotes, --output must be a folder: %s",
+	"Aucun devis ne correspond à la sélection":                                                              "No quote matches the selection",
+	"Génération de %d PDF(s), %d en parallèle":                                                              "Generating %d PDF(s), %d in parallel",
+	"%d PDF(s) générés":                                       "%d PDF(s) generated",
+	"%d PDF(s) générés, %d en erreur":                         "%d PDF(s) generated, %d failed",
+	"PDFs réunis: %s":                                         "PDFs combined: %s",
+	"Erreur lors de la fusion des PDFs: %v":                   "Error combining the PDFs: %v",
+	"Archive ZIP créée: %s":                                   "ZIP archive created: %s",
+	"Erreur lors de la création de l'archive ZIP: %v":         "Error creating the ZIP archive: %v",
+	"devis non trouvé: %v":                                    "quote not found: %v",
+	"erreur lors de la récupération des infos société: %v":    "error retrieving the company information: %v",
+	"thème invalide: %v":                                      "invalid theme: %v",
+	"erreur lors de la sélection des CGV: %v":                 "error selecting the terms and conditions: %v",
+	"le fichier %s est déjà attribué à un autre devis du lot": "the file %s is already assigned to another quote of the batch",
+	"statut invalide %q (%s)":                                 "invalid status %q (%s)",
+
+	// Devis
+	"Client supprimé #%d": "Deleted client #%d",
+	"Entreprise émettrice (ID ou nom), par défaut l'entreprise par défaut":             "Issuing company (ID or name), defaults to the default company",
+	"Devise du devis (code ISO 4217), par défaut celle du client puis de l'entreprise": "Quote currency (ISO 4217 code), defaults to the client's then the company's",
+	"Langue du PDF (fr, en), par défaut celle du client":                               "PDF language (fr, en), defaults to the client's",
+	"Générer le PDF dans une autre langue (fr, en) sans modifier le devis":             "Generate the PDF in another language (fr, en) without changing the quote",
+	"Gérer les devis": "Manage quotes",
+	"Commandes pour créer, lister, modifier et exporter des devis": "Commands to create, list, edit and export quotes",
+	"Lister tous les devis":                        "List all quotes",
+	"Erreur lors de la récupération des devis: %v": "Error retrieving quotes: %v",
+	"Aucun devis trouvé":                           "No quote found",
+	"Numéro":                                       "Number",
+	"Montant":                                      "Amount",
+	"Créer un nouveau devis":                       "Create a new quote",
+	"Entreprise émettrice introuvable: %v":         "Issuing company not found: %v",
+	"Entreprise émettrice: %s":                     "Issuing company: %s",
+	"Aucun client trouvé. Créez d'abord un client avec 'outbil client add'": "No client found. Create a client first with 'outbil client add'",
+	"Sélectionner un client":                                "Select a client",
+	"Erreur lors de la sélection du contact: %v":            "Error selecting the contact: %v",
+	"Devise du devis":                                       "Quote currency",
+	"Durée de validité (jours)":                             "Validity period (days)",
+	"Notes (optionnel)":                                     "Notes (optional)",
+	"Conditions de paiement":                                "Payment terms",
+	"Ajout des lignes du devis (tapez 'fin' pour terminer)": "Adding quote lines (type 'end' to finish)",
+	"--- Ligne %d ---":                                      "--- Line %d ---",
+	"Description (ou 'fin' pour terminer)":                  "Description (or 'end' to finish)",
+	"Quantité":                                              "Quantity",
+	"Prix unitaire HT":                                      "Unit price excl. VAT",
+	"Taux TVA (%)":                                          "VAT rate (%)",
+	"Ligne ajoutée: %s x %s = %s HT":                        "Line added: %s x %s = %s excl. VAT",
+	"Aucune ligne ajoutée, création annulée":                "No line added, creation cancelled",
+	"--- Récapitulatif ---":                                 "--- Summary ---",
+	"Sous-total HT: %s":                                     "Subtotal excl. VAT: %s",
+	"TVA:           %s":                                     "VAT:                %s",
+	"Total TTC:     %s":                                     "Total incl. VAT:    %s",
+	"Confirmer la création du devis":                        "Confirm quote creation",
+	"Erreur lors de la création: %v":                        "Error during creation: %v",
+	"Devis %s créé avec succès (ID: %d)":                    "Quote %s created successfully (ID: %d)",
+	"Afficher les détails d'un devis":                       "Show a quote's details",
+	"Devis non trouvé: %v":                                  "Quote not found: %v",
+	"=== DEVIS %s ===":                                      "=== QUOTE %s ===",
+	"Émetteur: %s":                                          "Issuer: %s",
+	"Valide jusqu'au: %s":                                   "Valid until: %s",
+	"Statut: %s":                                            "Status: %s",
+	"Devise: %s":                                            "Currency: %s",
+	"Langue: %s":                                            "Language: %s",
+	"Langue: %s (client)":                                   "Language: %s (client)",
+	"--- Livraison ---":                                     "--- Delivery ---",
+	"--- Détail ---":                                        "--- Details ---",
+	"Qté":                                                   "Qty",
+	"PU HT":                                                 "Unit price",
+	"TVA %":                                                 "VAT %",
+	"Total HT":                                              "Total excl. VAT",
+	"Remise:        %s":                                     "Discount:           %s",
+	"TOTAL TTC:     %s":                                     "TOTAL INCL. VAT:    %s",
+	"Conditions: %s":                                        "Terms: %s",
+	"Historique des fichiers indisponible: %v":              "Generated file history unavailable: %v",
+	"--- Fichiers générés ---":                              "--- Generated files ---",
+	"sans CGV":                                              "no terms and conditions",
+	"CGV %s":                                                "T&C %s",
+	"Modifier un devis existant":                            "Edit an existing quote",
+	"Modification du devis %s":                              "Editing quote %s",
+	"Modifier le client":                                    "Change the client",
+	"Modifier le contact et les adresses":                   "Change the contact and addresses",
+	"Modifier la durée de validité":                         "Change the validity period",
+	"Modifier les notes":                                    "Edit the notes",
+	"Modifier les conditions de paiement":                   "Edit the payment terms",
+	"Modifier la devise":                                    "Change the currency",
+	"Modifier la langue du document":                        "Change the document language",
+	"Modifier les lignes du devis":                          "Edit the quote lines",
+	"Terminer les modifications":                            "Finish editing",
+	"Que souhaitez-vous modifier?":                          "What would you like to change?",
+	"Sélectionner un nouveau client":                        "Select a new client",
+	"Client modifié: %s":                                    "Client changed: %s",
+	"Contact et adresses modifiés":                          "Contact and addresses changed",
+	"Validité modifiée: %s":                                 "Validity changed: %s",
+	"Notes modifiées":                                       "Notes updated",
+	"Conditions modifiées":                                  "Terms updated",
+	"Devise modifiée: %s":                                   "Currency changed: %s",
+	"Les prix des lignes ne sont pas convertis, vérifiez-les": "Line prices are not converted, please check them",
+	"Langue du client":                                     "Client language",
+	"Langue du client (%s)":                                "Client language (%s)",
+	"Langue du document":                                   "Document language",
+	"Langue du document modifiée":                          "Document language changed",
+	"Ajouter une ligne":                                    "Add a line",
+	"Modifier une ligne existante":                         "Edit an existing line",
+	"Supprimer une ligne":                                  "Delete a line",
+	"Retour":                                               "Back",
+	"Gestion des lignes":                                   "Line management",
+	"--- Nouvelle ligne ---":                               "--- New line ---",
+	"Ligne ajoutée":                                        "Line added",
+	"Aucune ligne à modifier":                              "No line to edit",
+	"Sélectionner la ligne à modifier":                     "Select the line to edit",
+	"Ligne modifiée":                                       "Line updated",
+	"Aucune ligne à supprimer":                             "No line to delete",
+	"Sélectionner la ligne à supprimer":                    "Select the line to delete",
+	"Ligne supprimée":                                      "Line deleted",
+	"--- Récapitulatif des modifications ---":              "--- Summary of changes ---",
+	"Validité: %s":                                         "Validity: %s",
+	"Nombre de lignes: %d":                                 "Number of lines: %d",
+	"Total TTC: %s":                                        "Total incl. VAT: %s",
+	"Devis %s mis à jour avec succès":                      "Quote %s updated successfully",
+	"Modifier le statut d'un devis":                        "Change a quote's status",
+	"Statut actuel: %s. Nouveau statut":                    "Current status: %s. New status",
+	"Statut mis à jour: %s":                                "Status updated: %s",
+	"Supprimer un devis":                                   "Delete a quote",
+	"Devis à supprimer: %s - %s (%s)":                      "Quote to delete: %s - %s (%s)",
+	"Devis supprimé avec succès":                           "Quote deleted successfully",
+	"Exporter un devis en PDF":                             "Export a quote to PDF",
+	"Erreur lors de la récupération des infos société: %v": "Error retrieving the company information: %v",
+	"Impossible de générer le document: %v":                "Unable to generate the document: %v",
+	"Complétez les informations de l'entreprise avec: outbil company setup":        "Complete the company information with: outbil company setup",
+	"Erreur lors de la création du dossier %s: %v":                                 "Error creating the folder %s: %v",
+	"La page a été générée mais n'a pas pu être enregistrée dans l'historique: %v": "The page was generated but could not be recorded in the history: %v",
+	"Erreur lors de la sélection des CGV: %v":                                      "Error selecting the terms and conditions: %v",
+	"Erreur lors de la génération du PDF: %v":                                      "Error generating the PDF: %v",
+	"Le PDF a été généré mais n'a pas pu être enregistré dans l'historique: %v":    "The PDF was generated but could not be recorded in the history: %v",
+	"PDF généré: %s":                                "PDF generated: %s",
+	"CGV jointes: %s v%s (en vigueur depuis le %s)": "Terms attached: %s v%s (in force since %s)",
+	"Dupliquer un devis existant":                   "Duplicate an existing quote",
+	"Créer une copie d'un devis existant avec un nouveau numéro et une nouvelle date": "Create a copy of an existing quote with a new number and a new date",
+	"Impossible de récupérer le devis: %v":                                            "Unable to retrieve the quote: %v",
+	"Duplication du devis %s - %s":                                                    "Duplicating quote %s - %s",
+	"Voulez-vous dupliquer ce devis":                                                  "Do you want to duplicate this quote",
+	"Duplication annulée":                                                             "Duplication cancelled",
+	"Erreur lors de la duplication: %v":                                               "Error during duplication: %v",
+	"Devis dupliqué avec succès!":                                                     "Quote duplicated successfully!",
+	"Nouveau numéro: %s":                                                              "New number: %s",
+	"Montant total: %s":                                                               "Total amount: %s",
+	"Voulez-vous modifier le nouveau devis":                                           "Do you want to edit the new quote",
+	"Vous pouvez maintenant modifier le devis avec: outbil quote edit %d":             "You can now edit the quote with: outbil quote edit %d",
+	"Aucun contact":                                                                   "No contact",
+	"Contact destinataire":                                                            "Recipient contact",
+	"Adresse de facturation":                                                          "Billing address",
+	"Adresse principale du client":                                                    "Client's main address",
+	"Adresse de livraison":                                                            "Delivery address",
+	"Pas d'adresse de livraison":                                                      "No delivery address",
+	"📝 Brouillon":                                                                     "📝 Draft",
+	"📤 Envoyé":                                                                        "📤 Sent",
+	"✅ Accepté":                                                                       "✅ Accepted",
+	"❌ Refusé":                                                                        "❌ Rejected",
+	"⏰ Expiré":                                                                        "⏰ Expired",
+
+	"Exporter un devis en page HTML autonome": "Export a quote as a standalone HTML page",
+	"Exporter un devis en page HTML autonome (logo et QR code inclus), pour un corps\nd'email ou un aperçu web. La page est produite avec le modèle HTML du thème, ou le\nmodèle intégré pour les thèmes sans modèle (voir 'outbil theme template').": "Export a quote as a standalone HTML page (logo and QR code included), for an email\nbody or a web preview. The page is produced with the theme's HTML template, or the\nbuilt-in template for themes without one (see 'outbil theme template').",
+	"Générer la page dans une autre langue (fr, en) sans modifier le devis":      "Generate the page in another language (fr, en) without changing the quote",
+	"Thème dont le modèle HTML est utilisé, à la place de celui de l'entreprise": "Theme whose HTML template is used, instead of the company's",
+	"Écrire la page sur la sortie standard (corps d'email, aperçu)":              "Write the page to standard output (email body, preview)",
+	"Erreur lors de la génération de la page HTML: %v":                           "Error generating the HTML page: %v",
+	"Page HTML générée: %s":                                                      "HTML page generated: %s",
+	"impossible de charger le devis source: %w":                                  "cannot load the source quote: %w",
+	"impossible de créer le devis dupliqué: %w":                                  "cannot create the duplicated quote: %w",
+	"impossible de copier les items: %w":                                         "cannot copy the items: %w",
+	"erreur lors de la génération aléatoire: %w":                                 "random generation error: %w",
+	"impossible de générer un numéro unique après 100 tentatives":                "cannot generate a unique number after 100 attempts",
+
+	// Rapport des devis
+	"Ne retenir que les devis de ce statut (draft, sent, accepted...)": "Only include quotes with this status (draft, sent, accepted...)",
+	"Date de début (format de l'entreprise ou AAAA-MM-JJ)":             "Start date (company's format or YYYY-MM-DD)",
+	"Date de fin (format de l'entreprise ou AAAA-MM-JJ)":               "End date (company's format or YYYY-MM-DD)",
+	"Totaliser les devis dans la devise de l'entreprise":               "Total quotes in the company currency",
+	"Totaliser les devis par devise et les convertir dans la devise de référence de\nl'entreprise, avec le taux de change en vigueur à la date de chaque devis.\nLes devis sans taux connu sont signalés et exclus du total converti.": "Total quotes per currency and convert them into the company's reference currency,\nusing the exchange rate in force on each quote's date.\nQuotes without a known rate are reported and excluded from the converted total.",
+	"Statut invalide %q (%s)":                           "Invalid status %q (%s)",
+	"Erreur lors de la recherche du taux de change: %v": "Error looking up the exchange rate: %v",
+	"Devis de %s, convertis en %s":                      "Quotes from %s, converted to %s",
+	"Total TTC":                                         "Total incl. VAT",
+	"Total en %s":                                       "Total in %s",
+	"(%d sans taux)":                                    "(%d without rate)",
+	"Aucun taux de change vers %s pour %d devis: %v":    "No exchange rate to %s for %d quote(s): %v",
+	"Ajoutez un taux avec 'outbil currency rate set <DEVISE> <TAUX>'": "Add a rate with 'outbil currency rate set <CURRENCY> <RATE>'",
+
+	// Commande principale
+	"OutBil - Gestionnaire de devis professionnel": "OutBil - Professional quote manager",
+	"OutBil est un outil en ligne de commande pour créer et gérer \ndes devis professionnels avec une base de données SQLite locale.\n\nFonctionnalités principales:\n  - Gestion des clients\n  - Création interactive de devis\n  - Export PDF des devis\n  - Suivi des statuts des devis": "OutBil is a command-line tool to create and manage\nprofessional quotes with a local SQLite database.\n\nMain features:\n  - Client management\n  - Interactive quote creation\n  - PDF export of quotes\n  - Quote status tracking",
+}
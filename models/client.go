@@ -5,16 +5,67 @@ import (
 )
 
 type Client struct {
+	ID         int             `json:"id"`
+	Name       string          `json:"name"`
+	Email      string          `json:"email"`
+	Phone      string          `json:"phone"`
+	Address    string          `json:"address"`
+	City       string          `json:"city"`
+	PostalCode string          `json:"postal_code"`
+	Country    string          `json:"country"`
+	Company    string          `json:"company"`
+	TaxID      string          `json:"tax_id"`
+	SIREN      string          `json:"siren"`
+	SIRET      string          `json:"siret"`
+	Category   string          `json:"category"`
+	Currency   string          `json:"currency,omitempty"`
+	Language   string          `json:"language,omitempty"`
+	Contacts   []ClientContact `json:"contacts,omitempty"`
+	Addresses  []ClientAddress `json:"addresses,omitempty"`
+	ArchivedAt *time.Time      `json:"archived_at,omitempty"`
+	CreatedAt  time.Time       `json:"created_at"`
+	UpdatedAt  time.Time       `json:"updated_at"`
+}
+
+type ClientContact struct {
 	ID        int       `json:"id"`
+	ClientID  int       `json:"client_id"`
 	Name      string    `json:"name"`
+	Role      string    `json:"role"`
 	Email     string    `json:"email"`
 	Phone     string    `json:"phone"`
-	Address   string    `json:"address"`
-	City      string    `json:"city"`
-	PostalCode string   `json:"postal_code"`
-	Country   string    `json:"country"`
-	Company   string    `json:"company"`
-	TaxID     string    `json:"tax_id"`
 	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
-}
\ No newline at end of file
+}
+
+type ClientAddress struct {
+	ID         int       `json:"id"`
+	ClientID   int       `json:"client_id"`
+	Type       string    `json:"type"`
+	Label      string    `json:"label"`
+	Address    string    `json:"address"`
+	City       string    `json:"city"`
+	PostalCode string    `json:"postal_code"`
+	Country    string    `json:"country"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+type ClientMerge struct {
+	ID             int       `json:"id"`
+	TargetClientID int       `json:"target_client_id"`
+	SourceClientID int       `json:"source_client_id"`
+	SourceName     string    `json:"source_name"`
+	SourceData     string    `json:"source_data"`
+	QuotesMoved    int       `json:"quotes_moved"`
+	MergedAt       time.Time `json:"merged_at"`
+}
+
+const (
+	AddressBilling  = "billing"
+	AddressDelivery = "delivery"
+)
+
+// Catégories de client, utilisées pour choisir les CGV applicables
+const (
+	ClientB2B = "b2b"
+	ClientB2C = "b2c"
+)
@@ -1,17 +1,108 @@
 package models
 
+import (
+	"fmt"
+	"time"
+)
+
 type Company struct {
-	ID         int    `json:"id"`
-	Name       string `json:"name"`
-	Email      string `json:"email"`
-	Phone      string `json:"phone"`
-	Address    string `json:"address"`
-	City       string `json:"city"`
-	PostalCode string `json:"postal_code"`
-	Country    string `json:"country"`
-	TaxID      string `json:"tax_id"`
-	Logo       []byte `json:"logo,omitempty"`
-	Website    string `json:"website"`
-	Currency   string `json:"currency"`
-	TaxRate    float64 `json:"tax_rate"`
-}
\ No newline at end of file
+	ID                int     `json:"id"`
+	Name              string  `json:"name"`
+	Email             string  `json:"email"`
+	Phone             string  `json:"phone"`
+	Address           string  `json:"address"`
+	City              string  `json:"city"`
+	PostalCode        string  `json:"postal_code"`
+	Country           string  `json:"country"`
+	TaxID             string  `json:"tax_id"`
+	SIRET             string  `json:"siret"`
+	Logo              []byte  `json:"logo,omitempty"`
+	Website           string  `json:"website"`
+	Currency          string  `json:"currency"`
+	TaxRate           float64 `json:"tax_rate"`
+	LegalForm         string  `json:"legal_form"`
+	ShareCapital      float64 `json:"share_capital"`
+	RegistryType      string  `json:"registry_type"`
+	RegistryCity      string  `json:"registry_city"`
+	APECode           string  `json:"ape_code"`
+	InsurerName       string  `json:"insurer_name"`
+	InsurancePolicy   string  `json:"insurance_policy"`
+	InsuranceCoverage string  `json:"insurance_coverage"`
+	PaymentTerms      string  `json:"payment_terms"`
+	IsDefault         bool    `json:"is_default"`
+	Locale            string  `json:"locale"`
+	Theme             string  `json:"theme"` // thème des PDFs
+	PDFProtection     string  `json:"pdf_protection"`
+	PDFOwnerPassword  string  `json:"-"` // aléatoire à chaque PDF si vide
+	PDFUserPassword   string  `json:"-"` // mot de passe d'ouverture, facultatif
+
+	// Certificat PKCS#12 de signature des PDFs, facultatif ; son mot de passe
+	// est demandé à la signature
+	PDFCertificate string `json:"pdf_certificate"`
+
+	BankAccounts []BankAccount `json:"bank_accounts,omitempty"`
+}
+
+// BankAccount est un compte bancaire de l'entreprise imprimé sur les documents
+type BankAccount struct {
+	ID        int       `json:"id"`
+	CompanyID int       `json:"company_id"`
+	Label     string    `json:"label"`
+	Holder    string    `json:"holder"`
+	IBAN      string    `json:"iban"`
+	BIC       string    `json:"bic"`
+	IsDefault bool      `json:"is_default"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// DefaultBankAccount renvoie le compte par défaut, ou le premier compte enregistré
+func (c *Company) DefaultBankAccount() *BankAccount {
+	for i := range c.BankAccounts {
+		if c.BankAccounts[i].IsDefault {
+			return &c.BankAccounts[i]
+		}
+	}
+	if len(c.BankAccounts) > 0 {
+		return &c.BankAccounts[0]
+	}
+	return nil
+}
+
+// Protection des PDFs de l'entreprise, appliquée par chiffrement
+const (
+	PDFProtectionOff   = "off"   // ni chiffrement ni restriction
+	PDFProtectionPrint = "print" // lecture et impression, modification interdite
+	PDFProtectionRead  = "read"  // lecture seule, ni impression ni modification
+	PDFProtectionAll   = "all"   // aucune restriction, avec un mot de passe d'ouverture
+)
+
+var PDFProtections = []string{PDFProtectionOff, PDFProtectionPrint, PDFProtectionRead, PDFProtectionAll}
+
+// EffectivePDFProtection retourne la protection appliquée aux PDFs, celle par
+// défaut (print) si aucune n'est enregistrée
+func (c *Company) EffectivePDFProtection() string {
+	if c.PDFProtection == "" {
+		return PDFProtectionPrint
+	}
+	return c.PDFProtection
+}
+
+const (
+	RegistryRCS = "RCS"
+	RegistryRM  = "RM"
+)
+
+// NumberSequence décrit la numérotation séquentielle d'un type de document pour
+// une entreprise (ex: préfixe "AC-", 4 chiffres => AC-0001, AC-0002...)
+type NumberSequence struct {
+	CompanyID    int    `json:"company_id"`
+	DocumentType string `json:"document_type"`
+	Prefix       string `json:"prefix"`
+	NextValue    int    `json:"next_value"`
+	Padding      int    `json:"padding"`
+}
+
+// Format retourne le numéro correspondant à une valeur de la séquence
+func (s *NumberSequence) Format(value int) string {
+	return fmt.Sprintf("%s%0*d", s.Prefix, s.Padding, value)
+}
@@ -0,0 +1,15 @@
+package models
+
+import "time"
+
+// ExchangeRate indique la valeur d'une unité de Currency exprimée en BaseCurrency
+// à partir de la date d'effet. Les taux sont saisis localement, aucun service
+// externe n'est interrogé.
+type ExchangeRate struct {
+	ID            int       `json:"id"`
+	Currency      string    `json:"currency"`
+	BaseCurrency  string    `json:"base_currency"`
+	Rate          float64   `json:"rate"`
+	EffectiveFrom time.Time `json:"effective_from"`
+	CreatedAt     time.Time `json:"created_at"`
+}
@@ -0,0 +1,36 @@
+package models
+
+import "time"
+
+// TermsDocument est une version des conditions générales de vente. Une version
+// n'est jamais modifiée : une nouvelle version est ajoutée avec sa date d'effet.
+type TermsDocument struct {
+	ID             int       `json:"id"`
+	CompanyID      int       `json:"company_id"`
+	Title          string    `json:"title"`
+	Version        string    `json:"version"`
+	DocumentType   string    `json:"document_type"`
+	ClientCategory string    `json:"client_category"`
+	EffectiveFrom  time.Time `json:"effective_from"`
+	Content        []byte    `json:"-"`
+	Pages          int       `json:"pages"`
+	SHA256         string    `json:"sha256"`
+	CreatedAt      time.Time `json:"created_at"`
+}
+
+// GeneratedDocument garde la trace d'un PDF produit et des CGV qui y ont été jointes
+type GeneratedDocument struct {
+	ID           int       `json:"id"`
+	DocumentType string    `json:"document_type"`
+	DocumentID   int       `json:"document_id"`
+	TermsID      int       `json:"terms_id,omitempty"`
+	Terms        string    `json:"terms,omitempty"`
+	FilePath     string    `json:"file_path"`
+	GeneratedAt  time.Time `json:"generated_at"`
+}
+
+// Types de document auxquels s'appliquent les CGV (vide = tous)
+const (
+	DocumentQuote   = "quote"
+	DocumentInvoice = "invoice"
+)
@@ -0,0 +1,93 @@
+package render
+
+import (
+	"outbil/models"
+	"outbil/utils"
+)
+
+// Document est le contenu d'un devis prêt à imprimer : les textes sont déjà
+// traduits et les nombres formatés selon la locale du document
+type Document struct {
+	Title      string // DEVIS
+	Number     string // 2025-01-0001
+	Date       string // Date: 31/01/2025
+	ValidUntil string
+
+	CompanyName  string
+	CompanyLines []string
+	Logo         []byte // PNG
+
+	ClientTitle   string
+	ClientLines   []string
+	DeliveryTitle string
+	DeliveryLines []string // vide sans adresse de livraison
+
+	Headers map[string]string // libellé de chaque colonne du tableau
+	Items   []Item
+	Totals  []Total
+
+	// Sous-total reporté quand le tableau continue sur la page suivante
+	CarriedForward string
+	BroughtForward string
+
+	PaymentTitle string
+	PaymentLines []string
+	PaymentQR    string // contenu du QR code de virement, vide sans QR code
+
+	NotesTitle string
+	Notes      string
+	TermsTitle string
+	Terms      string
+
+	Status     string            // statut du document, qui choisit le filigrane du thème
+	Watermarks map[string]string // texte de chaque filigrane (draft, cancelled)
+
+	Footer     []string // mentions légales
+	Reference  string   // Devis 2025-01-0001
+	PageNumber string   // format de la référence, de la page et du nombre de pages
+
+	// Données brutes, pour les modèles HTML
+	Quote     *models.Quote
+	Client    *models.Client
+	Company   *models.Company
+	Locale    utils.Locale
+	Currency  string
+	Subtotal  float64 // HT avant remise
+	Discount  float64
+	TaxAmount float64
+	Total     float64 // TTC
+}
+
+// Item est une ligne du tableau
+type Item struct {
+	Description string
+	Quantity    string
+	UnitPrice   string
+	TaxRate     string
+	Amount      string
+	Net         float64 // montant HT, cumulé dans les reports
+}
+
+// Value retourne le contenu d'une colonne
+func (i Item) Value(field string) string {
+	switch field {
+	case FieldDescription:
+		return i.Description
+	case FieldQuantity:
+		return i.Quantity
+	case FieldUnitPrice:
+		return i.UnitPrice
+	case FieldTaxRate:
+		return i.TaxRate
+	case FieldAmount:
+		return i.Amount
+	}
+	return ""
+}
+
+// Total est une ligne du bloc des totaux ; Grand marque le total à payer
+type Total struct {
+	Label string
+	Value string
+	Grand bool
+}
@@ -0,0 +1,125 @@
+package render
+
+import (
+	"bytes"
+	_ "embed"
+	"encoding/base64"
+	"fmt"
+	"html/template"
+	"image"
+	"image/draw"
+	"image/png"
+	"os"
+	"outbil/i18n"
+	"strings"
+	"time"
+
+	"github.com/boombuler/barcode"
+	"github.com/boombuler/barcode/qr"
+)
+
+// defaultTemplate est le modèle HTML utilisé sans fichier "template"
+//
+//go:embed templates/default.html
+var defaultTemplate string
+
+// DefaultTemplate retourne le modèle HTML intégré, point de départ d'un
+// modèle personnalisé
+func DefaultTemplate() string {
+	return defaultTemplate
+}
+
+// htmlRenderer remplit un modèle html/template puis convertit la page en PDF
+type htmlRenderer struct {
+	theme *Theme
+}
+
+func (r *htmlRenderer) Render(doc *Document) ([]byte, error) {
+	page, err := HTML(r.theme, doc)
+	if err != nil {
+		return nil, err
+	}
+	return htmlToPDF(page, r.theme)
+}
+
+// HTML produit la page HTML autonome d'un document avec le modèle du thème, ou
+// le modèle intégré si le thème n'en désigne pas. Les images (logo, QR code)
+// sont incluses dans la page.
+func HTML(theme *Theme, doc *Document) ([]byte, error) {
+	source := defaultTemplate
+	name := "default.html"
+	if theme.Template != "" {
+		data, err := os.ReadFile(theme.filePath(theme.Template))
+		if err != nil {
+			return nil, fmt.Errorf(i18n.T("erreur lors de la lecture du modèle HTML: %w"), err)
+		}
+		source, name = string(data), theme.Template
+	}
+
+	tmpl, err := template.New(name).Funcs(templateFuncs(doc)).Parse(source)
+	if err != nil {
+		return nil, fmt.Errorf(i18n.T("modèle HTML invalide: %w"), err)
+	}
+
+	var page bytes.Buffer
+	data := struct {
+		*Document
+		Theme *Theme
+	}{doc, theme}
+	if err := tmpl.Execute(&page, data); err != nil {
+		return nil, fmt.Errorf(i18n.T("erreur dans le modèle HTML: %w"), err)
+	}
+	return page.Bytes(), nil
+}
+
+// templateFuncs retourne les fonctions des modèles : formats de la locale et
+// de la devise du document, et images intégrées à la page
+func templateFuncs(doc *Document) template.FuncMap {
+	return template.FuncMap{
+		"price": func(amount float64) string {
+			return doc.Locale.FormatPrice(amount, doc.Currency)
+		},
+		"amount": func(amount float64) string {
+			return doc.Locale.FormatAmount(amount, doc.Currency)
+		},
+		"quantity": doc.Locale.FormatQuantity,
+		"percent":  doc.Locale.FormatPercent,
+		"date": func(t time.Time) string {
+			return doc.Locale.FormatDate(t)
+		},
+		// lines découpe un texte libre (notes, conditions) en lignes
+		"lines": func(text string) []string {
+			return strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n")
+		},
+		"logo": func() template.URL {
+			if len(doc.Logo) == 0 {
+				return ""
+			}
+			return dataURL("image/png", doc.Logo)
+		},
+		"qrcode": func() (template.URL, error) {
+			if doc.PaymentQR == "" {
+				return "", nil
+			}
+			code, err := qr.Encode(doc.PaymentQR, qr.M, qr.Auto)
+			if err != nil {
+				return "", err
+			}
+			if code, err = barcode.Scale(code, 240, 240); err != nil {
+				return "", err
+			}
+			// PNG en niveaux de gris 8 bits, lisible aussi par la conversion PDF
+			gray := image.NewGray(code.Bounds())
+			draw.Draw(gray, gray.Bounds(), code, code.Bounds().Min, draw.Src)
+			var encoded bytes.Buffer
+			if err := png.Encode(&encoded, gray); err != nil {
+				return "", err
+			}
+			return dataURL("image/png", encoded.Bytes()), nil
+		},
+	}
+}
+
+func dataURL(mediaType string, data []byte) template.URL {
+	return template.URL("data:" + mediaType + ";base64," + base64.StdEncoding.EncodeToString(data))
+}
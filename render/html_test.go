@@ -0,0 +1,289 @@
+package render
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/pdfcpu/pdfcpu/pkg/api"
+)
+
+var update = flag.Bool("update", false, "réécrire les fichiers de référence de testdata")
+
+// describe résume un style calculé, en points et en mm
+func (s *style) describe() string {
+	parts := []string{fmt.Sprintf("%s %.4gpt", s.font, s.size)}
+	if s.bold {
+		parts = append(parts, "bold")
+	}
+	parts = append(parts, s.color.String(), s.align)
+	if s.preLine {
+		parts = append(parts, "pre-line")
+	}
+	if s.background != nil {
+		parts = append(parts, "background="+s.background.String())
+	}
+	if s.marginTop != 0 || s.marginBottom != 0 {
+		parts = append(parts, fmt.Sprintf("margin=%.2f/%.2f", s.marginTop, s.marginBottom))
+	}
+	if s.padding != [4]float64{} {
+		parts = append(parts, fmt.Sprintf("padding=%.2f,%.2f,%.2f,%.2f", s.padding[0], s.padding[1], s.padding[2], s.padding[3]))
+	}
+	for i, name := range []string{"top", "right", "bottom", "left"} {
+		if b := s.borders[i]; b != nil {
+			parts = append(parts, fmt.Sprintf("border-%s=%.2f%s", name, b.width, b.color))
+		}
+	}
+	if s.width != "" {
+		parts = append(parts, "width="+s.width)
+	}
+	return strings.Join(parts, " ")
+}
+
+func TestHTMLCascade(t *testing.T) {
+	tests := []struct {
+		name string
+		css  string
+		body string
+		want string
+	}{
+		{
+			name: "ordre du fichier, sans spécificité",
+			css:  `.a { color: #ff0000 } div { color: #00ff00 } .b { color: #0000ff }`,
+			body: `<div class="a">x</div><div class="a b">y</div>`,
+			want: `
+div.a: helvetica 10pt #00ff00 left
+div.a.b: helvetica 10pt #0000ff left`,
+		},
+		{
+			name: "héritage du texte seulement",
+			css: `.box { font-family: courier, monospace; font-size: 12pt; font-weight: bold; color: #123456; text-align: right;
+				white-space: pre-line; background: #eeeeee; margin: 2mm 0 3mm; padding: 1mm 2mm; border-bottom: 1px solid #cccccc; width: 50% }`,
+			body: `<div class="box"><div>x</div></div>`,
+			want: `
+div.box: courier 12pt bold #123456 right pre-line background=#eeeeee margin=2.00/3.00 padding=1.00,2.00,1.00,2.00 border-bottom=0.26#cccccc width=50%
+  div: courier 12pt bold #123456 right pre-line`,
+		},
+		{
+			name: "styles par défaut et unités relatives",
+			css:  `div { font-size: 12pt } h1 { color: #1f4e79; margin: 4mm 0 1mm 0 } .small { font-size: 0.5em; padding: 2px }`,
+			body: `<div><h1>T</h1><h1 class="small">t</h1></div>`,
+			want: `
+div: helvetica 12pt #212529 left
+  h1: helvetica 24pt bold #1f4e79 left margin=4.00/1.00
+  h1.small: helvetica 12pt bold #1f4e79 left margin=4.00/1.00 padding=0.53,0.53,0.53,0.53`,
+		},
+		{
+			name: "sélecteurs de descendants",
+			css:  `.totals td { text-align: right } .totals .grand td { font-weight: bold; border-top: 1px solid #c8cdd2 } tr td { color: #000000 }`,
+			body: `<table class="totals"><tr><td>a</td></tr><tr class="grand"><td>b</td></tr></table><table><tr class="grand"><td>c</td></tr></table>`,
+			want: `
+table.totals: helvetica 10pt #212529 left
+  tr: helvetica 10pt #212529 left
+    td: helvetica 10pt #000000 right padding=0.26,0.26,0.26,0.26
+  tr.grand: helvetica 10pt #212529 left
+    td: helvetica 10pt bold #000000 right padding=0.26,0.26,0.26,0.26 border-top=0.26#c8cdd2
+table: helvetica 10pt #212529 left
+  tr.grand: helvetica 10pt #212529 left
+    td: helvetica 10pt #000000 left padding=0.26,0.26,0.26,0.26`,
+		},
+		{
+			name: "attributs, feuille de style puis attribut style",
+			css:  `th { color: #111111 } .n { text-align: right } td { padding: 2mm }`,
+			body: `<table><tr><th align="left" width="30%">a</th><th class="n" align="left">b</th><td style="color: #222222; padding: 0" width="10mm">c</td></tr></table>`,
+			want: `
+table: helvetica 10pt #212529 left
+  tr: helvetica 10pt #212529 left
+    th: helvetica 10pt bold #111111 left padding=0.26,0.26,0.26,0.26 width=30%
+    th.n: helvetica 10pt bold #111111 right padding=0.26,0.26,0.26,0.26
+    td: helvetica 10pt #222222 left width=10mm`,
+		},
+		{
+			name: "règles et valeurs non reconnues ignorées",
+			css: `/* div { color: #ff0000 } */ @media print { div { color: #ff0000 } } div > span, td:first-child, #id { color: #ff0000 }
+				div { color: red; font-family: fantasy; font-size: large; border-top: none; margin: auto 1mm; font-weight: 700 !important }`,
+			body: `<div><span>x</span></div>`,
+			want: `
+div: helvetica 10pt bold #212529 left
+  span: helvetica 10pt bold #212529 left`,
+		},
+	}
+
+	theme, _ := Builtin("html")
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			page := "<html><head><style>" + test.css + "</style></head><body>" + test.body + "</body></html>"
+			root, rules, err := parseHTML(strings.NewReader(page))
+			if err != nil {
+				t.Fatal(err)
+			}
+			c := &htmlPDF{theme: theme, rules: rules}
+			base := &style{font: "helvetica", size: theme.FontSize, color: theme.Colors.Text, align: "left", lineHeight: 1.3}
+
+			var b strings.Builder
+			var walk func(n *node, parent *style, depth int)
+			walk = func(n *node, parent *style, depth int) {
+				for _, child := range n.children {
+					if child.tag == "" {
+						continue
+					}
+					s := c.computeStyle(child, parent)
+					name := child.tag
+					for _, class := range strings.Fields(child.attr("class")) {
+						name += "." + class
+					}
+					fmt.Fprintf(&b, "\n%s%s: %s", strings.Repeat("  ", depth), name, s.describe())
+					walk(child, s, depth+1)
+				}
+			}
+			walk(root.find("body"), base, 0)
+
+			if got := b.String(); got != test.want {
+				t.Errorf("styles:%s\nattendu:%s", got, test.want)
+			}
+		})
+	}
+}
+
+// describeFragment décrit un fragment mis en page, une ligne par élément
+func describeFragment(c *htmlPDF, f fragment, x, y float64, indent string) string {
+	switch f := f.(type) {
+	case *textLine:
+		var words []string
+		for _, w := range f.words {
+			words = append(words, w.text)
+		}
+		return fmt.Sprintf("%stext x=%.2f y=%.2f h=%.2f %q\n", indent,
+			x+alignOffset(f.align, f.width, f.used(c)), y, f.height(), strings.Join(words, " "))
+	case *picture:
+		return fmt.Sprintf("%simage x=%.2f y=%.2f w=%.2f h=%.2f\n", indent, x+alignOffset(f.align, f.available, f.w), y, f.w, f.h)
+	case *tableRow:
+		out := fmt.Sprintf("%srow y=%.2f h=%.2f\n", indent, y, f.h)
+		for _, cell := range f.cells {
+			out += describeFragment(c, &cell.box, x+cell.x, y, indent+"  ")
+		}
+		return out
+	case *box:
+		out := fmt.Sprintf("%sbox x=%.2f y=%.2f w=%.2f h=%.2f\n", indent, x, y, f.width, f.height())
+		cy := y + f.style.padding[0]
+		for _, child := range f.children {
+			out += describeFragment(c, child, x+f.style.padding[3], cy, indent+"  ")
+			cy += child.height()
+		}
+		return out
+	case space:
+		return ""
+	}
+	return fmt.Sprintf("%s%T\n", indent, f)
+}
+
+// testDocument est un devis de deux pages, avec logo, QR code et textes libres
+func testDocument() *Document {
+	logo := image.NewRGBA(image.Rect(0, 0, 40, 20))
+	for x := 0; x < 40; x++ {
+		for y := 0; y < 20; y++ {
+			logo.Set(x, y, color.RGBA{31, 78, 121, 255})
+		}
+	}
+	var encoded bytes.Buffer
+	png.Encode(&encoded, logo)
+
+	doc := &Document{
+		Title:        "DEVIS",
+		Number:       "2025-10-0042",
+		Date:         "Date: 15/10/2025",
+		ValidUntil:   "Valable jusqu'au: 14/11/2025",
+		CompanyName:  "Acme Conseil",
+		CompanyLines: []string{"12 rue de la Paix", "75002 Paris", "contact@acme.fr"},
+		Logo:         encoded.Bytes(),
+		ClientTitle:  "CLIENT",
+		ClientLines:  []string{"Jean Dupont", "Dupont SARL", "8 avenue Foch", "69006 Lyon"},
+		Headers: map[string]string{
+			FieldDescription: "Description", FieldQuantity: "Qté", FieldUnitPrice: "Prix unitaire HT",
+			FieldTaxRate: "TVA", FieldAmount: "Total HT",
+		},
+		Totals: []Total{
+			{Label: "Sous-total HT", Value: "12 300,00 €"},
+			{Label: "TVA", Value: "2 460,00 €"},
+			{Label: "Total TTC", Value: "14 760,00 €", Grand: true},
+		},
+		PaymentTitle: "RÈGLEMENT",
+		PaymentLines: []string{"Titulaire: Acme Conseil", "IBAN: FR14 2004 1010 0505 0001 3M02 606"},
+		PaymentQR:    "BCD\n002\n1\nSCT\n\nAcme Conseil\nFR1420041010050500013M02606\nEUR14760.00\n\n\nDevis 2025-10-0042",
+		NotesTitle:   "NOTES",
+		Notes:        "Acompte de 30 % à la commande.\nSolde à la livraison.",
+		Footer:       []string{"Acme Conseil - SAS au capital de 10 000 € - SIRET 732 829 320 00074"},
+	}
+	for i := 1; i <= 24; i++ {
+		description := fmt.Sprintf("Prestation de conseil n°%d", i)
+		if i%8 == 0 {
+			description += " : audit de l'organisation, entretiens avec les équipes et rédaction d'un rapport de recommandations détaillé"
+		}
+		doc.Items = append(doc.Items, Item{
+			Description: description, Quantity: "1", UnitPrice: "512,50 €", TaxRate: "20 %", Amount: "512,50 €",
+		})
+	}
+	return doc
+}
+
+func TestHTMLLayout(t *testing.T) {
+	theme, _ := Builtin("html")
+	page, err := HTML(theme, testDocument())
+	if err != nil {
+		t.Fatal(err)
+	}
+	c, content, err := layoutHTML(page, theme)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "zone imprimable x=%.2f y=%.2f-%.2f\n", c.left, c.top, c.bottom)
+	pages := 0
+	for _, p := range c.paginate(content) {
+		if p.page != pages {
+			pages = p.page
+			fmt.Fprintf(&b, "--- page %d\n", pages)
+		}
+		b.WriteString(describeFragment(c, p.fragment, p.x, p.y, ""))
+	}
+	b.WriteString("--- pied de page\n")
+	y := c.bottom
+	for _, f := range c.footer {
+		b.WriteString(describeFragment(c, f, c.left, y, ""))
+		y += f.height()
+	}
+
+	golden := filepath.Join("testdata", "html_layout.golden")
+	if *update {
+		if err := os.WriteFile(golden, []byte(b.String()), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	want, err := os.ReadFile(golden)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := b.String(); got != string(want) {
+		t.Errorf("mise en page différente de %s (go test ./render -update pour la réécrire):\n%s", golden, got)
+	}
+
+	pdf, err := htmlToPDF(page, theme)
+	if err != nil {
+		t.Fatal(err)
+	}
+	count, err := api.PageCount(bytes.NewReader(pdf), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if count != pages {
+		t.Errorf("%d pages, attendu %d", count, pages)
+	}
+}
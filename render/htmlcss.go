@@ -0,0 +1,426 @@
+package render
+
+import (
+	"encoding/xml"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// node est un élément (ou un texte si tag est vide) de la page HTML
+type node struct {
+	tag      string
+	attrs    map[string]string
+	text     string
+	parent   *node
+	children []*node
+}
+
+func (n *node) attr(name string) string {
+	return n.attrs[name]
+}
+
+func (n *node) hasClass(class string) bool {
+	for _, c := range strings.Fields(n.attrs["class"]) {
+		if c == class {
+			return true
+		}
+	}
+	return false
+}
+
+// textContent retourne le texte d'un élément et de ses descendants
+func (n *node) textContent() string {
+	if n.tag == "" {
+		return n.text
+	}
+	var b strings.Builder
+	for _, child := range n.children {
+		b.WriteString(child.textContent())
+	}
+	return b.String()
+}
+
+// find retourne le premier descendant d'une balise
+func (n *node) find(tag string) *node {
+	for _, child := range n.children {
+		if child.tag == tag {
+			return child
+		}
+		if found := child.find(tag); found != nil {
+			return found
+		}
+	}
+	return nil
+}
+
+// parseHTML lit une page HTML avec le décodeur XML en mode tolérant : les
+// balises orphelines (<meta>, <img>…) et les entités HTML sont acceptées. Les
+// feuilles de style <style> sont renvoyées à part.
+func parseHTML(r io.Reader) (*node, []cssRule, error) {
+	decoder := xml.NewDecoder(r)
+	decoder.Strict = false
+	decoder.AutoClose = xml.HTMLAutoClose
+	decoder.Entity = xml.HTMLEntity
+
+	root := &node{tag: "#document"}
+	current := root
+	var rules []cssRule
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+
+		switch t := token.(type) {
+		case xml.StartElement:
+			n := &node{tag: strings.ToLower(t.Name.Local), attrs: make(map[string]string), parent: current}
+			for _, a := range t.Attr {
+				n.attrs[strings.ToLower(a.Name.Local)] = a.Value
+			}
+			current.children = append(current.children, n)
+			current = n
+		case xml.EndElement:
+			// Fermer l'élément correspondant, même si des balises internes
+			// n'ont pas été fermées
+			tag := strings.ToLower(t.Name.Local)
+			for n := current; n != root; n = n.parent {
+				if n.tag == tag {
+					current = n.parent
+					break
+				}
+			}
+		case xml.CharData:
+			if current.tag == "style" {
+				rules = append(rules, parseCSS(string(t))...)
+				continue
+			}
+			current.children = append(current.children, &node{text: string(t), parent: current})
+		}
+	}
+	return root, rules, nil
+}
+
+// cssRule associe des déclarations à un sélecteur. Seuls les sélecteurs
+// simples (balise, .classe) et leurs descendants sont reconnus.
+type cssRule struct {
+	selector []cssSelector // ancêtres puis élément visé
+	decls    [][2]string
+}
+
+type cssSelector struct {
+	tag     string
+	classes []string
+}
+
+func (s cssSelector) matches(n *node) bool {
+	if s.tag != "" && s.tag != "*" && s.tag != n.tag {
+		return false
+	}
+	for _, class := range s.classes {
+		if !n.hasClass(class) {
+			return false
+		}
+	}
+	return true
+}
+
+func (r cssRule) matches(n *node) bool {
+	last := len(r.selector) - 1
+	if !r.selector[last].matches(n) {
+		return false
+	}
+	// Les ancêtres doivent apparaître dans l'ordre en remontant l'arbre
+	ancestor := n.parent
+	for i := last - 1; i >= 0; i-- {
+		for ancestor != nil && !r.selector[i].matches(ancestor) {
+			ancestor = ancestor.parent
+		}
+		if ancestor == nil {
+			return false
+		}
+		ancestor = ancestor.parent
+	}
+	return true
+}
+
+// parseCSS lit une feuille de style ; les commentaires, les règles @media,
+// @page… et les sélecteurs non reconnus sont ignorés
+func parseCSS(css string) []cssRule {
+	for {
+		start := strings.Index(css, "/*")
+		if start < 0 {
+			break
+		}
+		end := strings.Index(css[start+2:], "*/")
+		if end < 0 {
+			css = css[:start]
+			break
+		}
+		css = css[:start] + css[start+2+end+2:]
+	}
+
+	var rules []cssRule
+	for {
+		open := strings.IndexByte(css, '{')
+		if open < 0 {
+			break
+		}
+		prelude := strings.TrimSpace(css[:open])
+
+		// Trouver l'accolade fermante correspondante
+		depth, end := 0, -1
+		for i := open; i < len(css); i++ {
+			if css[i] == '{' {
+				depth++
+			} else if css[i] == '}' {
+				depth--
+				if depth == 0 {
+					end = i
+					break
+				}
+			}
+		}
+		if end < 0 {
+			break
+		}
+		body := css[open+1 : end]
+		css = css[end+1:]
+
+		if strings.HasPrefix(prelude, "@") {
+			continue
+		}
+		decls := parseDeclarations(body)
+		for _, selector := range strings.Split(prelude, ",") {
+			if parsed, ok := parseSelector(selector); ok {
+				rules = append(rules, cssRule{selector: parsed, decls: decls})
+			}
+		}
+	}
+	return rules
+}
+
+func parseSelector(selector string) ([]cssSelector, bool) {
+	var parts []cssSelector
+	for _, compound := range strings.Fields(selector) {
+		if strings.ContainsAny(compound, ">+~:[#") {
+			return nil, false
+		}
+		tag, classes, _ := strings.Cut(compound, ".")
+		s := cssSelector{tag: strings.ToLower(tag)}
+		if classes != "" {
+			s.classes = strings.Split(classes, ".")
+		}
+		parts = append(parts, s)
+	}
+	return parts, len(parts) > 0
+}
+
+func parseDeclarations(body string) [][2]string {
+	var decls [][2]string
+	for _, decl := range strings.Split(body, ";") {
+		name, value, ok := strings.Cut(decl, ":")
+		if !ok {
+			continue
+		}
+		value = strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(value), "!important"))
+		decls = append(decls, [2]string{strings.ToLower(strings.TrimSpace(name)), value})
+	}
+	return decls
+}
+
+// borderSide est un côté de bordure d'une boîte
+type borderSide struct {
+	width float64 // en mm
+	color Color
+}
+
+// style est le style calculé d'un élément. Les propriétés de texte sont
+// héritées, les marges, fonds et bordures ne le sont pas.
+type style struct {
+	font       string
+	bold       bool
+	size       float64 // en points
+	color      Color
+	align      string
+	lineHeight float64 // multiple de la taille
+	preLine    bool    // conserver les retours à la ligne
+
+	background   *Color
+	marginTop    float64 // en mm
+	marginBottom float64
+	padding      [4]float64 // haut, droite, bas, gauche
+	borders      [4]*borderSide
+	width        string // valeur CSS brute (30%, 40mm…)
+}
+
+// inherit retourne le style hérité par les enfants
+func (s *style) inherit() *style {
+	return &style{
+		font:       s.font,
+		bold:       s.bold,
+		size:       s.size,
+		color:      s.color,
+		align:      s.align,
+		lineHeight: s.lineHeight,
+		preLine:    s.preLine,
+	}
+}
+
+// lineHeightMM retourne la hauteur d'une ligne de texte en mm
+func (s *style) lineHeightMM() float64 {
+	return s.size * ptToMM * s.lineHeight
+}
+
+// Styles par défaut des balises, ceux des navigateurs pour que l'aperçu HTML
+// et le PDF se ressemblent
+var defaultStyles = map[string]string{
+	"h1":     "font-size: 2em; font-weight: bold; margin: 0.67em 0",
+	"th":     "font-weight: bold; text-align: center; padding: 1px",
+	"td":     "padding: 1px",
+	"b":      "font-weight: bold",
+	"strong": "font-weight: bold",
+}
+
+const (
+	ptToMM = 0.3528
+	pxToMM = 0.2646
+)
+
+// applyDeclaration applique une propriété CSS ; les propriétés inconnues sont
+// ignorées comme le ferait un navigateur
+func (s *style) applyDeclaration(name, value string, fonts func(string) string) {
+	switch name {
+	case "color":
+		if c, ok := parseCSSColor(value); ok {
+			s.color = c
+		}
+	case "background", "background-color":
+		for _, part := range strings.Fields(value) {
+			if c, ok := parseCSSColor(part); ok {
+				s.background = &c
+			}
+		}
+	case "font-family":
+		for _, family := range strings.Split(value, ",") {
+			if font := fonts(strings.Trim(strings.TrimSpace(family), `"'`)); font != "" {
+				s.font = font
+				break
+			}
+		}
+	case "font-size":
+		if mm, ok := parseLength(value, s.size); ok {
+			s.size = mm / ptToMM
+		}
+	case "font-weight":
+		weight, _ := strconv.Atoi(value)
+		s.bold = value == "bold" || value == "bolder" || weight >= 600
+	case "text-align":
+		s.align = value
+	case "white-space":
+		s.preLine = strings.HasPrefix(value, "pre")
+	case "margin":
+		if sides, ok := parseBoxLengths(value, s.size); ok {
+			s.marginTop, s.marginBottom = sides[0], sides[2]
+		}
+	case "margin-top":
+		s.marginTop, _ = parseLength(value, s.size)
+	case "margin-bottom":
+		s.marginBottom, _ = parseLength(value, s.size)
+	case "padding":
+		if sides, ok := parseBoxLengths(value, s.size); ok {
+			s.padding = sides
+		}
+	case "border":
+		b := parseBorder(value, s.size)
+		s.borders = [4]*borderSide{b, b, b, b}
+	case "border-top":
+		s.borders[0] = parseBorder(value, s.size)
+	case "border-bottom":
+		s.borders[2] = parseBorder(value, s.size)
+	case "width":
+		s.width = value
+	}
+}
+
+// parseLength convertit une longueur CSS en mm ; un nombre seul est en pixels
+func parseLength(value string, fontSize float64) (float64, bool) {
+	value = strings.TrimSpace(strings.ToLower(value))
+	units := []struct {
+		suffix string
+		factor float64
+	}{
+		{"mm", 1}, {"cm", 10}, {"pt", ptToMM}, {"px", pxToMM}, {"em", fontSize * ptToMM},
+	}
+	factor := pxToMM
+	for _, unit := range units {
+		if strings.HasSuffix(value, unit.suffix) {
+			value, factor = strings.TrimSuffix(value, unit.suffix), unit.factor
+			break
+		}
+	}
+	number, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return 0, false
+	}
+	return number * factor, true
+}
+
+// parseBoxLengths lit les 1 à 4 valeurs de margin ou padding (haut, droite,
+// bas, gauche)
+func parseBoxLengths(value string, fontSize float64) ([4]float64, bool) {
+	var values []float64
+	for _, part := range strings.Fields(value) {
+		if part == "auto" {
+			values = append(values, 0)
+			continue
+		}
+		length, ok := parseLength(part, fontSize)
+		if !ok {
+			return [4]float64{}, false
+		}
+		values = append(values, length)
+	}
+	switch len(values) {
+	case 1:
+		return [4]float64{values[0], values[0], values[0], values[0]}, true
+	case 2:
+		return [4]float64{values[0], values[1], values[0], values[1]}, true
+	case 3:
+		return [4]float64{values[0], values[1], values[2], values[1]}, true
+	case 4:
+		return [4]float64{values[0], values[1], values[2], values[3]}, true
+	}
+	return [4]float64{}, false
+}
+
+// parseBorder lit une bordure CSS ("1px solid #ccc") ; none la supprime
+func parseBorder(value string, fontSize float64) *borderSide {
+	b := &borderSide{width: pxToMM}
+	for _, part := range strings.Fields(value) {
+		if part == "none" || part == "hidden" {
+			return nil
+		}
+		if width, ok := parseLength(part, fontSize); ok {
+			b.width = width
+		} else if c, ok := parseCSSColor(part); ok {
+			b.color = c
+		}
+	}
+	if b.width <= 0 {
+		return nil
+	}
+	return b
+}
+
+// parseCSSColor lit une couleur #RRGGBB ou #RGB, le format des couleurs du thème
+func parseCSSColor(value string) (Color, bool) {
+	if !strings.HasPrefix(value, "#") {
+		return Color{}, false
+	}
+	c, err := ParseColor(value)
+	return c, err == nil
+}
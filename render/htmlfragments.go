@@ -0,0 +1,244 @@
+package render
+
+import (
+	"github.com/jung-kurt/gofpdf"
+)
+
+// fragment est un morceau de page mis en page : il connaît sa hauteur et se
+// dessine à une position donnée
+type fragment interface {
+	height() float64
+	draw(c *htmlPDF, x, y float64)
+}
+
+// space est une marge verticale, supprimée en haut de page
+type space float64
+
+func (s space) height() float64               { return float64(s) }
+func (s space) draw(c *htmlPDF, x, y float64) {}
+
+// picture est une image alignée dans la largeur disponible
+type picture struct {
+	name      string
+	w, h      float64
+	available float64
+	align     string
+}
+
+func (p *picture) height() float64 { return p.h }
+
+func (p *picture) draw(c *htmlPDF, x, y float64) {
+	c.pdf.ImageOptions(p.name, x+alignOffset(p.align, p.available, p.w), y, p.w, p.h, false, gofpdf.ImageOptions{}, 0, "")
+}
+
+// textLine est une ligne de texte, éventuellement de plusieurs styles
+type textLine struct {
+	words     []word
+	width     float64
+	align     string
+	minHeight float64
+}
+
+func (l *textLine) height() float64 {
+	h := l.minHeight
+	for _, w := range l.words {
+		if wh := w.style.lineHeightMM(); wh > h {
+			h = wh
+		}
+	}
+	return h
+}
+
+// used retourne la largeur occupée par les mots de la ligne
+func (l *textLine) used(c *htmlPDF) float64 {
+	used := 0.0
+	for _, w := range l.words {
+		if w.spaced {
+			used += c.measure(w.style, " ")
+		}
+		used += w.width
+	}
+	return used
+}
+
+func (l *textLine) draw(c *htmlPDF, x, y float64) {
+	h := l.height()
+	x += alignOffset(l.align, l.width, l.used(c))
+
+	// Les mots consécutifs du même style sont imprimés d'un seul tenant
+	for i := 0; i < len(l.words); {
+		st := l.words[i].style
+		if l.words[i].spaced {
+			x += c.measure(st, " ")
+		}
+		text := l.words[i].text
+		j := i + 1
+		for ; j < len(l.words) && l.words[j].style == st; j++ {
+			if l.words[j].spaced {
+				text += " "
+			}
+			text += l.words[j].text
+		}
+		width := c.measure(st, text)
+		c.pdf.SetXY(x, y)
+		c.pdf.CellFormat(width, h, c.encode(st, text), "", 0, "L", false, 0, "")
+		x += width
+		i = j
+	}
+}
+
+// box est un bloc avec ses marges intérieures, son fond et ses bordures
+type box struct {
+	style    *style
+	width    float64
+	children []fragment
+}
+
+func (b *box) height() float64 {
+	return b.style.padding[0] + fragmentsHeight(b.children) + b.style.padding[2]
+}
+
+func (b *box) draw(c *htmlPDF, x, y float64) {
+	b.drawIn(c, x, y, b.height())
+}
+
+// drawIn dessine le bloc sur une hauteur imposée (cellule de tableau)
+func (b *box) drawIn(c *htmlPDF, x, y, h float64) {
+	s := b.style
+	if s.background != nil {
+		c.pdf.SetFillColor(s.background.Red, s.background.Green, s.background.Blue)
+		c.pdf.Rect(x, y, b.width, h, "F")
+	}
+
+	cy := y + s.padding[0]
+	for _, child := range b.children {
+		child.draw(c, x+s.padding[3], cy)
+		cy += child.height()
+	}
+
+	sides := [4][4]float64{
+		{x, y, x + b.width, y},
+		{x + b.width, y, x + b.width, y + h},
+		{x, y + h, x + b.width, y + h},
+		{x, y, x, y + h},
+	}
+	for i, side := range s.borders {
+		if side == nil {
+			continue
+		}
+		c.pdf.SetDrawColor(side.color.Red, side.color.Green, side.color.Blue)
+		c.pdf.SetLineWidth(side.width)
+		c.pdf.Line(sides[i][0], sides[i][1], sides[i][2], sides[i][3])
+	}
+}
+
+// decorated indique si le bloc a un fond ou une bordure, et ne peut donc pas
+// être coupé entre deux pages
+func (b *box) decorated() bool {
+	if b.style.background != nil {
+		return true
+	}
+	for _, side := range b.style.borders {
+		if side != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// table est un tableau ; ses lignes d'en-tête sont répétées à chaque page
+type table struct {
+	header []*tableRow
+	rows   []*tableRow
+}
+
+func (t *table) height() float64 {
+	h := 0.0
+	for _, row := range append(append([]*tableRow(nil), t.header...), t.rows...) {
+		h += row.height()
+	}
+	return h
+}
+
+func (t *table) draw(c *htmlPDF, x, y float64) {
+	for _, row := range append(append([]*tableRow(nil), t.header...), t.rows...) {
+		row.draw(c, x, y)
+		y += row.height()
+	}
+}
+
+// tableRow est une ligne de tableau ; ses cellules, alignées en haut, prennent
+// la hauteur de la plus haute
+type tableRow struct {
+	cells []*tableCell
+	h     float64
+}
+
+func (r *tableRow) height() float64 { return r.h }
+
+func (r *tableRow) draw(c *htmlPDF, x, y float64) {
+	for _, cell := range r.cells {
+		cell.drawIn(c, x+cell.x, y, r.h)
+	}
+}
+
+type tableCell struct {
+	box
+	x float64 // position dans le tableau
+}
+
+// placed est un fragment prêt à être imprimé dans le flux des pages
+type placed struct {
+	fragment fragment
+	dx       float64    // retrait depuis la marge gauche
+	header   []fragment // en-têtes à répéter si le fragment change de page
+}
+
+// flatten découpe les fragments aux endroits où une page peut se terminer :
+// entre les lignes d'un paragraphe, entre les lignes d'un tableau et dans les
+// blocs sans fond ni bordure
+func flatten(frags []fragment, dx float64, header []fragment) []placed {
+	var result []placed
+	for _, f := range frags {
+		switch f := f.(type) {
+		case *box:
+			if f.decorated() {
+				result = append(result, placed{f, dx, header})
+				continue
+			}
+			result = append(result, placed{space(f.style.padding[0]), dx, header})
+			result = append(result, flatten(f.children, dx+f.style.padding[3], header)...)
+			result = append(result, placed{space(f.style.padding[2]), dx, header})
+		case *table:
+			var rowHeader []fragment
+			for _, row := range f.header {
+				result = append(result, placed{row, dx, header})
+				rowHeader = append(rowHeader, row)
+			}
+			for _, row := range f.rows {
+				result = append(result, placed{row, dx, rowHeader})
+			}
+		default:
+			result = append(result, placed{f, dx, header})
+		}
+	}
+	return result
+}
+
+func fragmentsHeight(frags []fragment) float64 {
+	h := 0.0
+	for _, f := range frags {
+		h += f.height()
+	}
+	return h
+}
+
+func alignOffset(align string, available, used float64) float64 {
+	switch align {
+	case "center":
+		return (available - used) / 2
+	case "right", "end":
+		return available - used
+	}
+	return 0
+}
@@ -0,0 +1,534 @@
+package render
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"outbil/i18n"
+	"strconv"
+	"strings"
+
+	"github.com/jung-kurt/gofpdf"
+)
+
+// htmlToPDF convertit une page HTML en PDF, sans navigateur ni outil externe.
+// Le sous-ensemble reconnu est celui du modèle intégré : blocs, titres,
+// tableaux (en-têtes <thead> répétés à chaque page), images intégrées et un
+// <footer> imprimé en bas de chaque page.
+func htmlToPDF(page []byte, theme *Theme) ([]byte, error) {
+	c, content, err := layoutHTML(page, theme)
+	if err != nil {
+		return nil, err
+	}
+
+	if c.footer != nil {
+		c.pdf.SetFooterFunc(func() {
+			y := c.bottom
+			for _, f := range c.footer {
+				f.draw(c, c.left, y)
+				y += f.height()
+			}
+		})
+	}
+
+	c.pdf.AddPage()
+	for _, p := range c.paginate(content) {
+		for c.pdf.PageNo() < p.page {
+			c.pdf.AddPage()
+		}
+		p.fragment.draw(c, p.x, p.y)
+	}
+
+	var out bytes.Buffer
+	if err := c.pdf.Output(&out); err != nil {
+		return nil, fmt.Errorf(i18n.T("erreur lors de la génération du PDF: %w"), err)
+	}
+	return out.Bytes(), nil
+}
+
+// htmlPDF porte l'état de la conversion
+type htmlPDF struct {
+	pdf       *gofpdf.Fpdf
+	theme     *Theme
+	rules     []cssRule
+	translate func(string) string // UTF-8 vers cp1252 pour les polices standard
+	footer    []fragment
+	images    int
+
+	left, top float64 // coin de la zone imprimable
+	bottom    float64 // bas de la zone imprimable, au-dessus du pied de page
+}
+
+// layoutHTML lit une page HTML et met en page son contenu dans la largeur
+// d'une page A4 ; le <footer> est mis en page à part et réduit la zone utile
+func layoutHTML(page []byte, theme *Theme) (*htmlPDF, []fragment, error) {
+	root, rules, err := parseHTML(bytes.NewReader(page))
+	if err != nil {
+		return nil, nil, fmt.Errorf(i18n.T("page HTML illisible: %w"), err)
+	}
+
+	pdf := gofpdf.New("P", "mm", "A4", "")
+	pdf.SetMargins(theme.Margin, 15, theme.Margin)
+	pdf.SetAutoPageBreak(false, 15)
+	pdf.SetCellMargin(0)
+
+	c := &htmlPDF{pdf: pdf, theme: theme, rules: rules, translate: pdf.UnicodeTranslatorFromDescriptor("")}
+	if theme.customFont() {
+		regular, bold, err := theme.fontData()
+		if err != nil {
+			return nil, nil, err
+		}
+		pdf.AddUTF8FontFromBytes(theme.Font, "", regular)
+		pdf.AddUTF8FontFromBytes(theme.Font, "B", bold)
+	}
+	if title := root.find("title"); title != nil {
+		pdf.SetTitle(strings.TrimSpace(title.textContent()), true)
+	}
+
+	body := root.find("body")
+	if body == nil {
+		body = root
+	}
+	base := &style{
+		font:       c.fontFamily(theme.Font),
+		size:       theme.FontSize,
+		color:      theme.Colors.Text,
+		align:      "left",
+		lineHeight: 1.3,
+	}
+	pageWidth, pageHeight := pdf.GetPageSize()
+	left, top, right, bottom := pdf.GetMargins()
+	content := c.layoutChildren(body, c.computeStyle(body, base), pageWidth-left-right)
+
+	c.left, c.top = left, top
+	c.bottom = pageHeight - bottom - fragmentsHeight(c.footer)
+	return c, content, nil
+}
+
+// position est un fragment placé sur une page
+type position struct {
+	page     int
+	x, y     float64
+	fragment fragment
+}
+
+// paginate répartit le contenu sur les pages : un fragment qui dépasse le bas
+// de la zone imprimable passe à la page suivante, précédé des en-têtes de son
+// tableau. Les marges ne sont pas imprimées en haut de page.
+func (c *htmlPDF) paginate(content []fragment) []position {
+	var positions []position
+	page, y := 1, c.top
+	for _, p := range flatten(content, 0, nil) {
+		h := p.fragment.height()
+		_, isSpace := p.fragment.(space)
+		if isSpace && y == c.top {
+			continue
+		}
+		if y+h > c.bottom && y > c.top {
+			page, y = page+1, c.top
+			for _, header := range p.header {
+				positions = append(positions, position{page, c.left + p.dx, y, header})
+				y += header.height()
+			}
+			if isSpace {
+				continue
+			}
+		}
+		if !isSpace {
+			positions = append(positions, position{page, c.left + p.dx, y, p.fragment})
+		}
+		y += h
+	}
+	return positions
+}
+
+// fontFamily retourne la police gofpdf d'une famille CSS, vide si inconnue
+func (c *htmlPDF) fontFamily(family string) string {
+	if c.theme.customFont() && family == c.theme.Font {
+		return family
+	}
+	var font string
+	switch strings.ToLower(family) {
+	case "helvetica", "arial", "sans-serif":
+		font = "helvetica"
+	case "courier", "courier new", "monospace":
+		font = "courier"
+	}
+	// Un PDF/A n'utilise que la police incorporée du thème
+	if font != "" && c.theme.archive {
+		return c.theme.Font
+	}
+	return font
+}
+
+// computeStyle calcule le style d'un élément : héritage, styles par défaut de
+// la balise, attributs align et width, feuilles de style dans l'ordre du
+// fichier puis attribut style
+func (c *htmlPDF) computeStyle(n *node, parent *style) *style {
+	s := parent.inherit()
+	apply := func(decls [][2]string) {
+		for _, decl := range decls {
+			s.applyDeclaration(decl[0], decl[1], c.fontFamily)
+		}
+	}
+
+	if defaults, ok := defaultStyles[n.tag]; ok {
+		apply(parseDeclarations(defaults))
+	}
+	if align := n.attr("align"); align != "" {
+		s.align = strings.ToLower(align)
+	}
+	if width := n.attr("width"); width != "" {
+		s.width = width
+	}
+	for _, rule := range c.rules {
+		if rule.matches(n) {
+			apply(rule.decls)
+		}
+	}
+	apply(parseDeclarations(n.attr("style")))
+	return s
+}
+
+// Balises dont le contenu n'est pas imprimé
+var hiddenTags = map[string]bool{"head": true, "title": true, "style": true, "script": true, "meta": true}
+
+// Balises de texte, mises en page dans le flux d'une ligne
+var inlineTags = map[string]bool{"": true, "span": true, "b": true, "strong": true, "br": true}
+
+// layoutChildren met en page le contenu d'un élément dans une largeur donnée
+func (c *htmlPDF) layoutChildren(n *node, s *style, width float64) []fragment {
+	var frags []fragment
+	var inline []*node
+	flush := func() {
+		frags = appendFragments(frags, c.layoutInline(inline, s, width)...)
+		inline = nil
+	}
+
+	for _, child := range n.children {
+		if inlineTags[child.tag] {
+			inline = append(inline, child)
+			continue
+		}
+		flush()
+		frags = appendFragments(frags, c.layoutBlock(child, s, width)...)
+	}
+	flush()
+	return frags
+}
+
+func (c *htmlPDF) layoutBlock(n *node, parent *style, width float64) []fragment {
+	if hiddenTags[n.tag] {
+		return nil
+	}
+	s := c.computeStyle(n, parent)
+	frags := []fragment{space(s.marginTop)}
+
+	switch n.tag {
+	case "footer":
+		if c.footer == nil {
+			c.footer = c.layoutChildren(n, s, width)
+			return nil
+		}
+	case "img":
+		if image := c.layoutImage(n, s, width); image != nil {
+			frags = append(frags, image)
+		}
+	case "table":
+		frags = append(frags, c.layoutTable(n, s, width))
+	default:
+		inner := width - s.padding[1] - s.padding[3]
+		frags = append(frags, &box{
+			style:    s,
+			width:    width,
+			children: c.layoutChildren(n, s, inner),
+		})
+	}
+
+	return append(frags, space(s.marginBottom))
+}
+
+// word est un mot mis en page avec son style
+type word struct {
+	text   string
+	style  *style
+	width  float64
+	spaced bool // précédé d'une espace
+}
+
+// layoutInline coupe un flux de texte en lignes de la largeur disponible
+func (c *htmlPDF) layoutInline(nodes []*node, s *style, width float64) []fragment {
+	var words []word
+	breaks := map[int]int{} // position dans words -> nombre de retours à la ligne
+	pendingSpace := false
+
+	var walk func(n *node, st *style)
+	walk = func(n *node, st *style) {
+		if n.tag == "br" {
+			breaks[len(words)]++
+			pendingSpace = false
+			return
+		}
+		if n.tag != "" {
+			st = c.computeStyle(n, st)
+			for _, child := range n.children {
+				walk(child, st)
+			}
+			return
+		}
+
+		text := strings.ReplaceAll(n.text, "\r\n", "\n")
+		for i, segment := range strings.Split(text, "\n") {
+			if i > 0 {
+				if st.preLine {
+					breaks[len(words)]++
+					pendingSpace = false
+				} else {
+					pendingSpace = true
+				}
+			}
+			if segment != "" && isHTMLSpace(rune(segment[0])) {
+				pendingSpace = true
+			}
+			for _, field := range strings.FieldsFunc(segment, isHTMLSpace) {
+				words = append(words, word{text: field, style: st, width: c.measure(st, field), spaced: pendingSpace})
+				pendingSpace = true
+			}
+			if segment != "" && !isHTMLSpace(rune(segment[len(segment)-1])) {
+				pendingSpace = false
+			}
+		}
+	}
+	for _, n := range nodes {
+		walk(n, s)
+	}
+	if len(words) == 0 {
+		return nil
+	}
+
+	var lines []fragment
+	current := &textLine{width: width, align: s.align, minHeight: s.lineHeightMM()}
+	used := 0.0
+	newLine := func() {
+		lines = append(lines, current)
+		current = &textLine{width: width, align: s.align, minHeight: s.lineHeightMM()}
+		used = 0
+	}
+	for i, w := range words {
+		for n := breaks[i]; n > 0; n-- {
+			if i > 0 {
+				newLine()
+			}
+		}
+		advance := w.width
+		if w.spaced && len(current.words) > 0 {
+			advance += c.measure(w.style, " ")
+		}
+		if used+advance > width && len(current.words) > 0 {
+			newLine()
+			advance = w.width
+		}
+		if len(current.words) == 0 {
+			w.spaced = false
+		}
+		current.words = append(current.words, w)
+		used += advance
+	}
+	lines = append(lines, current)
+	return lines
+}
+
+// isHTMLSpace indique les espaces qui séparent les mots en HTML ; les espaces
+// insécables restent dans les mots
+func isHTMLSpace(r rune) bool {
+	return r == ' ' || r == '\t' || r == '\n' || r == '\r' || r == '\f'
+}
+
+func (c *htmlPDF) measure(s *style, text string) float64 {
+	c.setFont(s)
+	return c.pdf.GetStringWidth(c.encode(s, text))
+}
+
+// encode prépare un texte pour la police : les polices standard sont en cp1252
+func (c *htmlPDF) encode(s *style, text string) string {
+	text = strings.NewReplacer("\u202f", "\u00a0", "\u2009", " ").Replace(text)
+	if c.theme.customFont() && s.font == c.theme.Font {
+		return text
+	}
+	return c.translate(text)
+}
+
+func (c *htmlPDF) setFont(s *style) {
+	fontStyle := ""
+	if s.bold {
+		fontStyle = "B"
+	}
+	c.pdf.SetFont(s.font, fontStyle, s.size)
+	c.pdf.SetTextColor(s.color.Red, s.color.Green, s.color.Blue)
+}
+
+// layoutImage place une image intégrée à la page (data URI, comme le logo et
+// le QR code) ; sa hauteur suit la largeur demandée
+func (c *htmlPDF) layoutImage(n *node, s *style, width float64) fragment {
+	header, encoded, ok := strings.Cut(n.attr("src"), ",")
+	if !ok || !strings.HasPrefix(header, "data:image/") || !strings.HasSuffix(header, ";base64") {
+		return nil
+	}
+	data, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil || len(data) == 0 {
+		return nil
+	}
+	imageType := strings.TrimPrefix(strings.TrimSuffix(header, ";base64"), "data:image/")
+
+	c.images++
+	name := fmt.Sprintf("image%d", c.images)
+	info := c.pdf.RegisterImageOptionsReader(name, gofpdf.ImageOptions{ImageType: imageType}, bytes.NewReader(data))
+	if info == nil || c.pdf.Err() {
+		c.pdf.ClearError()
+		return nil
+	}
+
+	ratio := info.Height() / info.Width()
+	w, ok := resolveWidth(s.width, width, s.size)
+	if !ok {
+		w = info.Width() * pxToMM / ptToMM
+	}
+	if w > width {
+		w = width
+	}
+	return &picture{name: name, w: w, h: w * ratio, available: width, align: s.align}
+}
+
+// resolveWidth convertit une largeur CSS (%, mm, px…) en mm
+func resolveWidth(value string, available, fontSize float64) (float64, bool) {
+	value = strings.TrimSpace(value)
+	if value == "" || value == "auto" {
+		return 0, false
+	}
+	if strings.HasSuffix(value, "%") {
+		pct, err := strconv.ParseFloat(strings.TrimSuffix(value, "%"), 64)
+		if err != nil {
+			return 0, false
+		}
+		return available * pct / 100, true
+	}
+	return parseLength(value, fontSize)
+}
+
+// layoutTable met en page un tableau sur toute la largeur : largeurs des
+// colonnes d'après la première ligne, hauteur de chaque ligne d'après son
+// contenu
+func (c *htmlPDF) layoutTable(n *node, s *style, width float64) fragment {
+	type rowNode struct {
+		tr     *node
+		style  *style
+		header bool
+	}
+	var rows []rowNode
+	var collect func(parent *node, parentStyle *style, header bool)
+	collect = func(parent *node, parentStyle *style, header bool) {
+		for _, child := range parent.children {
+			switch child.tag {
+			case "thead", "tbody", "tfoot":
+				collect(child, c.computeStyle(child, parentStyle), child.tag == "thead")
+			case "tr":
+				rows = append(rows, rowNode{child, c.computeStyle(child, parentStyle), header})
+			}
+		}
+	}
+	collect(n, s, false)
+
+	cells := func(tr *node) []*node {
+		var result []*node
+		for _, child := range tr.children {
+			if child.tag == "td" || child.tag == "th" {
+				result = append(result, child)
+			}
+		}
+		return result
+	}
+
+	// Largeurs des colonnes : celles indiquées sur la première ligne, le reste
+	// partagé également
+	columns := 0
+	for _, row := range rows {
+		if count := len(cells(row.tr)); count > columns {
+			columns = count
+		}
+	}
+	if columns == 0 {
+		return &table{}
+	}
+	widths := make([]float64, columns)
+	fixed, free := 0.0, columns
+	for i, cell := range cells(rows[0].tr) {
+		cellStyle := c.computeStyle(cell, rows[0].style)
+		if w, ok := resolveWidth(cellStyle.width, width, cellStyle.size); ok {
+			widths[i] = w
+			fixed += w
+			free--
+		}
+	}
+	for i := range widths {
+		if widths[i] == 0 && free > 0 {
+			widths[i] = (width - fixed) / float64(free)
+		}
+	}
+	if total := sum(widths); total > width {
+		for i := range widths {
+			widths[i] *= width / total
+		}
+	}
+
+	t := &table{}
+	for _, row := range rows {
+		r := &tableRow{}
+		for i, cell := range cells(row.tr) {
+			cellStyle := c.computeStyle(cell, row.style)
+			inner := widths[i] - cellStyle.padding[1] - cellStyle.padding[3]
+			r.cells = append(r.cells, &tableCell{
+				x:   sum(widths[:i]),
+				box: box{style: cellStyle, width: widths[i], children: c.layoutChildren(cell, cellStyle, inner)},
+			})
+			if h := r.cells[i].height(); h > r.h {
+				r.h = h
+			}
+		}
+		if row.header {
+			t.header = append(t.header, r)
+		} else {
+			t.rows = append(t.rows, r)
+		}
+	}
+	return t
+}
+
+func sum(values []float64) float64 {
+	total := 0.0
+	for _, v := range values {
+		total += v
+	}
+	return total
+}
+
+// appendFragments ajoute des fragments en fusionnant les marges successives
+// (la plus grande l'emporte, comme dans un navigateur)
+func appendFragments(frags []fragment, added ...fragment) []fragment {
+	for _, f := range added {
+		if s, ok := f.(space); ok {
+			if s <= 0 {
+				continue
+			}
+			if last := len(frags) - 1; last >= 0 {
+				if previous, ok := frags[last].(space); ok {
+					if s > previous {
+						frags[last] = s
+					}
+					continue
+				}
+			}
+		}
+		frags = append(frags, f)
+	}
+	return frags
+}
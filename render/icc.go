@@ -0,0 +1,119 @@
+package render
+
+import (
+	"bytes"
+	"encoding/binary"
+	"math"
+)
+
+// Primaires sRGB adaptées à l'illuminant D50 de l'espace de connexion ICC
+var (
+	iccWhite = [3]float64{0.9642, 1.0, 0.8249}
+	iccRed   = [3]float64{0.4361, 0.2225, 0.0139}
+	iccGreen = [3]float64{0.3851, 0.7169, 0.0971}
+	iccBlue  = [3]float64{0.1431, 0.0606, 0.7141}
+)
+
+// iccTag est une entrée de la table des balises d'un profil ICC
+type iccTag struct {
+	signature string
+	data      []byte
+}
+
+// sRGBProfile construit le profil ICC v2 sRGB IEC61966-2.1 que les PDF/A
+// déclarent dans leur intention de sortie : les couleurs RGB des documents
+// sont ainsi rattachées à un espace colorimétrique défini
+func sRGBProfile() []byte {
+	curve := iccCurve()
+	tags := []iccTag{
+		{"desc", iccDescription("sRGB IEC61966-2.1")},
+		{"cprt", iccText("No copyright, use freely")},
+		{"wtpt", iccXYZ(iccWhite)},
+		{"rXYZ", iccXYZ(iccRed)},
+		{"gXYZ", iccXYZ(iccGreen)},
+		{"bXYZ", iccXYZ(iccBlue)},
+		{"rTRC", curve},
+		{"gTRC", curve},
+		{"bTRC", curve},
+	}
+
+	// Table des balises, puis leurs données alignées sur 4 octets ; les trois
+	// courbes identiques partagent les mêmes données
+	var table, data bytes.Buffer
+	offset := 128 + 4 + 12*len(tags)
+	offsets := map[*byte]int{}
+	binary.Write(&table, binary.BigEndian, uint32(len(tags)))
+	for _, tag := range tags {
+		at, ok := offsets[&tag.data[0]]
+		if !ok {
+			at = offset + data.Len()
+			offsets[&tag.data[0]] = at
+			data.Write(tag.data)
+			for data.Len()%4 != 0 {
+				data.WriteByte(0)
+			}
+		}
+		table.WriteString(tag.signature)
+		binary.Write(&table, binary.BigEndian, uint32(at))
+		binary.Write(&table, binary.BigEndian, uint32(len(tag.data)))
+	}
+
+	size := offset + data.Len()
+	header := make([]byte, 128)
+	binary.BigEndian.PutUint32(header[0:], uint32(size))
+	binary.BigEndian.PutUint32(header[8:], 0x02100000) // version 2.1
+	copy(header[12:], "mntr")
+	copy(header[16:], "RGB ")
+	copy(header[20:], "XYZ ")
+	for i, v := range []uint16{2026, 1, 1, 0, 0, 0} {
+		binary.BigEndian.PutUint16(header[24+2*i:], v)
+	}
+	copy(header[36:], "acsp")
+	copy(header[68:], iccXYZ(iccWhite)[8:])
+
+	profile := append(header, table.Bytes()...)
+	return append(profile, data.Bytes()...)
+}
+
+// iccCurve retourne la courbe de transfert sRGB, échantillonnée
+func iccCurve() []byte {
+	const points = 1024
+	var b bytes.Buffer
+	b.WriteString("curv\x00\x00\x00\x00")
+	binary.Write(&b, binary.BigEndian, uint32(points))
+	for i := 0; i < points; i++ {
+		v := float64(i) / (points - 1)
+		if v <= 0.04045 {
+			v /= 12.92
+		} else {
+			v = math.Pow((v+0.055)/1.055, 2.4)
+		}
+		binary.Write(&b, binary.BigEndian, uint16(math.Round(v*65535)))
+	}
+	return b.Bytes()
+}
+
+// iccXYZ retourne une balise XYZType
+func iccXYZ(xyz [3]float64) []byte {
+	var b bytes.Buffer
+	b.WriteString("XYZ \x00\x00\x00\x00")
+	for _, v := range xyz {
+		binary.Write(&b, binary.BigEndian, int32(math.Round(v*65536)))
+	}
+	return b.Bytes()
+}
+
+// iccText retourne une balise textType
+func iccText(text string) []byte {
+	return []byte("text\x00\x00\x00\x00" + text + "\x00")
+}
+
+// iccDescription retourne une balise textDescriptionType, en ASCII seulement
+func iccDescription(text string) []byte {
+	var b bytes.Buffer
+	b.WriteString("desc\x00\x00\x00\x00")
+	binary.Write(&b, binary.BigEndian, uint32(len(text)+1))
+	b.WriteString(text + "\x00")
+	b.Write(make([]byte, 4+4+2+1+67)) // descriptions Unicode et ScriptCode vides
+	return b.Bytes()
+}
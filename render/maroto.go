@@ -0,0 +1,502 @@
+package render
+
+import (
+	"fmt"
+	"outbil/i18n"
+	"strings"
+	"sync"
+
+	"github.com/johnfercher/maroto/v2"
+	"github.com/johnfercher/maroto/v2/pkg/components/code"
+	"github.com/johnfercher/maroto/v2/pkg/components/col"
+	"github.com/johnfercher/maroto/v2/pkg/components/image"
+	"github.com/johnfercher/maroto/v2/pkg/components/line"
+	"github.com/johnfercher/maroto/v2/pkg/components/row"
+	"github.com/johnfercher/maroto/v2/pkg/components/text"
+	"github.com/johnfercher/maroto/v2/pkg/config"
+	"github.com/johnfercher/maroto/v2/pkg/consts/align"
+	"github.com/johnfercher/maroto/v2/pkg/consts/border"
+	"github.com/johnfercher/maroto/v2/pkg/consts/extension"
+	"github.com/johnfercher/maroto/v2/pkg/consts/fontstyle"
+	"github.com/johnfercher/maroto/v2/pkg/core"
+	"github.com/johnfercher/maroto/v2/pkg/props"
+	"github.com/johnfercher/maroto/v2/pkg/repository"
+)
+
+// marotoMu sérialise la création des documents Maroto, qui initialise sans
+// verrou un singleton partagé (générateur de codes) : les PDFs d'un lot sont
+// rendus en parallèle
+var marotoMu sync.Mutex
+
+// marotoRenderer met en page les documents avec Maroto
+type marotoRenderer struct {
+	theme *Theme
+}
+
+func (r *marotoRenderer) Render(doc *Document) ([]byte, error) {
+	t := r.theme
+
+	builder := config.NewBuilder().
+		WithLeftMargin(t.Margin).
+		WithRightMargin(t.Margin).
+		WithTopMargin(15).
+		WithBottomMargin(15).
+		WithDefaultFont(&props.Font{Family: t.Font, Size: t.FontSize, Color: t.Colors.Text.props()})
+
+	if t.customFont() {
+		regular, bold, err := t.fontData()
+		if err != nil {
+			return nil, err
+		}
+		fonts, err := repository.New().
+			AddUTF8FontFromBytes(t.Font, fontstyle.Normal, regular).
+			AddUTF8FontFromBytes(t.Font, fontstyle.Bold, bold).
+			Load()
+		if err != nil {
+			return nil, fmt.Errorf(i18n.T("erreur lors du chargement des polices: %w"), err)
+		}
+		builder = builder.WithCustomFonts(fonts)
+	}
+
+	// Référence du document et numéro de page en bas de chaque page
+	if doc.PageNumber != "" {
+		builder = builder.WithPageNumber(props.PageNumber{
+			Pattern: fmt.Sprintf(doc.PageNumber, doc.Reference, "{current}", "{total}"),
+			Place:   props.RightBottom,
+			Size:    t.FontSize - 3,
+			Color:   t.Colors.Muted.props(),
+		})
+	}
+
+	cfg := builder.Build()
+	marotoMu.Lock()
+	m := maroto.New(cfg)
+	marotoMu.Unlock()
+	measure, err := newMeasurer(t)
+	if err != nil {
+		return nil, err
+	}
+
+	// Pied de page avec les mentions légales, répété sur chaque page
+	footerHeight := 0.0
+	if len(doc.Footer) > 0 {
+		lineHeight := r.lineHeight(t.FontSize - 3)
+		footerCol := col.New(12)
+		for i, footerLine := range doc.Footer {
+			footerCol.Add(text.New(footerLine, props.Text{
+				Size:  t.FontSize - 3,
+				Align: align.Center,
+				Top:   2 + float64(i)*lineHeight,
+				Color: t.Colors.Muted.props(),
+			}))
+		}
+		footerHeight = 4 + float64(len(doc.Footer))*lineHeight
+		if err := m.RegisterFooter(row.New(footerHeight).Add(footerCol)); err != nil {
+			return nil, fmt.Errorf(i18n.T("erreur lors de la création du pied de page: %w"), err)
+		}
+	}
+
+	p := newPager(m, cfg, measure, footerHeight)
+	for _, block := range t.Blocks {
+		switch block {
+		case BlockHeader:
+			r.header(p, doc)
+		case BlockTitle:
+			r.title(p, doc)
+		case BlockDates:
+			r.dates(p, doc)
+		case BlockParties:
+			r.parties(p, doc)
+		case BlockItems:
+			r.items(p, doc)
+		case BlockTotals:
+			r.totals(p, doc)
+		case BlockPayment:
+			r.payment(p, doc)
+		case BlockNotes:
+			r.textBlock(p, doc.NotesTitle, doc.Notes)
+		case BlockTerms:
+			r.textBlock(p, doc.TermsTitle, doc.Terms)
+		}
+	}
+
+	document, err := m.Generate()
+	if err != nil {
+		return nil, fmt.Errorf(i18n.T("erreur lors de la génération du PDF: %w"), err)
+	}
+	return document.GetBytes(), nil
+}
+
+// header imprime les coordonnées de l'entreprise et son logo
+func (r *marotoRenderer) header(p *pager, doc *Document) {
+	t := r.theme
+	lineHeight := r.lineHeight(t.FontSize)
+
+	companyCol := col.New(8)
+	companyCol.Add(text.New(doc.CompanyName, props.Text{
+		Size:  t.FontSize + 6,
+		Style: fontstyle.Bold,
+		Color: t.Colors.Accent.props(),
+	}))
+	for i, companyLine := range doc.CompanyLines {
+		companyCol.Add(text.New(companyLine, props.Text{
+			Top: 8 + float64(i)*lineHeight,
+		}))
+	}
+
+	logoCol := col.New(4)
+	if len(doc.Logo) > 0 {
+		logoCol.Add(image.NewFromBytes(doc.Logo, extension.Png, props.Rect{
+			Percent: 80,
+			Center:  true,
+		}))
+	}
+
+	height := 8 + float64(len(doc.CompanyLines))*lineHeight + 2
+	if height < 40 {
+		height = 40
+	}
+	p.row(height, companyCol, logoCol)
+}
+
+// title imprime le titre et le numéro du document
+func (r *marotoRenderer) title(p *pager, doc *Document) {
+	t := r.theme
+	p.row(t.TitleSize*0.75,
+		col.New(12).Add(text.New(doc.Title, props.Text{
+			Size:  t.TitleSize,
+			Style: fontstyle.Bold,
+			Align: r.titleAlign(),
+			Color: t.Colors.Accent.props(),
+		})),
+	)
+	p.row(8,
+		col.New(12).Add(text.New(doc.Number, props.Text{
+			Size:   t.FontSize + 2,
+			Family: t.NumberFont,
+			Align:  r.titleAlign(),
+		})),
+	)
+}
+
+func (r *marotoRenderer) dates(p *pager, doc *Document) {
+	p.row(8,
+		col.New(6).Add(text.New(doc.Date, props.Text{})),
+		col.New(6).Add(text.New(doc.ValidUntil, props.Text{Align: align.Right})),
+	)
+	p.space(10)
+}
+
+// parties imprime le bloc client et, en regard, l'adresse de livraison
+func (r *marotoRenderer) parties(p *pager, doc *Document) {
+	clientCol, clientHeight := r.addressBlock(doc.ClientTitle, doc.ClientLines)
+	deliveryCol := col.New(6)
+	height := clientHeight
+	if len(doc.DeliveryLines) > 0 {
+		var deliveryHeight float64
+		deliveryCol, deliveryHeight = r.addressBlock(doc.DeliveryTitle, doc.DeliveryLines)
+		if deliveryHeight > height {
+			height = deliveryHeight
+		}
+	}
+	p.row(height, clientCol, deliveryCol)
+	p.space(10)
+}
+
+func (r *marotoRenderer) addressBlock(title string, lines []string) (core.Col, float64) {
+	t := r.theme
+	lineHeight := r.lineHeight(t.FontSize)
+
+	block := col.New(6)
+	block.Add(text.New(title, props.Text{
+		Size:  t.FontSize + 2,
+		Style: fontstyle.Bold,
+	}))
+	for i, blockLine := range lines {
+		block.Add(text.New(blockLine, props.Text{
+			Top: 8 + float64(i)*lineHeight,
+		}))
+	}
+	return block, 10 + float64(len(lines))*lineHeight
+}
+
+// items imprime le tableau des lignes avec les colonnes du thème. Quand le
+// tableau continue sur la page suivante, le sous-total est reporté en bas de
+// page puis en haut de la suivante, sous l'en-tête répété.
+func (r *marotoRenderer) items(p *pager, doc *Document) {
+	t := r.theme
+	headerStyle, cellStyle := r.tableStyles()
+
+	var headerCols []core.Col
+	for _, column := range t.Columns {
+		headerCol := col.New(column.Width).Add(text.New(doc.Headers[column.Field], props.Text{
+			Style: fontstyle.Bold,
+			Align: columnAlign(column.Field),
+			Left:  columnPadding(column.Field),
+			Top:   2,
+			Color: t.Colors.HeaderText.props(),
+		}))
+		if headerStyle != nil {
+			headerCol.WithStyle(headerStyle)
+		}
+		headerCols = append(headerCols, headerCol)
+	}
+
+	const headerHeight, carryHeight = 10.0, 7.0
+	carried := 0.0
+	for i, item := range doc.Items {
+		var cols []core.Col
+		height := 8.0
+		for _, column := range t.Columns {
+			family := t.NumberFont
+			if column.Field == FieldDescription {
+				family = t.Font
+			}
+			prop := r.textProps(props.Text{
+				Size:   t.FontSize - 1,
+				Family: family,
+				Align:  columnAlign(column.Field),
+				Left:   columnPadding(column.Field),
+				Right:  columnPadding(column.Field),
+				Top:    2,
+			})
+			value := item.Value(column.Field)
+			// Hauteur du texte coupé dans la colonne, plus la marge du bas
+			if h := p.measure.textHeight(value, prop, p.colWidth(column.Width)) + 3; h > height {
+				height = h
+			}
+			cell := col.New(column.Width).Add(text.New(value, prop))
+			if cellStyle != nil {
+				cell.WithStyle(cellStyle)
+			}
+			cols = append(cols, cell)
+		}
+
+		// Place réservée sous la ligne pour le report, sauf après la dernière
+		reserve := carryHeight
+		if i == len(doc.Items)-1 {
+			reserve = 0
+		}
+		switch {
+		case i == 0:
+			// L'en-tête ne reste pas seul en bas de page
+			if !p.fits(headerHeight + height + reserve) {
+				p.newPage()
+			}
+			p.row(headerHeight, headerCols...)
+		case !p.fits(height + reserve):
+			r.carryRow(p, doc.CarriedForward, doc.Locale.FormatAmount(carried, doc.Currency))
+			p.newPage()
+			p.row(headerHeight, headerCols...)
+			r.carryRow(p, doc.BroughtForward, doc.Locale.FormatAmount(carried, doc.Currency))
+		}
+		p.row(height, cols...)
+		carried += item.Net
+	}
+	p.space(10)
+}
+
+// carryRow imprime un report du sous-total, le montant sous la colonne des
+// montants
+func (r *marotoRenderer) carryRow(p *pager, label, value string) {
+	t := r.theme
+	before, amount := 0, 0
+	for _, column := range t.Columns {
+		if column.Field == FieldAmount {
+			amount = column.Width
+			break
+		}
+		before += column.Width
+	}
+	if amount == 0 {
+		before, amount = 9, 3
+	}
+
+	prop := props.Text{Style: fontstyle.Bold, Align: align.Right, Top: 2}
+	numberProp := prop
+	numberProp.Family = t.NumberFont
+	cols := []core.Col{
+		col.New(before).Add(text.New(label, prop)),
+		col.New(amount).Add(text.New(value, numberProp)),
+	}
+	if after := 12 - before - amount; after > 0 {
+		cols = append(cols, col.New(after))
+	}
+	p.row(7, cols...)
+}
+
+// totals imprime les totaux alignés sous la colonne des montants
+func (r *marotoRenderer) totals(p *pager, doc *Document) {
+	t := r.theme
+
+	// Les totaux ne sont pas coupés entre deux pages
+	height := 2.0
+	for _, total := range doc.Totals {
+		size := t.FontSize
+		if total.Grand {
+			size += 2
+		}
+		height += size*0.6 + 0.5
+	}
+	if !p.fits(height) {
+		p.newPage()
+	}
+
+	for _, total := range doc.Totals {
+		size, style, color := t.FontSize, fontstyle.Normal, t.Colors.Text.props()
+		if total.Grand {
+			p.row(2,
+				col.New(8),
+				col.New(4).Add(line.New(props.Line{
+					Thickness: 0.5,
+					Color:     t.Colors.Border.props(),
+				})),
+			)
+			size, style, color = t.FontSize+2, fontstyle.Bold, t.Colors.Accent.props()
+		}
+		p.row(size*0.6+0.5,
+			col.New(8),
+			col.New(2).Add(text.New(total.Label, props.Text{
+				Size:  size,
+				Style: style,
+				Align: align.Right,
+				Color: color,
+			})),
+			col.New(2).Add(text.New(total.Value, props.Text{
+				Size:   size,
+				Style:  style,
+				Align:  align.Right,
+				Family: t.NumberFont,
+				Color:  color,
+			})),
+		)
+	}
+	p.space(15)
+}
+
+// payment imprime les coordonnées bancaires et le QR code de virement
+func (r *marotoRenderer) payment(p *pager, doc *Document) {
+	if len(doc.PaymentLines) == 0 {
+		return
+	}
+	t := r.theme
+	lineHeight := r.lineHeight(t.FontSize)
+
+	bankCol := col.New(8)
+	bankCol.Add(text.New(doc.PaymentTitle, props.Text{
+		Style: fontstyle.Bold,
+	}))
+	for i, paymentLine := range doc.PaymentLines {
+		bankCol.Add(text.New(paymentLine, props.Text{
+			Size: t.FontSize - 1,
+			Top:  6 + float64(i)*lineHeight,
+		}))
+	}
+
+	qrCol := col.New(4)
+	if doc.PaymentQR != "" {
+		qrCol.Add(code.NewQr(doc.PaymentQR, props.Rect{
+			Center:  true,
+			Percent: 100,
+		}))
+	}
+
+	p.row(32, bankCol, qrCol)
+	p.space(8)
+}
+
+// textBlock imprime un titre suivi d'un texte libre (notes, conditions), un
+// paragraphe par ligne pour que le texte puisse continuer sur la page suivante
+func (r *marotoRenderer) textBlock(p *pager, title, content string) {
+	if content == "" {
+		return
+	}
+	t := r.theme
+	prop := r.textProps(props.Text{
+		Size:            t.FontSize - 1,
+		VerticalPadding: 1,
+	})
+	for i, paragraph := range strings.Split(strings.ReplaceAll(content, "\r\n", "\n"), "\n") {
+		height := p.measure.textHeight(paragraph, prop, p.colWidth(12)) + 1
+		if i == 0 {
+			// Le titre reste avec le premier paragraphe
+			if !p.fits(6 + height) {
+				p.newPage()
+			}
+			p.row(6, col.New(12).Add(text.New(title, props.Text{
+				Style: fontstyle.Bold,
+			})))
+		}
+		p.row(height, col.New(12).Add(text.New(paragraph, prop)))
+	}
+	p.space(10)
+}
+
+// tableStyles retourne le style des cellules d'en-tête et des lignes du tableau
+func (r *marotoRenderer) tableStyles() (*props.Cell, *props.Cell) {
+	t := r.theme
+	headerStyle := &props.Cell{
+		BackgroundColor: t.Colors.HeaderBackground.props(),
+		BorderColor:     t.Colors.Border.props(),
+		BorderThickness: 0.5,
+	}
+	cellStyle := &props.Cell{
+		BorderColor:     t.Colors.Border.props(),
+		BorderThickness: 0.5,
+	}
+
+	switch t.TableBorder {
+	case BorderFull:
+		headerStyle.BorderType = border.Full
+		cellStyle.BorderType = border.Full
+	case BorderRows:
+		cellStyle.BorderType = border.Bottom
+	default:
+		headerStyle.BorderType = border.Bottom
+		cellStyle = nil
+	}
+	return headerStyle, cellStyle
+}
+
+func (r *marotoRenderer) titleAlign() align.Type {
+	switch r.theme.TitleAlign {
+	case "left":
+		return align.Left
+	case "right":
+		return align.Right
+	}
+	return align.Center
+}
+
+// textProps complète les propriétés d'un texte avec la police du thème, pour
+// le mesurer comme Maroto l'imprimera
+func (r *marotoRenderer) textProps(prop props.Text) props.Text {
+	prop.MakeValid(&props.Font{Family: r.theme.Font, Size: r.theme.FontSize, Style: fontstyle.Normal, Color: r.theme.Colors.Text.props()})
+	return prop
+}
+
+// lineHeight retourne l'interligne (en mm) d'un texte de cette taille
+func (r *marotoRenderer) lineHeight(size float64) float64 {
+	return size / 2
+}
+
+func columnAlign(field string) align.Type {
+	switch field {
+	case FieldDescription:
+		return align.Left
+	case FieldQuantity, FieldTaxRate:
+		return align.Center
+	}
+	return align.Right
+}
+
+func columnPadding(field string) float64 {
+	if field == FieldDescription {
+		return 2
+	}
+	return 0
+}
+
+func (c Color) props() *props.Color {
+	return &props.Color{Red: c.Red, Green: c.Green, Blue: c.Blue}
+}
@@ -0,0 +1,120 @@
+package render
+
+import (
+	"strings"
+
+	"github.com/johnfercher/maroto/v2/pkg/core"
+	"github.com/johnfercher/maroto/v2/pkg/core/entity"
+	"github.com/johnfercher/maroto/v2/pkg/props"
+	"github.com/jung-kurt/gofpdf"
+)
+
+// pager ajoute les lignes au document Maroto en suivant le remplissage des
+// pages, avec la même règle que Maroto : une ligne qui ne tient plus dans la
+// page restante passe à la page suivante. Le renderer sait ainsi où les pages
+// seront coupées, pour répéter l'en-tête du tableau et reporter les montants.
+type pager struct {
+	m       core.Maroto
+	measure *measurer
+	width   float64 // largeur utile d'une page
+	height  float64 // hauteur utile d'une page
+	footer  float64 // hauteur du pied de page
+	used    float64 // hauteur déjà occupée sur la page courante
+}
+
+func newPager(m core.Maroto, cfg *entity.Config, measure *measurer, footer float64) *pager {
+	return &pager{
+		m:       m,
+		measure: measure,
+		width:   cfg.Dimensions.Width - cfg.Margins.Left - cfg.Margins.Right,
+		height:  cfg.Dimensions.Height - cfg.Margins.Top - cfg.Margins.Bottom,
+		footer:  footer,
+	}
+}
+
+// row ajoute une ligne de hauteur fixe
+func (p *pager) row(height float64, cols ...core.Col) {
+	if !p.fits(height) {
+		p.used = 0
+	}
+	p.used += height
+	p.m.AddRow(height, cols...)
+}
+
+// fits indique si une hauteur tient encore sur la page courante
+func (p *pager) fits(height float64) bool {
+	return height+p.used+p.footer <= p.height
+}
+
+// space ajoute un espace vertical ; en bas de page, il est remplacé par un
+// saut de page pour ne pas ouvrir une page blanche
+func (p *pager) space(height float64) {
+	if p.fits(height) {
+		p.row(height)
+		return
+	}
+	p.newPage()
+}
+
+// newPage termine la page courante : la ligne suivante commencera une page
+func (p *pager) newPage() {
+	remaining := p.height - p.footer - p.used
+	if p.used == 0 || remaining <= 0.01 {
+		return
+	}
+	p.row(remaining - 0.01)
+}
+
+// colWidth retourne la largeur en mm d'une colonne de la grille de 12
+func (p *pager) colWidth(size int) float64 {
+	return p.width * float64(size) / 12
+}
+
+// measurer mesure les textes comme Maroto les coupe, pour donner aux lignes
+// la hauteur de leur contenu
+type measurer struct {
+	pdf       *gofpdf.Fpdf
+	translate func(string) string
+}
+
+func newMeasurer(t *Theme) (*measurer, error) {
+	pdf := gofpdf.New("P", "mm", "A4", "")
+	if t.customFont() {
+		regular, bold, err := t.fontData()
+		if err != nil {
+			return nil, err
+		}
+		pdf.AddUTF8FontFromBytes(t.Font, "", regular)
+		pdf.AddUTF8FontFromBytes(t.Font, "B", bold)
+	}
+	return &measurer{pdf: pdf, translate: pdf.UnicodeTranslatorFromDescriptor("")}, nil
+}
+
+// textHeight retourne la hauteur d'un texte dans une cellule de cette largeur,
+// marges du texte comprises. Les propriétés doivent être complètes (MakeValid).
+func (ms *measurer) textHeight(value string, prop props.Text, width float64) float64 {
+	ms.pdf.SetFont(prop.Family, string(prop.Style), prop.Size)
+	// Maroto convertit en cp1252 le texte des polices standard
+	if contains(standardFonts, prop.Family) {
+		value = ms.translate(value)
+	}
+	width -= prop.Left + prop.Right
+
+	lines := 1
+	if ms.pdf.GetStringWidth(value) >= width {
+		// Coupure aux espaces, mot par mot, comme Maroto
+		current := 0.0
+		for _, word := range strings.Split(value, " ") {
+			wordWidth := ms.pdf.GetStringWidth(word + " ")
+			if wordWidth+current < width {
+				current += wordWidth
+				continue
+			}
+			lines++
+			current = wordWidth
+		}
+	}
+
+	fontHeight := prop.Size * 25.4 / 72
+	return float64(lines)*fontHeight + float64(lines-1)*prop.VerticalPadding + prop.Top + prop.Bottom
+}
@@ -0,0 +1,343 @@
+package render
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"outbil/i18n"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pdfcpu/pdfcpu/pkg/api"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+)
+
+// Attachment est un fichier joint à un PDF/A, par exemple les données
+// structurées du document
+type Attachment struct {
+	Name        string
+	Description string
+	MimeType    string
+	Data        []byte
+	ModTime     time.Time
+}
+
+// ConformanceError liste les écarts d'un PDF à la norme PDF/A-3b
+type ConformanceError struct {
+	Problems []string
+}
+
+func (e *ConformanceError) Error() string {
+	return fmt.Sprintf(i18n.T("PDF non conforme à PDF/A-3b: %s"), strings.Join(e.Problems, "; "))
+}
+
+// Archive convertit un PDF en PDF/A-3b pour l'archivage : profil de couleurs
+// sRGB, métadonnées XMP reprises du dictionnaire d'informations et fichiers
+// joints. Les ajouts sont écrits en mise à jour incrémentale, sans réécrire le
+// document. Le résultat est contrôlé avant d'être retourné : les polices
+// doivent être incorporées au préalable, avec un thème Archival.
+func Archive(content []byte, attachments ...Attachment) ([]byte, error) {
+	if !bytes.HasSuffix(content, []byte("\n")) {
+		content = append(content, '\n')
+	}
+	increment, err := archiveIncrement(content, attachments)
+	if err != nil {
+		return nil, err
+	}
+	archived := append(content, increment...)
+
+	if problems, err := CheckArchive(archived); err != nil {
+		return nil, err
+	} else if len(problems) > 0 {
+		return nil, &ConformanceError{Problems: problems}
+	}
+	return archived, nil
+}
+
+// archiveIncrement écrit la mise à jour incrémentale : intention de sortie,
+// métadonnées XMP et fichiers joints, référencés par le catalogue
+func archiveIncrement(content []byte, attachments []Attachment) ([]byte, error) {
+	conf := model.NewDefaultConfiguration()
+	conf.Cmd = model.ADDANNOTATIONS
+
+	ctx, err := api.ReadAndValidate(bytes.NewReader(content), conf)
+	if err != nil {
+		return nil, fmt.Errorf(i18n.T("PDF illisible: %w"), err)
+	}
+	if ctx.Encrypt != nil {
+		return nil, &ConformanceError{Problems: []string{i18n.T("le PDF est chiffré")}}
+	}
+	ctx.Write.Increment = true
+	ctx.Write.Offset = int64(len(content))
+
+	root, err := ctx.Catalog()
+	if err != nil {
+		return nil, err
+	}
+
+	// Intention de sortie : profil ICC des couleurs RGB du document
+	profile, err := ctx.NewStreamDictForBuf(sRGBProfile())
+	if err != nil {
+		return nil, err
+	}
+	profile.InsertInt("N", 3)
+	if err := profile.Encode(); err != nil {
+		return nil, err
+	}
+	profileRef, err := ctx.IndRefForNewObject(*profile)
+	if err != nil {
+		return nil, err
+	}
+	ctx.Write.IncrementWithObjNr(profileRef.ObjectNumber.Value())
+	root["OutputIntents"] = types.Array{types.Dict(map[string]types.Object{
+		"Type":                      types.Name("OutputIntent"),
+		"S":                         types.Name("GTS_PDFA1"),
+		"OutputConditionIdentifier": types.StringLiteral("sRGB IEC61966-2.1"),
+		"RegistryName":              types.StringLiteral("http://www.color.org"),
+		"Info":                      types.StringLiteral("sRGB IEC61966-2.1"),
+		"DestOutputProfile":         *profileRef,
+	})}
+
+	if err := archiveOptionalContent(ctx, root); err != nil {
+		return nil, err
+	}
+
+	if len(attachments) > 0 {
+		if err := attach(ctx, root, attachments); err != nil {
+			return nil, err
+		}
+	}
+
+	// Métadonnées XMP, non compressées, identiques au dictionnaire d'informations
+	metadata := types.StreamDict{
+		Dict: types.Dict(map[string]types.Object{
+			"Type":    types.Name("Metadata"),
+			"Subtype": types.Name("XML"),
+		}),
+		Content: xmpPacket(documentInfo(ctx)),
+	}
+	if err := metadata.Encode(); err != nil {
+		return nil, err
+	}
+	metadataRef, err := ctx.IndRefForNewObject(metadata)
+	if err != nil {
+		return nil, err
+	}
+	ctx.Write.IncrementWithObjNr(metadataRef.ObjectNumber.Value())
+	root["Metadata"] = *metadataRef
+
+	ctx.Write.IncrementWithObjNr(ctx.Root.ObjectNumber.Value())
+
+	var increment bytes.Buffer
+	if err := api.WriteIncrement(ctx, &increment); err != nil {
+		return nil, err
+	}
+	return increment.Bytes(), nil
+}
+
+// archiveOptionalContent met en conformité les calques du filigrane : PDF/A
+// exige un nom pour chaque configuration et y interdit l'affichage automatique
+// par usage (/AS)
+func archiveOptionalContent(ctx *model.Context, root types.Dict) error {
+	properties, err := ctx.DereferenceDict(root["OCProperties"])
+	if err != nil || properties == nil {
+		return err
+	}
+	if ref, ok := root["OCProperties"].(types.IndirectRef); ok {
+		ctx.Write.IncrementWithObjNr(ref.ObjectNumber.Value())
+	}
+
+	configs := types.Array{properties["D"]}
+	if others, err := ctx.DereferenceArray(properties["Configs"]); err == nil {
+		configs = append(configs, others...)
+	}
+	for i, o := range configs {
+		config, err := ctx.DereferenceDict(o)
+		if err != nil || config == nil {
+			return err
+		}
+		if ref, ok := o.(types.IndirectRef); ok {
+			ctx.Write.IncrementWithObjNr(ref.ObjectNumber.Value())
+		}
+		delete(config, "AS")
+		if config["Name"] == nil {
+			config["Name"] = types.StringLiteral(fmt.Sprintf("Configuration %d", i+1))
+		}
+	}
+	return nil
+}
+
+// attach ajoute les fichiers joints à l'arbre des fichiers incorporés et à la
+// liste /AF des fichiers associés au document
+func attach(ctx *model.Context, root types.Dict, attachments []Attachment) error {
+	names, err := ctx.DereferenceDict(root["Names"])
+	if err != nil {
+		return err
+	}
+	if names == nil {
+		names = types.NewDict()
+		root["Names"] = names
+	} else if ref, ok := root["Names"].(types.IndirectRef); ok {
+		ctx.Write.IncrementWithObjNr(ref.ObjectNumber.Value())
+	}
+	files, err := ctx.DereferenceDict(names["EmbeddedFiles"])
+	if err != nil {
+		return err
+	}
+	if files == nil {
+		files = types.NewDict()
+		names["EmbeddedFiles"] = files
+	} else if ref, ok := names["EmbeddedFiles"].(types.IndirectRef); ok {
+		ctx.Write.IncrementWithObjNr(ref.ObjectNumber.Value())
+	}
+	if files["Kids"] != nil {
+		return errors.New(i18n.T("arbre des fichiers joints du PDF non pris en charge"))
+	}
+
+	// Les clés d'un arbre de noms sont triées
+	type entry struct {
+		key   string
+		value types.Object
+	}
+	var entries []entry
+	existing, err := ctx.DereferenceArray(files["Names"])
+	if err != nil {
+		return err
+	}
+	for i := 0; i+1 < len(existing); i += 2 {
+		key, err := ctx.DereferenceText(existing[i])
+		if err != nil {
+			return err
+		}
+		entries = append(entries, entry{key, existing[i+1]})
+	}
+
+	af, _ := ctx.DereferenceArray(root["AF"])
+	for _, a := range attachments {
+		file, err := ctx.NewStreamDictForBuf(a.Data)
+		if err != nil {
+			return err
+		}
+		file.InsertName("Type", "EmbeddedFile")
+		file.InsertName("Subtype", a.MimeType)
+		file.Insert("Params", types.Dict(map[string]types.Object{
+			"Size":    types.Integer(len(a.Data)),
+			"ModDate": types.StringLiteral(types.DateString(a.ModTime)),
+		}))
+		if err := file.Encode(); err != nil {
+			return err
+		}
+		fileRef, err := ctx.IndRefForNewObject(*file)
+		if err != nil {
+			return err
+		}
+
+		spec, err := ctx.NewFileSpecDict(a.Name, a.Name, a.Description, *fileRef)
+		if err != nil {
+			return err
+		}
+		delete(spec, "CI")
+		spec.InsertName("AFRelationship", "Data")
+		specRef, err := ctx.IndRefForNewObject(spec)
+		if err != nil {
+			return err
+		}
+
+		ctx.Write.IncrementWithObjNr(fileRef.ObjectNumber.Value())
+		ctx.Write.IncrementWithObjNr(specRef.ObjectNumber.Value())
+		entries = append(entries, entry{a.Name, *specRef})
+		af = append(af, *specRef)
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool { return entries[i].key < entries[j].key })
+	list := types.Array{}
+	for _, e := range entries {
+		key, err := types.EscapedUTF16String(e.key)
+		if err != nil {
+			return err
+		}
+		list = append(list, types.StringLiteral(*key), e.value)
+	}
+	files["Names"] = list
+	root["AF"] = af
+	return nil
+}
+
+// documentInfo retourne les entrées du dictionnaire d'informations du PDF
+func documentInfo(ctx *model.Context) map[string]string {
+	info := map[string]string{}
+	if ctx.Info == nil {
+		return info
+	}
+	d, err := ctx.DereferenceDict(*ctx.Info)
+	if err != nil || d == nil {
+		return info
+	}
+	for key, o := range d {
+		if text, err := ctx.DereferenceText(o); err == nil {
+			info[key] = text
+		}
+	}
+	return info
+}
+
+// xmpPacket retourne les métadonnées XMP du PDF/A-3b, reprises du dictionnaire
+// d'informations comme l'exige la norme
+func xmpPacket(info map[string]string) []byte {
+	escape := func(s string) string {
+		var b strings.Builder
+		xml.EscapeText(&b, []byte(s))
+		return b.String()
+	}
+	date := func(s string) string {
+		t, ok := types.DateTime(s, true)
+		if !ok {
+			return ""
+		}
+		return t.Format("2006-01-02T15:04:05-07:00")
+	}
+
+	var b strings.Builder
+	b.WriteString("<?xpacket begin=\"\ufeff\" id=\"W5M0MpCehiHzreSzNTczkc9d\"?>\n")
+	b.WriteString("<x:xmpmeta xmlns:x=\"adobe:ns:meta/\">\n")
+	b.WriteString(" <rdf:RDF xmlns:rdf=\"http://www.w3.org/1999/02/22-rdf-syntax-ns#\">\n")
+	b.WriteString("  <rdf:Description rdf:about=\"\"\n")
+	b.WriteString("    xmlns:pdfaid=\"http://www.aiim.org/pdfa/ns/id/\"\n")
+	b.WriteString("    xmlns:dc=\"http://purl.org/dc/elements/1.1/\"\n")
+	b.WriteString("    xmlns:pdf=\"http://ns.adobe.com/pdf/1.3/\"\n")
+	b.WriteString("    xmlns:xmp=\"http://ns.adobe.com/xap/1.0/\">\n")
+	b.WriteString("   <pdfaid:part>3</pdfaid:part>\n")
+	b.WriteString("   <pdfaid:conformance>B</pdfaid:conformance>\n")
+	if title := info["Title"]; title != "" {
+		fmt.Fprintf(&b, "   <dc:title><rdf:Alt><rdf:li xml:lang=\"x-default\">%s</rdf:li></rdf:Alt></dc:title>\n", escape(title))
+	}
+	if author := info["Author"]; author != "" {
+		fmt.Fprintf(&b, "   <dc:creator><rdf:Seq><rdf:li>%s</rdf:li></rdf:Seq></dc:creator>\n", escape(author))
+	}
+	if subject := info["Subject"]; subject != "" {
+		fmt.Fprintf(&b, "   <dc:description><rdf:Alt><rdf:li xml:lang=\"x-default\">%s</rdf:li></rdf:Alt></dc:description>\n", escape(subject))
+	}
+	if keywords := info["Keywords"]; keywords != "" {
+		fmt.Fprintf(&b, "   <pdf:Keywords>%s</pdf:Keywords>\n", escape(keywords))
+	}
+	if producer := info["Producer"]; producer != "" {
+		fmt.Fprintf(&b, "   <pdf:Producer>%s</pdf:Producer>\n", escape(producer))
+	}
+	if creator := info["Creator"]; creator != "" {
+		fmt.Fprintf(&b, "   <xmp:CreatorTool>%s</xmp:CreatorTool>\n", escape(creator))
+	}
+	if created := date(info["CreationDate"]); created != "" {
+		fmt.Fprintf(&b, "   <xmp:CreateDate>%s</xmp:CreateDate>\n", created)
+	}
+	if modified := date(info["ModDate"]); modified != "" {
+		fmt.Fprintf(&b, "   <xmp:ModifyDate>%s</xmp:ModifyDate>\n", modified)
+	}
+	b.WriteString("  </rdf:Description>\n")
+	b.WriteString(" </rdf:RDF>\n")
+	b.WriteString("</x:xmpmeta>\n")
+	b.WriteString("<?xpacket end=\"w\"?>")
+	return []byte(b.String())
+}
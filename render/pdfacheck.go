@@ -0,0 +1,283 @@
+package render
+
+import (
+	"bytes"
+	"fmt"
+	"outbil/i18n"
+
+	"github.com/pdfcpu/pdfcpu/pkg/api"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+)
+
+// CheckArchive contrôle les exigences de PDF/A-3b que le document peut
+// enfreindre : chiffrement, métadonnées, intention de sortie, polices non
+// incorporées, JavaScript, calques, fichiers joints et annotations non
+// imprimables.
+// Il retourne la liste des écarts constatés, vide pour un PDF conforme.
+func CheckArchive(content []byte) ([]string, error) {
+	conf := model.NewDefaultConfiguration()
+	conf.Cmd = model.VALIDATE
+
+	ctx, err := api.ReadAndValidate(bytes.NewReader(content), conf)
+	if err != nil {
+		return nil, fmt.Errorf(i18n.T("PDF illisible: %w"), err)
+	}
+	c := &archiveCheck{ctx: ctx, seen: map[int]bool{}, reported: map[string]bool{}}
+
+	if ctx.Encrypt != nil {
+		c.report(i18n.T("le PDF est chiffré"))
+	}
+	if len(ctx.ID) == 0 {
+		c.report(i18n.T("identifiant du fichier (/ID) absent"))
+	}
+
+	root, err := ctx.Catalog()
+	if err != nil {
+		return nil, err
+	}
+	c.metadata(root)
+	c.outputIntent(root)
+	c.javaScript(root)
+	c.embeddedFiles(root)
+	c.optionalContent(root)
+
+	for i := 1; i <= ctx.PageCount; i++ {
+		page, _, inherited, err := ctx.PageDict(i, false)
+		if err != nil {
+			return nil, err
+		}
+		c.page = i
+		c.resources(page["Resources"])
+		if inherited != nil && inherited.Resources != nil {
+			c.resources(inherited.Resources)
+		}
+		c.annotations(page)
+	}
+	return c.problems, nil
+}
+
+// archiveCheck accumule les écarts à PDF/A, chacun signalé une fois
+type archiveCheck struct {
+	ctx      *model.Context
+	page     int          // page en cours de contrôle
+	seen     map[int]bool // objets déjà parcourus
+	reported map[string]bool
+	problems []string
+}
+
+func (c *archiveCheck) report(problem string) {
+	if !c.reported[problem] {
+		c.reported[problem] = true
+		c.problems = append(c.problems, problem)
+	}
+}
+
+// visit indique si un objet indirect reste à parcourir
+func (c *archiveCheck) visit(o types.Object) bool {
+	ref, ok := o.(types.IndirectRef)
+	if !ok {
+		return true
+	}
+	if c.seen[ref.ObjectNumber.Value()] {
+		return false
+	}
+	c.seen[ref.ObjectNumber.Value()] = true
+	return true
+}
+
+func (c *archiveCheck) metadata(root types.Dict) {
+	sd, _, err := c.ctx.DereferenceStreamDict(root["Metadata"])
+	if err != nil || sd == nil {
+		c.report(i18n.T("métadonnées XMP absentes"))
+		return
+	}
+	if err := sd.Decode(); err != nil || !bytes.Contains(sd.Content, []byte("<pdfaid:part>3</pdfaid:part>")) {
+		c.report(i18n.T("identification PDF/A-3 absente des métadonnées XMP"))
+	}
+}
+
+func (c *archiveCheck) outputIntent(root types.Dict) {
+	intents, _ := c.ctx.DereferenceArray(root["OutputIntents"])
+	for _, o := range intents {
+		intent, err := c.ctx.DereferenceDict(o)
+		if err != nil || intent == nil {
+			continue
+		}
+		if s := intent.NameEntry("S"); s != nil && *s == "GTS_PDFA1" && intent["DestOutputProfile"] != nil {
+			return
+		}
+	}
+	c.report(i18n.T("intention de sortie PDF/A (profil de couleurs) absente"))
+}
+
+func (c *archiveCheck) javaScript(root types.Dict) {
+	names, _ := c.ctx.DereferenceDict(root["Names"])
+	if names != nil && names["JavaScript"] != nil || root["AA"] != nil {
+		c.report(i18n.T("le PDF contient du JavaScript ou des actions automatiques"))
+		return
+	}
+	if action, _ := c.ctx.DereferenceDict(root["OpenAction"]); action != nil {
+		if s := action.NameEntry("S"); s != nil && *s == "JavaScript" {
+			c.report(i18n.T("le PDF contient du JavaScript ou des actions automatiques"))
+		}
+	}
+}
+
+// optionalContent contrôle les configurations des calques : nommées et sans
+// affichage automatique par usage
+func (c *archiveCheck) optionalContent(root types.Dict) {
+	properties, _ := c.ctx.DereferenceDict(root["OCProperties"])
+	if properties == nil {
+		return
+	}
+	configs := types.Array{properties["D"]}
+	if others, err := c.ctx.DereferenceArray(properties["Configs"]); err == nil {
+		configs = append(configs, others...)
+	}
+	for _, o := range configs {
+		config, _ := c.ctx.DereferenceDict(o)
+		if config != nil && (config["AS"] != nil || config["Name"] == nil) {
+			c.report(i18n.T("configuration des calques non conforme (/Name absent ou /AS présent)"))
+		}
+	}
+}
+
+// embeddedFiles contrôle que chaque fichier joint déclare sa relation au
+// document et son type MIME
+func (c *archiveCheck) embeddedFiles(root types.Dict) {
+	names, _ := c.ctx.DereferenceDict(root["Names"])
+	if names == nil {
+		return
+	}
+	files, _ := c.ctx.DereferenceDict(names["EmbeddedFiles"])
+	if files == nil {
+		return
+	}
+	list, _ := c.ctx.DereferenceArray(files["Names"])
+	for i := 0; i+1 < len(list); i += 2 {
+		name, _ := c.ctx.DereferenceText(list[i])
+		spec, err := c.ctx.DereferenceDict(list[i+1])
+		if err != nil || spec == nil {
+			continue
+		}
+		if spec["AFRelationship"] == nil {
+			c.report(fmt.Sprintf(i18n.T("fichier joint %s sans relation au document (/AFRelationship)"), name))
+		}
+		ef, _ := c.ctx.DereferenceDict(spec["EF"])
+		if ef == nil {
+			continue
+		}
+		if sd, _, err := c.ctx.DereferenceStreamDict(ef["F"]); err == nil && sd != nil && sd.NameEntry("Subtype") == nil {
+			c.report(fmt.Sprintf(i18n.T("fichier joint %s sans type MIME"), name))
+		}
+	}
+}
+
+// resources contrôle les polices d'un dictionnaire de ressources et des
+// formulaires qu'il utilise
+func (c *archiveCheck) resources(o types.Object) {
+	if !c.visit(o) {
+		return
+	}
+	res, err := c.ctx.DereferenceDict(o)
+	if err != nil || res == nil {
+		return
+	}
+
+	fonts, _ := c.ctx.DereferenceDict(res["Font"])
+	for _, f := range fonts {
+		if !c.visit(f) {
+			continue
+		}
+		if font, err := c.ctx.DereferenceDict(f); err == nil && font != nil && !c.fontEmbedded(font) {
+			name := ""
+			if base := font.NameEntry("BaseFont"); base != nil {
+				name = *base
+			}
+			c.report(fmt.Sprintf(i18n.T("police %s non incorporée (page %d)"), name, c.page))
+		}
+	}
+
+	xobjects, _ := c.ctx.DereferenceDict(res["XObject"])
+	for _, x := range xobjects {
+		c.form(x)
+	}
+}
+
+// form contrôle les ressources d'un formulaire (XObject ou apparence)
+func (c *archiveCheck) form(o types.Object) {
+	if !c.visit(o) {
+		return
+	}
+	sd, _, err := c.ctx.DereferenceStreamDict(o)
+	if err != nil || sd == nil {
+		return
+	}
+	if subtype := sd.NameEntry("Subtype"); subtype != nil && *subtype == "Form" {
+		c.resources(sd.Dict["Resources"])
+	}
+}
+
+// fontEmbedded indique si le programme d'une police est incorporé ; les
+// polices Type3 sont décrites dans le PDF lui-même
+func (c *archiveCheck) fontEmbedded(font types.Dict) bool {
+	switch subtype := font.NameEntry("Subtype"); {
+	case subtype != nil && *subtype == "Type3":
+		return true
+	case subtype != nil && *subtype == "Type0":
+		descendants, _ := c.ctx.DereferenceArray(font["DescendantFonts"])
+		if len(descendants) == 0 {
+			return false
+		}
+		descendant, err := c.ctx.DereferenceDict(descendants[0])
+		if err != nil || descendant == nil {
+			return false
+		}
+		font = descendant
+	}
+	descriptor, err := c.ctx.DereferenceDict(font["FontDescriptor"])
+	if err != nil || descriptor == nil {
+		return false
+	}
+	return descriptor["FontFile"] != nil || descriptor["FontFile2"] != nil || descriptor["FontFile3"] != nil
+}
+
+// annotations contrôle que les annotations d'une page sont imprimées et que
+// leurs apparences n'utilisent que des polices incorporées
+func (c *archiveCheck) annotations(page types.Dict) {
+	annots, _ := c.ctx.DereferenceArray(page["Annots"])
+	for _, o := range annots {
+		annot, err := c.ctx.DereferenceDict(o)
+		if err != nil || annot == nil {
+			continue
+		}
+		subtype := ""
+		if s := annot.NameEntry("Subtype"); s != nil {
+			subtype = *s
+		}
+		if subtype == "Popup" {
+			continue
+		}
+		// Bit 3 : imprimée ; bits 1, 2 et 6 : invisible, masquée, non affichée
+		flags := 0
+		if f := annot.IntEntry("F"); f != nil {
+			flags = *f
+		}
+		if flags&4 == 0 || flags&(1|2|32) != 0 {
+			c.report(fmt.Sprintf(i18n.T("annotation %s non imprimable page %d"), subtype, c.page))
+		}
+		ap, _ := c.ctx.DereferenceDict(annot["AP"])
+		for _, appearance := range ap {
+			// Apparence unique, ou une apparence par état
+			if sd, _, err := c.ctx.DereferenceStreamDict(appearance); err == nil && sd != nil {
+				c.form(appearance)
+				continue
+			}
+			states, _ := c.ctx.DereferenceDict(appearance)
+			for _, state := range states {
+				c.form(state)
+			}
+		}
+	}
+}
@@ -0,0 +1,16 @@
+// Package render met en page les documents (devis) en PDF selon un thème.
+package render
+
+// Renderer produit le PDF d'un document
+type Renderer interface {
+	Render(doc *Document) ([]byte, error)
+}
+
+// New retourne le moteur de rendu PDF d'un thème : Maroto, ou un modèle HTML
+// converti en PDF pour les thèmes "engine": "html"
+func New(theme *Theme) Renderer {
+	if theme.Engine == EngineHTML {
+		return &htmlRenderer{theme: theme}
+	}
+	return &marotoRenderer{theme: theme}
+}
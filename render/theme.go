@@ -0,0 +1,583 @@
+package render
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"outbil/i18n"
+	"outbil/models"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"golang.org/x/image/font/gofont/gobold"
+	"golang.org/x/image/font/gofont/goregular"
+)
+
+// Blocs d'un document, dans l'ordre choisi par le thème. Les mentions légales
+// sont toujours imprimées en pied de page et ne font pas partie des blocs.
+const (
+	BlockHeader  = "header"  // entreprise et logo
+	BlockTitle   = "title"   // titre et numéro du document
+	BlockDates   = "dates"   // date et fin de validité
+	BlockParties = "parties" // client et adresse de livraison
+	BlockItems   = "items"   // tableau des lignes
+	BlockTotals  = "totals"  // sous-total, remise, TVA et total
+	BlockPayment = "payment" // coordonnées bancaires et QR code
+	BlockNotes   = "notes"
+	BlockTerms   = "terms" // conditions de paiement
+)
+
+// Colonnes du tableau des lignes
+const (
+	FieldDescription = "description"
+	FieldQuantity    = "quantity"
+	FieldUnitPrice   = "unit_price"
+	FieldTaxRate     = "tax_rate"
+	FieldAmount      = "amount"
+)
+
+// Bordures du tableau des lignes
+const (
+	BorderFull = "full" // toutes les cellules encadrées
+	BorderRows = "rows" // un trait sous chaque ligne
+	BorderNone = "none"
+)
+
+// Filigranes imprimés en travers des pages selon le statut du document
+const (
+	WatermarkDraft     = "draft"
+	WatermarkCancelled = "cancelled"
+)
+
+// Moteurs de rendu
+const (
+	EngineMaroto = "maroto" // mise en page décrite par le thème
+	EngineHTML   = "html"   // modèle html/template converti en PDF
+)
+
+const DefaultTheme = "classic"
+
+var knownBlocks = []string{BlockHeader, BlockTitle, BlockDates, BlockParties, BlockItems, BlockTotals, BlockPayment, BlockNotes, BlockTerms}
+
+var requiredBlocks = []string{BlockTitle, BlockItems, BlockTotals}
+
+var knownWatermarks = []string{WatermarkDraft, WatermarkCancelled}
+
+// documentStatuses sont les statuts auxquels un thème peut associer un filigrane
+var documentStatuses = []string{models.StatusDraft, models.StatusSent, models.StatusAccepted, models.StatusRejected, models.StatusExpired}
+
+var knownFields = []string{FieldDescription, FieldQuantity, FieldUnitPrice, FieldTaxRate, FieldAmount}
+
+// standardFonts sont les polices des PDFs utilisables sans fichier TrueType
+var standardFonts = []string{"arial", "helvetica", "courier"}
+
+// Color est une couleur RVB, écrite "#RRGGBB" dans les fichiers de thème
+type Color struct {
+	Red, Green, Blue int
+}
+
+func (c Color) String() string {
+	return fmt.Sprintf("#%02x%02x%02x", c.Red, c.Green, c.Blue)
+}
+
+func (c Color) MarshalJSON() ([]byte, error) {
+	return json.Marshal(c.String())
+}
+
+func (c *Color) UnmarshalJSON(data []byte) error {
+	var value string
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+	parsed, err := ParseColor(value)
+	if err != nil {
+		return err
+	}
+	*c = parsed
+	return nil
+}
+
+// ParseColor lit une couleur au format #RRGGBB ou #RGB
+func ParseColor(value string) (Color, error) {
+	hex := strings.TrimPrefix(strings.TrimSpace(value), "#")
+	if len(hex) == 3 {
+		hex = string([]byte{hex[0], hex[0], hex[1], hex[1], hex[2], hex[2]})
+	}
+	var c Color
+	if len(hex) != 6 {
+		return c, fmt.Errorf(i18n.T("couleur invalide %q (format attendu #RRGGBB)"), value)
+	}
+	if _, err := fmt.Sscanf(hex, "%02x%02x%02x", &c.Red, &c.Green, &c.Blue); err != nil {
+		return c, fmt.Errorf(i18n.T("couleur invalide %q (format attendu #RRGGBB)"), value)
+	}
+	return c, nil
+}
+
+// Colors regroupe les couleurs d'un thème
+type Colors struct {
+	Text             Color `json:"text"`
+	Accent           Color `json:"accent"` // nom de l'entreprise, titre et total
+	Muted            Color `json:"muted"`  // pied de page
+	HeaderBackground Color `json:"header_background"`
+	HeaderText       Color `json:"header_text"`
+	Border           Color `json:"border"`
+}
+
+// Column est une colonne du tableau des lignes. Les largeurs sont exprimées
+// sur une grille de 12.
+type Column struct {
+	Field string `json:"field"`
+	Width int    `json:"width"`
+}
+
+// FontFiles désigne les fichiers TrueType d'une police personnalisée. Les
+// chemins relatifs partent du dossier du fichier de thème.
+type FontFiles struct {
+	Regular string `json:"regular"`
+	Bold    string `json:"bold,omitempty"` // le style normal à défaut
+}
+
+// Watermark règle le filigrane tamponné sur les pages du document. Statuses
+// associe un statut (draft, sent...) à un filigrane (draft, cancelled) ;
+// un statut absent ou associé à "" n'est pas tamponné.
+type Watermark struct {
+	Statuses map[string]string `json:"statuses"`
+	Color    Color             `json:"color"`
+	Opacity  float64           `json:"opacity"`  // de 0.05 à 1
+	Rotation float64           `json:"rotation"` // en degrés
+	Scale    float64           `json:"scale"`    // largeur relative à la page, de 0.1 à 1
+}
+
+// defaultWatermark est le filigrane des thèmes intégrés : les brouillons, les
+// devis refusés et les devis expirés sont tamponnés en rouge
+var defaultWatermark = Watermark{
+	Statuses: map[string]string{
+		models.StatusDraft:    WatermarkDraft,
+		models.StatusRejected: WatermarkCancelled,
+		models.StatusExpired:  WatermarkCancelled,
+	},
+	Color:    Color{200, 30, 30},
+	Opacity:  0.25,
+	Rotation: 45,
+	Scale:    0.8,
+}
+
+// Theme décrit la mise en page d'un document : polices, couleurs, colonnes du
+// tableau et blocs imprimés, dans leur ordre
+type Theme struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	Extends     string `json:"extends,omitempty"` // thème de base d'un fichier utilisateur
+
+	Engine   string `json:"engine,omitempty"`   // maroto (par défaut) ou html
+	Template string `json:"template,omitempty"` // modèle html/template, le modèle intégré à défaut
+
+	Font       string     `json:"font"`        // arial, helvetica, courier ou nom de la police de font_files
+	NumberFont string     `json:"number_font"` // police des quantités et des montants
+	FontFiles  *FontFiles `json:"font_files,omitempty"`
+	FontSize   float64    `json:"font_size"`
+	TitleSize  float64    `json:"title_size"`
+	Margin     float64    `json:"margin"` // en mm
+
+	TitleAlign  string `json:"title_align"`  // left, center ou right
+	TableBorder string `json:"table_border"` // full, rows ou none
+
+	Colors    Colors    `json:"colors"`
+	Columns   []Column  `json:"columns"`
+	Blocks    []string  `json:"blocks"`
+	Watermark Watermark `json:"watermark"`
+
+	dir     string // dossier du fichier de thème
+	archive bool   // rendu PDF/A, polices incorporées
+}
+
+var (
+	black = Color{0, 0, 0}
+	white = Color{255, 255, 255}
+)
+
+var builtinThemes = map[string]Theme{
+	"classic": {
+		Name:        "classic",
+		Description: "Mise en page historique : titre centré, tableau encadré, montants en police à chasse fixe",
+		Font:        "arial",
+		NumberFont:  "courier",
+		FontSize:    10,
+		TitleSize:   20,
+		Margin:      15,
+		TitleAlign:  "center",
+		TableBorder: BorderFull,
+		Colors: Colors{
+			Text:             black,
+			Accent:           black,
+			Muted:            Color{100, 100, 100},
+			HeaderBackground: Color{240, 240, 240},
+			HeaderText:       black,
+			Border:           black,
+		},
+		Columns: []Column{
+			{FieldDescription, 5}, {FieldQuantity, 1}, {FieldUnitPrice, 2}, {FieldTaxRate, 1}, {FieldAmount, 3},
+		},
+		Blocks:    []string{BlockHeader, BlockTitle, BlockDates, BlockParties, BlockItems, BlockTotals, BlockPayment, BlockNotes, BlockTerms},
+		Watermark: defaultWatermark,
+	},
+	"modern": {
+		Name:        "modern",
+		Description: "Titre à gauche, en-tête de tableau coloré, lignes séparées par un simple trait",
+		Font:        "helvetica",
+		NumberFont:  "helvetica",
+		FontSize:    10,
+		TitleSize:   22,
+		Margin:      18,
+		TitleAlign:  "left",
+		TableBorder: BorderRows,
+		Colors: Colors{
+			Text:             Color{33, 37, 41},
+			Accent:           Color{31, 78, 121},
+			Muted:            Color{120, 120, 120},
+			HeaderBackground: Color{31, 78, 121},
+			HeaderText:       white,
+			Border:           Color{200, 205, 210},
+		},
+		Columns: []Column{
+			{FieldDescription, 6}, {FieldQuantity, 1}, {FieldUnitPrice, 2}, {FieldTaxRate, 1}, {FieldAmount, 2},
+		},
+		Blocks:    []string{BlockHeader, BlockTitle, BlockDates, BlockParties, BlockItems, BlockTotals, BlockPayment, BlockNotes, BlockTerms},
+		Watermark: defaultWatermark,
+	},
+	"minimal": {
+		Name:        "minimal",
+		Description: "Sobre : sans couleur ni bordure, client avant les dates, paiement en fin de document",
+		Font:        "helvetica",
+		NumberFont:  "helvetica",
+		FontSize:    9,
+		TitleSize:   16,
+		Margin:      20,
+		TitleAlign:  "left",
+		TableBorder: BorderNone,
+		Colors: Colors{
+			Text:             black,
+			Accent:           black,
+			Muted:            Color{130, 130, 130},
+			HeaderBackground: white,
+			HeaderText:       black,
+			Border:           Color{180, 180, 180},
+		},
+		Columns: []Column{
+			{FieldDescription, 6}, {FieldQuantity, 1}, {FieldUnitPrice, 2}, {FieldTaxRate, 1}, {FieldAmount, 2},
+		},
+		Blocks:    []string{BlockHeader, BlockTitle, BlockParties, BlockDates, BlockItems, BlockTotals, BlockNotes, BlockTerms, BlockPayment},
+		Watermark: defaultWatermark,
+	},
+	"html": {
+		Name:        "html",
+		Description: "Modèle HTML intégré converti en PDF, base des modèles personnalisés",
+		Engine:      EngineHTML,
+		Font:        "helvetica",
+		NumberFont:  "helvetica",
+		FontSize:    10,
+		TitleSize:   20,
+		Margin:      15,
+		TitleAlign:  "left",
+		TableBorder: BorderRows,
+		Colors: Colors{
+			Text:             Color{33, 37, 41},
+			Accent:           Color{31, 78, 121},
+			Muted:            Color{120, 120, 120},
+			HeaderBackground: Color{233, 239, 245},
+			HeaderText:       Color{31, 78, 121},
+			Border:           Color{200, 205, 210},
+		},
+		Columns: []Column{
+			{FieldDescription, 6}, {FieldQuantity, 1}, {FieldUnitPrice, 2}, {FieldTaxRate, 1}, {FieldAmount, 2},
+		},
+		Blocks:    []string{BlockHeader, BlockTitle, BlockDates, BlockParties, BlockItems, BlockTotals, BlockPayment, BlockNotes, BlockTerms},
+		Watermark: defaultWatermark,
+	},
+}
+
+// BuiltinThemes retourne les noms des thèmes intégrés
+func BuiltinThemes() []string {
+	names := make([]string, 0, len(builtinThemes))
+	for name := range builtinThemes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Builtin retourne une copie d'un thème intégré
+func Builtin(name string) (*Theme, bool) {
+	theme, ok := builtinThemes[strings.ToLower(strings.TrimSpace(name))]
+	if !ok {
+		return nil, false
+	}
+	theme.Columns = append([]Column(nil), theme.Columns...)
+	theme.Blocks = append([]string(nil), theme.Blocks...)
+	statuses := theme.Watermark.Statuses
+	theme.Watermark.Statuses = make(map[string]string, len(statuses))
+	for status, watermark := range statuses {
+		theme.Watermark.Statuses[status] = watermark
+	}
+	return &theme, true
+}
+
+// UserThemes retourne les noms des fichiers de thème d'un dossier (sans .json)
+func UserThemes(dir string) []string {
+	files, _ := filepath.Glob(filepath.Join(dir, "*.json"))
+	names := make([]string, 0, len(files))
+	for _, file := range files {
+		names = append(names, strings.TrimSuffix(filepath.Base(file), ".json"))
+	}
+	sort.Strings(names)
+	return names
+}
+
+// LoadTheme charge un thème par son nom : un thème intégré, un fichier
+// <nom>.json du dossier des thèmes utilisateur, ou un chemin vers un fichier
+func LoadTheme(name, dir string) (*Theme, error) {
+	if name == "" {
+		name = DefaultTheme
+	}
+	if !strings.HasSuffix(strings.ToLower(name), ".json") && !strings.ContainsAny(name, `/\`) {
+		if theme, ok := Builtin(name); ok {
+			return theme, nil
+		}
+		name = filepath.Join(dir, name+".json")
+		if _, err := os.Stat(name); err != nil {
+			return nil, fmt.Errorf(i18n.T("thème %q introuvable (thèmes intégrés: %s)"),
+				strings.TrimSuffix(filepath.Base(name), ".json"), strings.Join(BuiltinThemes(), ", "))
+		}
+	}
+	return LoadThemeFile(name)
+}
+
+// LoadThemeFile lit un fichier de thème JSON. Les valeurs absentes du fichier
+// sont celles du thème indiqué par "extends" (classic par défaut).
+func LoadThemeFile(path string) (*Theme, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var header struct {
+		Extends string `json:"extends"`
+	}
+	if err := json.Unmarshal(data, &header); err != nil {
+		return nil, fmt.Errorf("%s: %w", filepath.Base(path), err)
+	}
+	if header.Extends == "" {
+		header.Extends = DefaultTheme
+	}
+	theme, ok := Builtin(header.Extends)
+	if !ok {
+		return nil, fmt.Errorf(i18n.T("%s: thème de base %q inconnu (%s)"), filepath.Base(path), header.Extends, strings.Join(BuiltinThemes(), ", "))
+	}
+
+	theme.Description = ""
+	if err := json.Unmarshal(data, theme); err != nil {
+		return nil, fmt.Errorf("%s: %w", filepath.Base(path), err)
+	}
+	// Le nom du thème est celui du fichier, même copié depuis un autre thème
+	theme.Name = strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	theme.dir = filepath.Dir(path)
+	theme.Font = normalizeFont(theme.Font)
+	theme.NumberFont = normalizeFont(theme.NumberFont)
+
+	if err := theme.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w", filepath.Base(path), err)
+	}
+	return theme, nil
+}
+
+// Validate vérifie la cohérence d'un thème
+func (t *Theme) Validate() error {
+	switch t.Engine {
+	case "", EngineMaroto:
+		if t.Template != "" {
+			return errors.New(i18n.T("template: un modèle HTML demande \"engine\": \"html\""))
+		}
+	case EngineHTML:
+		if t.Template != "" {
+			if _, err := os.Stat(t.filePath(t.Template)); err != nil {
+				return fmt.Errorf("template: %w", err)
+			}
+		}
+	default:
+		return fmt.Errorf(i18n.T("engine %q inconnu (maroto ou html)"), t.Engine)
+	}
+
+	if t.FontFiles != nil {
+		if t.FontFiles.Regular == "" {
+			return errors.New(i18n.T("font_files: le fichier \"regular\" est obligatoire"))
+		}
+		if contains(standardFonts, strings.ToLower(t.Font)) {
+			return fmt.Errorf(i18n.T("font: choisissez un nom propre à la police TrueType (pas %s)"), t.Font)
+		}
+		for _, file := range []string{t.FontFiles.Regular, t.FontFiles.Bold} {
+			if file == "" {
+				continue
+			}
+			if _, err := os.Stat(t.filePath(file)); err != nil {
+				return fmt.Errorf("font_files: %w", err)
+			}
+		}
+	}
+	for _, font := range []string{t.Font, t.NumberFont} {
+		if !t.isFont(font) {
+			return fmt.Errorf(i18n.T("police %q inconnue (%s, ou une police déclarée dans font_files)"), font, strings.Join(standardFonts, ", "))
+		}
+	}
+
+	if t.FontSize < 6 || t.FontSize > 16 {
+		return errors.New(i18n.T("font_size doit être compris entre 6 et 16"))
+	}
+	if t.TitleSize < t.FontSize || t.TitleSize > 40 {
+		return errors.New(i18n.T("title_size doit être compris entre font_size et 40"))
+	}
+	if t.Margin < 5 || t.Margin > 40 {
+		return errors.New(i18n.T("margin doit être compris entre 5 et 40 mm"))
+	}
+	if !contains([]string{"left", "center", "right"}, t.TitleAlign) {
+		return fmt.Errorf(i18n.T("title_align %q invalide (left, center ou right)"), t.TitleAlign)
+	}
+	if !contains([]string{BorderFull, BorderRows, BorderNone}, t.TableBorder) {
+		return fmt.Errorf(i18n.T("table_border %q invalide (full, rows ou none)"), t.TableBorder)
+	}
+
+	width := 0
+	seen := make(map[string]bool)
+	for _, column := range t.Columns {
+		if !contains(knownFields, column.Field) {
+			return fmt.Errorf(i18n.T("colonne %q inconnue (%s)"), column.Field, strings.Join(knownFields, ", "))
+		}
+		if seen[column.Field] {
+			return fmt.Errorf(i18n.T("colonne %q en double"), column.Field)
+		}
+		if column.Width < 1 {
+			return fmt.Errorf(i18n.T("colonne %q: largeur invalide"), column.Field)
+		}
+		seen[column.Field] = true
+		width += column.Width
+	}
+	if !seen[FieldDescription] || !seen[FieldAmount] {
+		return fmt.Errorf(i18n.T("les colonnes %s et %s sont obligatoires"), FieldDescription, FieldAmount)
+	}
+	if width != 12 {
+		return fmt.Errorf(i18n.T("la somme des largeurs de colonnes doit valoir 12 (actuellement %d)"), width)
+	}
+
+	seen = make(map[string]bool)
+	for _, block := range t.Blocks {
+		if !contains(knownBlocks, block) {
+			return fmt.Errorf(i18n.T("bloc %q inconnu (%s)"), block, strings.Join(knownBlocks, ", "))
+		}
+		if seen[block] {
+			return fmt.Errorf(i18n.T("bloc %q en double"), block)
+		}
+		seen[block] = true
+	}
+	for _, block := range requiredBlocks {
+		if !seen[block] {
+			return fmt.Errorf(i18n.T("le bloc %q est obligatoire"), block)
+		}
+	}
+
+	for status, watermark := range t.Watermark.Statuses {
+		if !contains(documentStatuses, status) {
+			return fmt.Errorf(i18n.T("watermark: statut %q inconnu (%s)"), status, strings.Join(documentStatuses, ", "))
+		}
+		if watermark != "" && !contains(knownWatermarks, watermark) {
+			return fmt.Errorf(i18n.T("watermark: filigrane %q inconnu (%s)"), watermark, strings.Join(knownWatermarks, ", "))
+		}
+	}
+	if t.Watermark.Opacity < 0.05 || t.Watermark.Opacity > 1 {
+		return errors.New(i18n.T("watermark: opacity doit être comprise entre 0.05 et 1"))
+	}
+	if t.Watermark.Scale < 0.1 || t.Watermark.Scale > 1 {
+		return errors.New(i18n.T("watermark: scale doit être compris entre 0.1 et 1"))
+	}
+	return nil
+}
+
+// JSON retourne le thème au format des fichiers de thème
+func (t *Theme) JSON() ([]byte, error) {
+	return json.MarshalIndent(t, "", "  ")
+}
+
+// isFont indique si une police est standard ou déclarée dans font_files
+func (t *Theme) isFont(font string) bool {
+	if contains(standardFonts, strings.ToLower(font)) {
+		return true
+	}
+	return t.customFont() && font == t.Font
+}
+
+// archiveFont est la police incorporée aux PDF/A à la place des polices standard
+const archiveFont = "Go"
+
+// Archival retourne le thème adapté aux PDF/A, qui doivent incorporer toutes
+// leurs polices : les polices standard sont remplacées par les polices Go
+func (t *Theme) Archival() *Theme {
+	archival := *t
+	archival.archive = true
+	if t.FontFiles == nil {
+		archival.Font = archiveFont
+	}
+	if contains(standardFonts, archival.NumberFont) {
+		archival.NumberFont = archival.Font
+	}
+	return &archival
+}
+
+// customFont indique si la police du thème est une police TrueType incorporée
+func (t *Theme) customFont() bool {
+	return t.FontFiles != nil || t.archive
+}
+
+// fontData retourne la police TrueType du thème, en style normal et gras.
+// Les données sont propres à chaque appel : gofpdf écrit dans les polices
+// qu'il incorpore, et les PDFs d'un lot sont rendus en parallèle.
+func (t *Theme) fontData() (regular, bold []byte, err error) {
+	if t.FontFiles == nil {
+		return bytes.Clone(goregular.TTF), bytes.Clone(gobold.TTF), nil
+	}
+	if regular, err = os.ReadFile(t.filePath(t.FontFiles.Regular)); err != nil {
+		return nil, nil, fmt.Errorf(i18n.T("erreur lors du chargement des polices: %w"), err)
+	}
+	if t.FontFiles.Bold == "" {
+		return regular, bytes.Clone(regular), nil
+	}
+	if bold, err = os.ReadFile(t.filePath(t.FontFiles.Bold)); err != nil {
+		return nil, nil, fmt.Errorf(i18n.T("erreur lors du chargement des polices: %w"), err)
+	}
+	return regular, bold, nil
+}
+
+// filePath résout un chemin relatif au dossier du fichier de thème
+func (t *Theme) filePath(file string) string {
+	if filepath.IsAbs(file) || t.dir == "" {
+		return file
+	}
+	return filepath.Join(t.dir, file)
+}
+
+// normalizeFont écrit les polices standard en minuscules, comme les attend Maroto
+func normalizeFont(font string) string {
+	if contains(standardFonts, strings.ToLower(font)) {
+		return strings.ToLower(font)
+	}
+	return font
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,56 @@
+package render
+
+import (
+	"bytes"
+	"fmt"
+	"outbil/i18n"
+
+	"github.com/pdfcpu/pdfcpu/pkg/api"
+	"github.com/pdfcpu/pdfcpu/pkg/font"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+)
+
+// Stamp tamponne sur chaque page d'un PDF le filigrane que le thème associe
+// au statut du document. Sans filigrane pour ce statut, le PDF est inchangé.
+func Stamp(pdf []byte, theme *Theme, doc *Document) ([]byte, error) {
+	text := doc.Watermarks[theme.Watermark.Statuses[doc.Status]]
+	if text == "" {
+		return pdf, nil
+	}
+
+	fontName := "Helvetica-Bold"
+	if theme.archive {
+		var err error
+		if fontName, err = archiveStampFont(); err != nil {
+			return nil, fmt.Errorf(i18n.T("erreur lors de l'ajout du filigrane: %w"), err)
+		}
+	}
+
+	w := theme.Watermark
+	desc := fmt.Sprintf("fontname:%s, scalefactor:%.2f rel, rotation:%.0f, opacity:%.2f, fillcolor:%s, strokecolor:%s",
+		fontName, w.Scale, w.Rotation, w.Opacity, w.Color, w.Color)
+	// Tampon au-dessus du contenu, pour qu'il reste visible sur les fonds colorés
+	stamp, err := api.TextWatermark(text, desc, true, false, types.POINTS)
+	if err != nil {
+		return nil, fmt.Errorf(i18n.T("filigrane invalide: %w"), err)
+	}
+
+	var stamped bytes.Buffer
+	if err := api.AddWatermarks(bytes.NewReader(pdf), &stamped, nil, stamp, nil); err != nil {
+		return nil, fmt.Errorf(i18n.T("erreur lors de l'ajout du filigrane: %w"), err)
+	}
+	return stamped.Bytes(), nil
+}
+
+// archiveStampFont retourne la police TrueType que pdfcpu installe avec sa
+// configuration : les polices standard ne sont pas incorporées, ce qu'interdit
+// PDF/A
+func archiveStampFont() (string, error) {
+	const name = "Roboto-Regular"
+	model.NewDefaultConfiguration()
+	if !font.IsUserFont(name) {
+		return "", fmt.Errorf(i18n.T("police %s introuvable dans %s"), name, font.UserFontDir)
+	}
+	return name, nil
+}
@@ -0,0 +1,281 @@
+// Package signature signe les PDFs générés avec un certificat PKCS#12 local et
+// vérifie leurs signatures hors ligne.
+//
+// La signature est ajoutée en mise à jour incrémentale, sans toucher aux octets
+// du document signé, au format PAdES : signature CMS détachée
+// (ETSI.CAdES.detached) portant l'attribut signing-certificate-v2.
+package signature
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/asn1"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"outbil/i18n"
+	"strings"
+	"time"
+
+	"github.com/digitorus/pkcs7"
+	"github.com/pdfcpu/pdfcpu/pkg/api"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+	"software.sslmate.com/src/go-pkcs12"
+)
+
+// Taille réservée à la signature CMS (certificats compris)
+const contentsSize = 16384
+
+// Valeurs provisoires de /ByteRange, remplacées une fois le fichier écrit ;
+// elles réservent la place des vraies valeurs
+const byteRangePlaceholder = 9999999999
+
+var oidSigningCertificateV2 = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 9, 16, 2, 47}
+
+// Signer est un certificat de signature et sa clé privée
+type Signer struct {
+	Certificate *x509.Certificate
+	Chain       []*x509.Certificate // certificats intermédiaires, joints à la signature
+	key         crypto.Signer
+}
+
+// Load lit un certificat PKCS#12 (.p12, .pfx) protégé par un mot de passe
+func Load(path, password string) (*Signer, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf(i18n.T("erreur de lecture du certificat: %w"), err)
+	}
+
+	key, cert, chain, err := pkcs12.DecodeChain(data, password)
+	if err != nil {
+		return nil, fmt.Errorf(i18n.T("certificat PKCS#12 illisible: %w"), err)
+	}
+	signer, ok := key.(crypto.Signer)
+	if !ok {
+		return nil, errors.New(i18n.T("clé privée du certificat non prise en charge"))
+	}
+	if cert.KeyUsage != 0 && cert.KeyUsage&(x509.KeyUsageDigitalSignature|x509.KeyUsageContentCommitment) == 0 {
+		return nil, fmt.Errorf(i18n.T("le certificat %s n'est pas destiné à la signature"), Name(cert))
+	}
+	return &Signer{Certificate: cert, Chain: chain, key: signer}, nil
+}
+
+// Name retourne le nom du titulaire d'un certificat
+func Name(cert *x509.Certificate) string {
+	if cert.Subject.CommonName != "" {
+		return cert.Subject.CommonName
+	}
+	return cert.Subject.String()
+}
+
+// Options complète le dictionnaire de signature
+type Options struct {
+	Location string
+	Reason   string
+	Time     time.Time // maintenant si vide
+}
+
+// Sign ajoute au PDF une signature invisible sur la première page. La
+// configuration porte les mots de passe d'un PDF chiffré : le mot de passe
+// propriétaire est nécessaire pour y ajouter la signature.
+func (s *Signer) Sign(content []byte, opts Options, conf *model.Configuration) ([]byte, error) {
+	if time.Now().After(s.Certificate.NotAfter) {
+		return nil, fmt.Errorf(i18n.T("le certificat %s a expiré le %s"), Name(s.Certificate), s.Certificate.NotAfter.Format("2006-01-02"))
+	}
+	if opts.Time.IsZero() {
+		opts.Time = time.Now()
+	}
+
+	// Les objets de la mise à jour sont écrits à la suite du document
+	if !bytes.HasSuffix(content, []byte("\n")) {
+		content = append(content, '\n')
+	}
+	increment, err := s.increment(content, opts, conf)
+	if err != nil {
+		return nil, fmt.Errorf(i18n.T("erreur lors de la signature du PDF: %w"), err)
+	}
+	signed := append(content, increment...)
+
+	// Emplacements réservés dans la mise à jour
+	contents := bytes.Index(increment, []byte("<"+strings.Repeat("0", contentsSize*2)+">"))
+	byteRange := bytes.Index(increment, []byte(placeholderByteRange().PDFString()))
+	if contents < 0 || byteRange < 0 {
+		return nil, fmt.Errorf(i18n.T("erreur lors de la signature du PDF: %w"), errors.New("signature dictionary not found"))
+	}
+	contents += len(content)
+	byteRange += len(content)
+	contentsEnd := contents + contentsSize*2 + 2
+
+	// La signature couvre tout le fichier sauf la valeur de /Contents
+	ranges := fmt.Sprintf("[0 %d %d %d", contents, contentsEnd, len(signed)-contentsEnd)
+	width := len(placeholderByteRange().PDFString())
+	copy(signed[byteRange:], ranges+strings.Repeat(" ", width-len(ranges)-1)+"]")
+
+	cms, err := s.cms(append(signed[:contents:contents], signed[contentsEnd:]...))
+	if err != nil {
+		return nil, fmt.Errorf(i18n.T("erreur lors de la signature du PDF: %w"), err)
+	}
+	if len(cms) > contentsSize {
+		return nil, fmt.Errorf(i18n.T("erreur lors de la signature du PDF: %w"), fmt.Errorf("signature too large (%d bytes)", len(cms)))
+	}
+	copy(signed[contents+1:], strings.ToUpper(hex.EncodeToString(cms)))
+
+	return signed, nil
+}
+
+func placeholderByteRange() types.Array {
+	return types.Array{types.Integer(0), types.Integer(byteRangePlaceholder), types.Integer(byteRangePlaceholder), types.Integer(byteRangePlaceholder)}
+}
+
+// increment écrit la mise à jour incrémentale : dictionnaire de signature,
+// champ de signature et widget invisible, formulaire du catalogue et première
+// page
+func (s *Signer) increment(content []byte, opts Options, conf *model.Configuration) ([]byte, error) {
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	}
+	conf.Cmd = model.ADDANNOTATIONS
+
+	ctx, err := api.ReadAndValidate(bytes.NewReader(content), conf)
+	if err != nil {
+		return nil, err
+	}
+	ctx.Write.Increment = true
+	ctx.Write.Offset = int64(len(content))
+
+	sig := types.Dict(map[string]types.Object{
+		"Type":      types.Name("Sig"),
+		"Filter":    types.Name("Adobe.PPKLite"),
+		"SubFilter": types.Name("ETSI.CAdES.detached"),
+		"ByteRange": placeholderByteRange(),
+		"Contents":  types.HexLiteral(strings.Repeat("0", contentsSize*2)),
+		"M":         types.StringLiteral(types.DateString(opts.Time)),
+		"Name":      text(Name(s.Certificate)),
+	})
+	if opts.Location != "" {
+		sig["Location"] = text(opts.Location)
+	}
+	if opts.Reason != "" {
+		sig["Reason"] = text(opts.Reason)
+	}
+	sigRef, err := ctx.IndRefForNewObject(sig)
+	if err != nil {
+		return nil, err
+	}
+
+	pageRef, err := ctx.PageDictIndRef(1)
+	if err != nil {
+		return nil, err
+	}
+	page, err := ctx.DereferenceDict(*pageRef)
+	if err != nil {
+		return nil, err
+	}
+
+	root, err := ctx.Catalog()
+	if err != nil {
+		return nil, err
+	}
+	form, err := ctx.DereferenceDict(root["AcroForm"])
+	if err != nil {
+		return nil, err
+	}
+	if form == nil {
+		form = types.NewDict()
+		root["AcroForm"] = form
+	} else if ref, ok := root["AcroForm"].(types.IndirectRef); ok {
+		ctx.Write.IncrementWithObjNr(ref.ObjectNumber.Value())
+	}
+	fields, err := ctx.DereferenceArray(form["Fields"])
+	if err != nil {
+		return nil, err
+	}
+
+	// Champ de signature invisible (rectangle vide), imprimé et verrouillé
+	field := types.Dict(map[string]types.Object{
+		"Type":    types.Name("Annot"),
+		"Subtype": types.Name("Widget"),
+		"FT":      types.Name("Sig"),
+		"T":       types.StringLiteral(fmt.Sprintf("Signature%d", len(fields)+1)),
+		"Rect":    types.NewNumberArray(0, 0, 0, 0),
+		"F":       types.Integer(132),
+		"P":       *pageRef,
+		"V":       *sigRef,
+	})
+	fieldRef, err := ctx.IndRefForNewObject(field)
+	if err != nil {
+		return nil, err
+	}
+
+	form["Fields"] = append(fields, *fieldRef)
+	form["SigFlags"] = types.Integer(3)
+
+	if ref, ok := page["Annots"].(types.IndirectRef); ok {
+		annots, err := ctx.DereferenceArray(ref)
+		if err != nil {
+			return nil, err
+		}
+		entry, _ := ctx.FindTableEntryForIndRef(&ref)
+		entry.Object = append(annots, *fieldRef)
+		ctx.Write.IncrementWithObjNr(ref.ObjectNumber.Value())
+	} else {
+		annots, _ := page["Annots"].(types.Array)
+		page["Annots"] = append(annots, *fieldRef)
+	}
+
+	ctx.Write.IncrementWithObjNr(ctx.Root.ObjectNumber.Value())
+	ctx.Write.IncrementWithObjNr(pageRef.ObjectNumber.Value())
+	ctx.Write.IncrementWithObjNr(sigRef.ObjectNumber.Value())
+	ctx.Write.IncrementWithObjNr(fieldRef.ObjectNumber.Value())
+
+	var increment bytes.Buffer
+	if err := api.WriteIncrement(ctx, &increment); err != nil {
+		return nil, err
+	}
+	return increment.Bytes(), nil
+}
+
+// text retourne une chaîne PDF en UTF-16
+func text(s string) types.StringLiteral {
+	escaped, _ := types.EscapedUTF16String(s)
+	return types.StringLiteral(*escaped)
+}
+
+// essCertIDv2 identifie le certificat du signataire (RFC 5035), avec
+// l'algorithme SHA-256 par défaut
+type essCertIDv2 struct {
+	CertHash []byte
+}
+
+type signingCertificateV2 struct {
+	Certs []essCertIDv2
+}
+
+// cms retourne la signature CMS détachée des octets signés
+func (s *Signer) cms(data []byte) ([]byte, error) {
+	signed, err := pkcs7.NewSignedData(data)
+	if err != nil {
+		return nil, err
+	}
+	signed.SetDigestAlgorithm(pkcs7.OIDDigestAlgorithmSHA256)
+
+	certHash := sha256.Sum256(s.Certificate.Raw)
+	config := pkcs7.SignerInfoConfig{
+		ExtraSignedAttributes: []pkcs7.Attribute{{
+			Type:  oidSigningCertificateV2,
+			Value: signingCertificateV2{Certs: []essCertIDv2{{CertHash: certHash[:]}}},
+		}},
+	}
+	if err := signed.AddSigner(s.Certificate, s.key, config); err != nil {
+		return nil, err
+	}
+	for _, cert := range s.Chain {
+		signed.AddCertificate(cert)
+	}
+	signed.Detach()
+	return signed.Finish()
+}
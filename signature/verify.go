@@ -0,0 +1,236 @@
+package signature
+
+import (
+	"bytes"
+	"crypto/x509"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"outbil/i18n"
+	"time"
+
+	"github.com/digitorus/pkcs7"
+	"github.com/pdfcpu/pdfcpu/pkg/api"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+)
+
+// Result est le résultat de la vérification d'une signature
+type Result struct {
+	Field       string
+	SubFilter   string
+	Signer      string
+	Certificate *x509.Certificate
+	SigningTime time.Time
+	Reason      string
+	Location    string
+	Err         error // signature invalide ou octets signés altérés
+	Modified    bool  // le fichier a été complété après la signature
+	TrustErr    error // certificat non reconnu par les autorités de confiance
+}
+
+// Valid indique si la signature est intacte et couvre tout le fichier
+func (r *Result) Valid() bool {
+	return r.Err == nil && !r.Modified
+}
+
+// Verify vérifie hors ligne les signatures d'un PDF : intégrité des octets
+// signés, signature CMS et chaîne du certificat jusqu'aux autorités de roots.
+// La configuration porte le mot de passe d'ouverture d'un PDF chiffré.
+func Verify(content []byte, conf *model.Configuration, roots *x509.CertPool) ([]*Result, error) {
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	}
+	conf.Cmd = model.VALIDATESIGNATURES
+
+	ctx, err := api.ReadContext(bytes.NewReader(content), conf)
+	if errors.Is(err, pdfcpu.ErrWrongPassword) {
+		return nil, errors.New(i18n.T("mot de passe d'ouverture du PDF manquant ou incorrect (--password)"))
+	}
+	if err != nil {
+		return nil, fmt.Errorf(i18n.T("PDF illisible: %w"), err)
+	}
+
+	fields, err := signatureFields(ctx)
+	if err != nil {
+		return nil, fmt.Errorf(i18n.T("PDF illisible: %w"), err)
+	}
+
+	var results []*Result
+	for _, field := range fields {
+		results = append(results, verifyField(ctx, content, field, roots))
+	}
+	return results, nil
+}
+
+// signatureFields retourne les champs de signature signés du formulaire
+func signatureFields(ctx *model.Context) ([]types.Dict, error) {
+	root, err := ctx.Catalog()
+	if err != nil {
+		return nil, err
+	}
+	form, err := ctx.DereferenceDict(root["AcroForm"])
+	if err != nil || form == nil {
+		return nil, err
+	}
+
+	var fields []types.Dict
+	var walk func(o types.Object) error
+	walk = func(o types.Object) error {
+		list, err := ctx.DereferenceArray(o)
+		if err != nil {
+			return err
+		}
+		for _, item := range list {
+			field, err := ctx.DereferenceDict(item)
+			if err != nil {
+				return err
+			}
+			if field == nil {
+				continue
+			}
+			if ft := field.NameEntry("FT"); ft != nil && *ft == "Sig" && field["V"] != nil {
+				fields = append(fields, field)
+			}
+			if err := walk(field["Kids"]); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	return fields, walk(form["Fields"])
+}
+
+func verifyField(ctx *model.Context, content []byte, field types.Dict, roots *x509.CertPool) *Result {
+	result := &Result{Field: stringEntry(ctx, field, "T")}
+
+	sig, err := ctx.DereferenceDict(field["V"])
+	if err != nil || sig == nil {
+		result.Err = errors.New(i18n.T("dictionnaire de signature illisible"))
+		return result
+	}
+	if subFilter := sig.NameEntry("SubFilter"); subFilter != nil {
+		result.SubFilter = *subFilter
+	}
+	result.Reason = stringEntry(ctx, sig, "Reason")
+	result.Location = stringEntry(ctx, sig, "Location")
+	if t, ok := types.DateTime(stringEntry(ctx, sig, "M"), true); ok {
+		result.SigningTime = t
+	}
+
+	// Octets signés : tout le fichier, sauf la valeur de /Contents
+	ranges, err := byteRange(ctx, sig, len(content))
+	if err != nil {
+		result.Err = err
+		return result
+	}
+	signed := append(content[ranges[0]:ranges[0]+ranges[1]:ranges[0]+ranges[1]], content[ranges[2]:ranges[2]+ranges[3]]...)
+	end := ranges[2] + ranges[3]
+	result.Modified = ranges[0] != 0 || len(bytes.TrimSpace(content[end:])) > 0
+
+	gap := bytes.TrimSpace(content[ranges[0]+ranges[1] : ranges[2]])
+	if len(gap) < 2 || gap[0] != '<' || gap[len(gap)-1] != '>' {
+		result.Err = errors.New(i18n.T("signature absente des octets réservés"))
+		return result
+	}
+	der, err := hex.DecodeString(string(gap[1 : len(gap)-1]))
+	if err != nil {
+		result.Err = errors.New(i18n.T("signature absente des octets réservés"))
+		return result
+	}
+
+	p7, err := pkcs7.Parse(der)
+	if err != nil {
+		result.Err = fmt.Errorf(i18n.T("signature CMS illisible: %w"), err)
+		return result
+	}
+	p7.Content = signed
+	if cert := p7.GetOnlySigner(); cert != nil {
+		result.Certificate = cert
+		result.Signer = Name(cert)
+	}
+	var signingTime time.Time
+	if err := p7.UnmarshalSignedAttribute(pkcs7.OIDAttributeSigningTime, &signingTime); err == nil && result.SigningTime.IsZero() {
+		result.SigningTime = signingTime
+	}
+
+	if err := p7.Verify(); err != nil {
+		var mismatch *pkcs7.MessageDigestMismatchError
+		if errors.As(err, &mismatch) {
+			result.Err = errors.New(i18n.T("le document a été modifié après la signature"))
+		} else {
+			result.Err = fmt.Errorf(i18n.T("signature invalide: %w"), err)
+		}
+		return result
+	}
+
+	result.TrustErr = verifyChain(p7, result, roots)
+	return result
+}
+
+// byteRange lit et contrôle les plages d'octets signées
+func byteRange(ctx *model.Context, sig types.Dict, size int) ([4]int, error) {
+	var ranges [4]int
+	list, err := ctx.DereferenceArray(sig["ByteRange"])
+	if err != nil || len(list) != 4 {
+		return ranges, errors.New(i18n.T("plages d'octets signées (/ByteRange) invalides"))
+	}
+	for i, o := range list {
+		value, ok := o.(types.Integer)
+		if !ok || value < 0 {
+			return ranges, errors.New(i18n.T("plages d'octets signées (/ByteRange) invalides"))
+		}
+		ranges[i] = value.Value()
+	}
+	if ranges[0]+ranges[1] > ranges[2] || ranges[2]+ranges[3] > size {
+		return ranges, errors.New(i18n.T("plages d'octets signées (/ByteRange) invalides"))
+	}
+	return ranges, nil
+}
+
+// verifyChain contrôle la chaîne du certificat du signataire à la date de
+// signature
+func verifyChain(p7 *pkcs7.PKCS7, result *Result, roots *x509.CertPool) error {
+	if result.Certificate == nil {
+		return errors.New(i18n.T("certificat du signataire absent"))
+	}
+	intermediates := x509.NewCertPool()
+	for _, cert := range p7.Certificates {
+		intermediates.AddCert(cert)
+	}
+	opts := x509.VerifyOptions{
+		Roots:         roots,
+		Intermediates: intermediates,
+		CurrentTime:   result.SigningTime,
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageAny},
+	}
+	if _, err := result.Certificate.Verify(opts); err != nil {
+		var unknown x509.UnknownAuthorityError
+		if errors.As(err, &unknown) {
+			if bytes.Equal(result.Certificate.RawIssuer, result.Certificate.RawSubject) {
+				return errors.New(i18n.T("certificat auto-signé"))
+			}
+			return errors.New(i18n.T("autorité de certification inconnue"))
+		}
+		return err
+	}
+	return nil
+}
+
+// stringEntry retourne le texte d'une entrée chaîne d'un dictionnaire
+func stringEntry(ctx *model.Context, d types.Dict, key string) string {
+	o, err := ctx.Dereference(d[key])
+	if err != nil || o == nil {
+		return ""
+	}
+	switch value := o.(type) {
+	case types.StringLiteral:
+		s, _ := types.StringLiteralToString(value)
+		return s
+	case types.HexLiteral:
+		s, _ := types.HexLiteralToString(value)
+		return s
+	}
+	return ""
+}
@@ -3,6 +3,7 @@ package utils
 import (
 	"os"
 	"path/filepath"
+	"strings"
 )
 
 func GetDatabasePath() string {
@@ -37,4 +38,49 @@ func GetCurrentDatabase() string {
 		return "outbil"
 	}
 	return dbName
-}
\ No newline at end of file
+}
+// GetLanguage retourne la langue des messages enregistrée dans la
+// configuration (vide pour suivre la variable LANG)
+func GetLanguage() string {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+
+	data, err := os.ReadFile(filepath.Join(homeDir, ".outbil", "language"))
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(data))
+}
+
+// SaveLanguage enregistre la langue des messages ; une langue vide supprime
+// le réglage
+func SaveLanguage(language string) error {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return err
+	}
+
+	dataDir := filepath.Join(homeDir, ".outbil")
+	languagePath := filepath.Join(dataDir, "language")
+	if language == "" {
+		if err := os.Remove(languagePath); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		return nil
+	}
+	if err := os.MkdirAll(dataDir, 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(languagePath, []byte(language), 0644)
+}
+
+// GetThemesDir retourne le dossier des thèmes PDF de l'utilisateur
+func GetThemesDir() string {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "themes"
+	}
+	return filepath.Join(homeDir, ".outbil", "themes")
+}
@@ -0,0 +1,113 @@
+package utils
+
+import (
+	"fmt"
+	"math"
+	"outbil/i18n"
+	"strings"
+)
+
+// Currency décrit une devise ISO 4217. Le symbole n'est renseigné que s'il est
+// imprimable avec les polices standard des PDFs (Windows-1252), sinon le code
+// ISO est utilisé.
+type Currency struct {
+	Code     string
+	Name     string
+	Symbol   string
+	Decimals int
+}
+
+const DefaultCurrency = "EUR"
+
+var currencies = map[string]Currency{
+	"EUR": {"EUR", "Euro", "€", 2},
+	"USD": {"USD", "Dollar américain", "$", 2},
+	"GBP": {"GBP", "Livre sterling", "£", 2},
+	"CHF": {"CHF", "Franc suisse", "CHF", 2},
+	"JPY": {"JPY", "Yen", "¥", 0},
+	"CNY": {"CNY", "Yuan", "CNY", 2},
+	"CAD": {"CAD", "Dollar canadien", "CA$", 2},
+	"AUD": {"AUD", "Dollar australien", "A$", 2},
+	"NZD": {"NZD", "Dollar néo-zélandais", "NZ$", 2},
+	"SEK": {"SEK", "Couronne suédoise", "kr", 2},
+	"NOK": {"NOK", "Couronne norvégienne", "kr", 2},
+	"DKK": {"DKK", "Couronne danoise", "kr", 2},
+	"ISK": {"ISK", "Couronne islandaise", "kr", 0},
+	"PLN": {"PLN", "Zloty", "PLN", 2},
+	"CZK": {"CZK", "Couronne tchèque", "CZK", 2},
+	"HUF": {"HUF", "Forint", "Ft", 2},
+	"RON": {"RON", "Leu roumain", "RON", 2},
+	"BGN": {"BGN", "Lev bulgare", "BGN", 2},
+	"TRY": {"TRY", "Livre turque", "TRY", 2},
+	"MAD": {"MAD", "Dirham marocain", "MAD", 2},
+	"TND": {"TND", "Dinar tunisien", "TND", 3},
+	"DZD": {"DZD", "Dinar algérien", "DZD", 2},
+	"XOF": {"XOF", "Franc CFA (BCEAO)", "FCFA", 0},
+	"XAF": {"XAF", "Franc CFA (BEAC)", "FCFA", 0},
+	"XPF": {"XPF", "Franc CFP", "XPF", 0},
+	"KWD": {"KWD", "Dinar koweïtien", "KWD", 3},
+	"BHD": {"BHD", "Dinar bahreïni", "BHD", 3},
+	"AED": {"AED", "Dirham des Émirats", "AED", 2},
+	"SAR": {"SAR", "Riyal saoudien", "SAR", 2},
+	"INR": {"INR", "Roupie indienne", "INR", 2},
+	"KRW": {"KRW", "Won", "KRW", 0},
+	"SGD": {"SGD", "Dollar de Singapour", "S$", 2},
+	"HKD": {"HKD", "Dollar de Hong Kong", "HK$", 2},
+	"BRL": {"BRL", "Réal brésilien", "R$", 2},
+	"MXN": {"MXN", "Peso mexicain", "MX$", 2},
+	"ZAR": {"ZAR", "Rand", "R", 2},
+}
+
+// LookupCurrency retourne la description d'une devise connue
+func LookupCurrency(code string) (Currency, bool) {
+	currency, ok := currencies[NormalizeCurrency(code)]
+	return currency, ok
+}
+
+// NormalizeCurrency met un code devise en majuscules ; un code vide vaut EUR
+func NormalizeCurrency(code string) string {
+	code = strings.ToUpper(strings.TrimSpace(code))
+	if code == "" {
+		return DefaultCurrency
+	}
+	return code
+}
+
+// ValidateCurrency vérifie qu'un code devise ISO 4217 est pris en charge
+func ValidateCurrency(code string) error {
+	if _, ok := LookupCurrency(code); !ok {
+		return fmt.Errorf(i18n.T("devise %q non prise en charge (code ISO 4217 attendu, ex: EUR, USD, GBP)"), strings.TrimSpace(code))
+	}
+	return nil
+}
+
+// CurrencyDecimals retourne le nombre de décimales d'une devise (2 si inconnue)
+func CurrencyDecimals(code string) int {
+	if currency, ok := LookupCurrency(code); ok {
+		return currency.Decimals
+	}
+	return 2
+}
+
+// CurrencySymbol retourne le symbole d'une devise, ou son code à défaut
+func CurrencySymbol(code string) string {
+	if currency, ok := LookupCurrency(code); ok {
+		return currency.Symbol
+	}
+	return NormalizeCurrency(code)
+}
+
+// RoundAmount arrondit un montant au nombre de décimales de la devise
+func RoundAmount(amount float64, code string) float64 {
+	factor := math.Pow(10, float64(CurrencyDecimals(code)))
+	return math.Round(amount*factor) / factor
+}
+
+// CurrencyCodes retourne les codes des devises prises en charge
+func CurrencyCodes() []string {
+	codes := make([]string, 0, len(currencies))
+	for code := range currencies {
+		codes = append(codes, code)
+	}
+	return codes
+}
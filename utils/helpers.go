@@ -6,24 +6,26 @@ import (
 	"strconv"
 	"strings"
 
+	"outbil/i18n"
+
 	"github.com/fatih/color"
 	"github.com/olekukonko/tablewriter"
 )
 
 func Success(format string, args ...interface{}) {
-	color.Green("✓ " + fmt.Sprintf(format, args...))
+	color.Green("✓ " + fmt.Sprintf(i18n.T(format), args...))
 }
 
 func Error(format string, args ...interface{}) {
-	color.Red("✗ " + fmt.Sprintf(format, args...))
+	color.Red("✗ " + fmt.Sprintf(i18n.T(format), args...))
 }
 
 func Info(format string, args ...interface{}) {
-	color.Blue("ℹ " + fmt.Sprintf(format, args...))
+	color.Blue("ℹ " + fmt.Sprintf(i18n.T(format), args...))
 }
 
 func Warning(format string, args ...interface{}) {
-	color.Yellow("⚠ " + fmt.Sprintf(format, args...))
+	color.Yellow("⚠ " + fmt.Sprintf(i18n.T(format), args...))
 }
 
 func CreateTable() *tablewriter.Table {
@@ -32,14 +34,24 @@ func CreateTable() *tablewriter.Table {
 }
 
 func FormatPrice(amount float64, currency string) string {
-	if currency == "" {
-		currency = "EUR"
-	}
-	return fmt.Sprintf("%.2f %s", amount, currency)
+	return currentLocale.FormatPrice(amount, currency)
 }
 
+// ParseFloat accepte la virgule ou le point comme séparateur décimal, ainsi que
+// les séparateurs de milliers (1 234,50 ; 1.234,50 ; 1,234.50)
 func ParseFloat(s string) (float64, error) {
 	s = strings.TrimSpace(s)
-	s = strings.Replace(s, ",", ".", -1)
+	s = strings.NewReplacer(" ", "", "\u00a0", "", "\u202f", "", "'", "").Replace(s)
+
+	comma, dot := strings.LastIndex(s, ","), strings.LastIndex(s, ".")
+	switch {
+	case comma >= 0 && dot >= 0 && comma > dot:
+		s = strings.ReplaceAll(s, ".", "")
+		s = strings.Replace(s, ",", ".", 1)
+	case comma >= 0 && dot >= 0:
+		s = strings.ReplaceAll(s, ",", "")
+	default:
+		s = strings.Replace(s, ",", ".", -1)
+	}
 	return strconv.ParseFloat(s, 64)
 }
\ No newline at end of file
@@ -0,0 +1,274 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"outbil/i18n"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// La Poste dispose d'un SIREN dont les établissements ne respectent pas Luhn :
+// la somme des chiffres du SIRET doit être un multiple de 5.
+const laPosteSIREN = "356000000"
+
+// euVATPatterns décrit le format du numéro de TVA intracommunautaire de chaque
+// État membre (préfixe pays exclu)
+var euVATPatterns = map[string]*regexp.Regexp{
+	"AT": regexp.MustCompile(`^U\d{8}$`),
+	"BE": regexp.MustCompile(`^[01]\d{9}$`),
+	"BG": regexp.MustCompile(`^\d{9,10}$`),
+	"CY": regexp.MustCompile(`^\d{8}[A-Z]$`),
+	"CZ": regexp.MustCompile(`^\d{8,10}$`),
+	"DE": regexp.MustCompile(`^\d{9}$`),
+	"DK": regexp.MustCompile(`^\d{8}$`),
+	"EE": regexp.MustCompile(`^\d{9}$`),
+	"EL": regexp.MustCompile(`^\d{9}$`),
+	"ES": regexp.MustCompile(`^[A-Z0-9]\d{7}[A-Z0-9]$`),
+	"FI": regexp.MustCompile(`^\d{8}$`),
+	"FR": regexp.MustCompile(`^[A-HJ-NP-Z0-9]{2}\d{9}$`),
+	"HR": regexp.MustCompile(`^\d{11}$`),
+	"HU": regexp.MustCompile(`^\d{8}$`),
+	"IE": regexp.MustCompile(`^(\d{7}[A-W][A-I]?|\d[A-Z+*]\d{5}[A-W])$`),
+	"IT": regexp.MustCompile(`^\d{11}$`),
+	"LT": regexp.MustCompile(`^(\d{9}|\d{12})$`),
+	"LU": regexp.MustCompile(`^\d{8}$`),
+	"LV": regexp.MustCompile(`^\d{11}$`),
+	"MT": regexp.MustCompile(`^\d{8}$`),
+	"NL": regexp.MustCompile(`^\d{9}B\d{2}$`),
+	"PL": regexp.MustCompile(`^\d{10}$`),
+	"PT": regexp.MustCompile(`^\d{9}$`),
+	"RO": regexp.MustCompile(`^[1-9]\d{1,9}$`),
+	"SE": regexp.MustCompile(`^\d{10}01$`),
+	"SI": regexp.MustCompile(`^\d{8}$`),
+	"SK": regexp.MustCompile(`^\d{10}$`),
+	"XI": regexp.MustCompile(`^(\d{9}|\d{12}|GD[0-4]\d{2}|HA[5-9]\d{2})$`),
+}
+
+// CompactIdentifier retire espaces, points, tirets et barres d'un identifiant
+func CompactIdentifier(s string) string {
+	return strings.ToUpper(strings.NewReplacer(" ", "", ".", "", "-", "", "/", "", "\t", "").Replace(s))
+}
+
+// Luhn vérifie la clé de Luhn d'une suite de chiffres
+func Luhn(digits string) bool {
+	sum := 0
+	double := false
+	for i := len(digits) - 1; i >= 0; i-- {
+		d := int(digits[i] - '0')
+		if d < 0 || d > 9 {
+			return false
+		}
+		if double {
+			d *= 2
+			if d > 9 {
+				d -= 9
+			}
+		}
+		sum += d
+		double = !double
+	}
+	return sum%10 == 0
+}
+
+func isDigits(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// ValidateSIREN vérifie un numéro SIREN (9 chiffres, clé de Luhn)
+func ValidateSIREN(siren string) error {
+	siren = CompactIdentifier(siren)
+	if len(siren) != 9 || !isDigits(siren) {
+		return errors.New(i18n.T("le SIREN doit comporter 9 chiffres"))
+	}
+	if !Luhn(siren) {
+		return errors.New(i18n.T("SIREN invalide (clé de contrôle incorrecte)"))
+	}
+	return nil
+}
+
+// ValidateSIRET vérifie un numéro SIRET (SIREN + NIC, 14 chiffres, clé de Luhn)
+func ValidateSIRET(siret string) error {
+	siret = CompactIdentifier(siret)
+	if len(siret) != 14 || !isDigits(siret) {
+		return errors.New(i18n.T("le SIRET doit comporter 14 chiffres"))
+	}
+	if err := ValidateSIREN(siret[:9]); err != nil {
+		return err
+	}
+
+	if siret[:9] == laPosteSIREN {
+		sum := 0
+		for _, r := range siret {
+			sum += int(r - '0')
+		}
+		if sum%5 != 0 {
+			return errors.New(i18n.T("SIRET invalide (clé de contrôle incorrecte)"))
+		}
+		return nil
+	}
+
+	if !Luhn(siret) {
+		return errors.New(i18n.T("SIRET invalide (clé de contrôle incorrecte)"))
+	}
+	return nil
+}
+
+// FrenchVATNumber calcule le numéro de TVA intracommunautaire d'un SIREN
+func FrenchVATNumber(siren string) (string, error) {
+	siren = CompactIdentifier(siren)
+	if err := ValidateSIREN(siren); err != nil {
+		return "", err
+	}
+	n, _ := strconv.Atoi(siren)
+	key := (12 + 3*(n%97)) % 97
+	return fmt.Sprintf("FR%02d%s", key, siren), nil
+}
+
+// ValidateVATNumber vérifie hors ligne le format d'un numéro de TVA
+// intracommunautaire et, pour la France, la clé calculée à partir du SIREN.
+// Les identifiants fiscaux hors Union européenne ne sont pas contrôlés.
+func ValidateVATNumber(vat string) error {
+	vat = CompactIdentifier(vat)
+	if len(vat) < 3 {
+		return errors.New(i18n.T("numéro de TVA trop court"))
+	}
+
+	country, number := vat[:2], vat[2:]
+	if country == "GR" {
+		country = "EL"
+	}
+	pattern, ok := euVATPatterns[country]
+	if !ok {
+		return nil
+	}
+
+	if !pattern.MatchString(number) {
+		return fmt.Errorf(i18n.T("format de numéro de TVA %s invalide"), country)
+	}
+
+	if country == "FR" {
+		siren := number[2:]
+		if err := ValidateSIREN(siren); err != nil {
+			return fmt.Errorf(i18n.T("numéro de TVA FR invalide: %v"), err)
+		}
+		// Les clés alphanumériques (nouveau format) ne sont pas calculables
+		if isDigits(number[:2]) {
+			expected, _ := FrenchVATNumber(siren)
+			if expected != vat {
+				return fmt.Errorf(i18n.T("clé de TVA incorrecte, attendu %s"), expected)
+			}
+		}
+	}
+
+	return nil
+}
+
+// ValidateFrenchIdentifiers vérifie la cohérence entre SIREN, SIRET et numéro de TVA
+// français. Les champs vides sont ignorés.
+func ValidateFrenchIdentifiers(siren, siret, vat string) error {
+	siren, siret, vat = CompactIdentifier(siren), CompactIdentifier(siret), CompactIdentifier(vat)
+
+	if siren != "" {
+		if err := ValidateSIREN(siren); err != nil {
+			return err
+		}
+	}
+	if siret != "" {
+		if err := ValidateSIRET(siret); err != nil {
+			return err
+		}
+		if siren != "" && siret[:9] != siren {
+			return fmt.Errorf(i18n.T("le SIRET %s ne correspond pas au SIREN %s"), siret, siren)
+		}
+		if siren == "" {
+			siren = siret[:9]
+		}
+	}
+	if vat != "" {
+		if err := ValidateVATNumber(vat); err != nil {
+			return err
+		}
+		if siren != "" && strings.HasPrefix(vat, "FR") && vat[4:] != siren {
+			return fmt.Errorf(i18n.T("le numéro de TVA %s ne correspond pas au SIREN %s"), vat, siren)
+		}
+	}
+
+	return nil
+}
+
+// ibanLengths donne la longueur de l'IBAN des pays de la zone SEPA
+var ibanLengths = map[string]int{
+	"AD": 24, "AT": 20, "BE": 16, "BG": 22, "CH": 21, "CY": 28, "CZ": 24, "DE": 22,
+	"DK": 18, "EE": 20, "ES": 24, "FI": 18, "FR": 27, "GB": 22, "GI": 23, "GR": 27,
+	"HR": 21, "HU": 28, "IE": 22, "IS": 26, "IT": 27, "LI": 21, "LT": 20, "LU": 20,
+	"LV": 21, "MC": 27, "MT": 31, "NL": 18, "NO": 15, "PL": 28, "PT": 25, "RO": 24,
+	"SE": 24, "SI": 19, "SK": 24, "SM": 27, "VA": 22,
+}
+
+var bicPattern = regexp.MustCompile(`^[A-Z]{4}[A-Z]{2}[A-Z0-9]{2}([A-Z0-9]{3})?$`)
+
+// ValidateIBAN vérifie la longueur et la clé de contrôle (modulo 97) d'un IBAN
+func ValidateIBAN(iban string) error {
+	iban = CompactIdentifier(iban)
+	if len(iban) < 15 || len(iban) > 34 {
+		return errors.New(i18n.T("longueur d'IBAN invalide"))
+	}
+	for _, r := range iban {
+		if !(r >= '0' && r <= '9') && !(r >= 'A' && r <= 'Z') {
+			return errors.New(i18n.T("l'IBAN contient des caractères invalides"))
+		}
+	}
+	if expected, ok := ibanLengths[iban[:2]]; ok && len(iban) != expected {
+		return fmt.Errorf(i18n.T("un IBAN %s doit comporter %d caractères"), iban[:2], expected)
+	}
+
+	// Déplacer les 4 premiers caractères à la fin et convertir les lettres (A=10...)
+	rearranged := iban[4:] + iban[:4]
+	remainder := 0
+	for _, r := range rearranged {
+		var value int
+		if r >= 'A' {
+			value = int(r-'A') + 10
+			remainder = (remainder*100 + value) % 97
+		} else {
+			value = int(r - '0')
+			remainder = (remainder*10 + value) % 97
+		}
+	}
+	if remainder != 1 {
+		return errors.New(i18n.T("IBAN invalide (clé de contrôle incorrecte)"))
+	}
+	return nil
+}
+
+// ValidateBIC vérifie le format d'un code BIC (8 ou 11 caractères)
+func ValidateBIC(bic string) error {
+	if !bicPattern.MatchString(CompactIdentifier(bic)) {
+		return errors.New(i18n.T("BIC invalide (8 ou 11 caractères, ex: BNPAFRPPXXX)"))
+	}
+	return nil
+}
+
+// FormatIBAN présente un IBAN par groupes de 4 caractères
+func FormatIBAN(iban string) string {
+	iban = CompactIdentifier(iban)
+	var groups []string
+	for i := 0; i < len(iban); i += 4 {
+		end := i + 4
+		if end > len(iban) {
+			end = len(iban)
+		}
+		groups = append(groups, iban[i:end])
+	}
+	return strings.Join(groups, " ")
+}
@@ -0,0 +1,208 @@
+package utils
+
+import "testing"
+
+func TestLuhn(t *testing.T) {
+	tests := []struct {
+		digits string
+		want   bool
+	}{
+		{"0", true},
+		{"18", true},
+		{"732829320", true},
+		{"732829321", false},
+		{"4539578763621486", true},
+		{"73282932000074", true},
+		{"73282932000075", false},
+		{"7328293a0", false},
+	}
+	for _, test := range tests {
+		if got := Luhn(test.digits); got != test.want {
+			t.Errorf("Luhn(%q) = %v, attendu %v", test.digits, got, test.want)
+		}
+	}
+}
+
+func TestValidateSIRENAndSIRET(t *testing.T) {
+	tests := []struct {
+		name  string
+		check func(string) error
+		value string
+		valid bool
+	}{
+		{name: "SIREN valide", check: ValidateSIREN, value: "732829320", valid: true},
+		{name: "SIREN avec espaces", check: ValidateSIREN, value: " 732 829 320 ", valid: true},
+		{name: "SIREN avec points", check: ValidateSIREN, value: "732.829.320", valid: true},
+		{name: "SIREN clé fausse", check: ValidateSIREN, value: "732829321"},
+		{name: "SIREN trop court", check: ValidateSIREN, value: "73282932"},
+		{name: "SIREN non numérique", check: ValidateSIREN, value: "73282932A"},
+		{name: "SIREN vide", check: ValidateSIREN, value: ""},
+		{name: "SIRET valide", check: ValidateSIRET, value: "73282932000074", valid: true},
+		{name: "SIRET avec espaces", check: ValidateSIRET, value: "732 829 320 00074", valid: true},
+		{name: "SIRET clé fausse", check: ValidateSIRET, value: "73282932000075"},
+		{name: "SIRET au SIREN invalide", check: ValidateSIRET, value: "73282932100074"},
+		{name: "SIRET trop long", check: ValidateSIRET, value: "732829320000740"},
+		{name: "SIRET La Poste, somme multiple de 5", check: ValidateSIRET, value: "35600000049837", valid: true},
+		{name: "SIRET La Poste, somme non multiple de 5", check: ValidateSIRET, value: "35600000049838"},
+		// Luhn valide mais la règle propre à La Poste s'applique
+		{name: "SIRET La Poste, Luhn sans la règle", check: ValidateSIRET, value: "35600000000048"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := test.check(test.value)
+			if test.valid && err != nil {
+				t.Errorf("%q: %v", test.value, err)
+			}
+			if !test.valid && err == nil {
+				t.Errorf("%q: erreur attendue", test.value)
+			}
+		})
+	}
+}
+
+func TestFrenchVATNumber(t *testing.T) {
+	tests := []struct{ siren, want string }{
+		{"732829320", "FR44732829320"},
+		{"732 829 320", "FR44732829320"},
+		{"356000000", "FR39356000000"},
+		{"732829321", ""},
+	}
+	for _, test := range tests {
+		got, err := FrenchVATNumber(test.siren)
+		if test.want == "" {
+			if err == nil {
+				t.Errorf("%q: erreur attendue, obtenu %s", test.siren, got)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%q: %v", test.siren, err)
+		} else if got != test.want {
+			t.Errorf("%q: %s, attendu %s", test.siren, got, test.want)
+		}
+	}
+}
+
+func TestValidateVATNumber(t *testing.T) {
+	tests := []struct {
+		vat   string
+		valid bool
+	}{
+		{"FR44732829320", true},
+		{"fr 44 732 829 320", true},
+		{"FR45732829320", false},
+		{"FR44732829321", false},
+		// Clé alphanumérique : seul le SIREN est contrôlé
+		{"FRAB732829320", true},
+		{"FRIO732829320", false},
+		{"DE123456789", true},
+		{"DE12345678", false},
+		{"ATU12345678", true},
+		{"AT12345678", false},
+		{"NL123456789B01", true},
+		{"NL123456789", false},
+		{"ESX1234567L", true},
+		{"EL123456789", true},
+		{"GR123456789", true},
+		{"IE1234567WA", true},
+		{"SE123456789001", true},
+		{"SE123456789002", false},
+		{"XI123456789", true},
+		// Hors Union européenne : non contrôlé
+		{"CHE123456789", true},
+		{"FR", false},
+	}
+	for _, test := range tests {
+		err := ValidateVATNumber(test.vat)
+		if test.valid && err != nil {
+			t.Errorf("%q: %v", test.vat, err)
+		}
+		if !test.valid && err == nil {
+			t.Errorf("%q: erreur attendue", test.vat)
+		}
+	}
+}
+
+func TestValidateFrenchIdentifiers(t *testing.T) {
+	tests := []struct {
+		siren, siret, vat string
+		valid             bool
+	}{
+		{"", "", "", true},
+		{"732829320", "73282932000074", "FR44732829320", true},
+		{"", "73282932000074", "FR44732829320", true},
+		{"356000000", "73282932000074", "", false},
+		{"", "73282932000074", "FR39356000000", false},
+		{"", "", "DE123456789", true},
+	}
+	for _, test := range tests {
+		err := ValidateFrenchIdentifiers(test.siren, test.siret, test.vat)
+		if test.valid && err != nil {
+			t.Errorf("%+v: %v", test, err)
+		}
+		if !test.valid && err == nil {
+			t.Errorf("%+v: erreur attendue", test)
+		}
+	}
+}
+
+func TestValidateIBAN(t *testing.T) {
+	tests := []struct {
+		iban  string
+		valid bool
+	}{
+		{"FR1420041010050500013M02606", true},
+		{"FR14 2004 1010 0505 0001 3M02 606", true},
+		{"fr1420041010050500013m02606", true},
+		{"DE89370400440532013000", true},
+		{"GB82WEST12345698765432", true},
+		{"BE68539007547034", true},
+		{"FR1520041010050500013M02606", false},
+		{"DE89370400440532013001", false},
+		// Longueur propre au pays
+		{"FR142004101005050001302606", false},
+		{"DE8937040044053201300", false},
+		{"FR14_20041010050500013M02606", false},
+		{"FR14", false},
+	}
+	for _, test := range tests {
+		err := ValidateIBAN(test.iban)
+		if test.valid && err != nil {
+			t.Errorf("%q: %v", test.iban, err)
+		}
+		if !test.valid && err == nil {
+			t.Errorf("%q: erreur attendue", test.iban)
+		}
+	}
+}
+
+func TestValidateBIC(t *testing.T) {
+	tests := []struct {
+		bic   string
+		valid bool
+	}{
+		{"BNPAFRPP", true},
+		{"BNPAFRPPXXX", true},
+		{"bnpa fr pp xxx", true},
+		{"DEUTDEFF500", true},
+		{"BNPAFRP", false},
+		{"BNPAFRPPXX", false},
+		{"BNP1FRPP", false},
+		{"BNPA12PP", false},
+	}
+	for _, test := range tests {
+		err := ValidateBIC(test.bic)
+		if test.valid && err != nil {
+			t.Errorf("%q: %v", test.bic, err)
+		}
+		if !test.valid && err == nil {
+			t.Errorf("%q: erreur attendue", test.bic)
+		}
+	}
+}
+
+func TestFormatIBAN(t *testing.T) {
+	if got, want := FormatIBAN("fr1420041010050500013m02606"), "FR14 2004 1010 0505 0001 3M02 606"; got != want {
+		t.Errorf("%q, attendu %q", got, want)
+	}
+}
@@ -0,0 +1,84 @@
+package utils
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	_ "image/gif"
+	_ "image/jpeg"
+	"image/png"
+	"outbil/i18n"
+
+	"golang.org/x/image/draw"
+)
+
+const (
+	// Dimensions maximales du logo enregistré en base, suffisantes pour une
+	// impression nette sur 35 mm de large
+	LogoMaxWidth  = 600
+	LogoMaxHeight = 300
+
+	logoMaxFileSize = 5 << 20
+	logoMinSide     = 16
+)
+
+// PrepareLogo valide une image PNG, JPEG ou GIF, la réduit si nécessaire en
+// conservant ses proportions et la réencode en PNG
+func PrepareLogo(data []byte) ([]byte, error) {
+	if len(data) == 0 {
+		return nil, errors.New(i18n.T("fichier vide"))
+	}
+	if len(data) > logoMaxFileSize {
+		return nil, fmt.Errorf(i18n.T("fichier trop volumineux (%d Ko, maximum %d Ko)"), len(data)>>10, logoMaxFileSize>>10)
+	}
+
+	src, format, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, errors.New(i18n.T("format d'image non reconnu (PNG, JPEG ou GIF attendu)"))
+	}
+
+	bounds := src.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+	if width < logoMinSide || height < logoMinSide {
+		return nil, fmt.Errorf(i18n.T("image trop petite (%dx%d pixels)"), width, height)
+	}
+
+	scale := 1.0
+	if width > LogoMaxWidth {
+		scale = float64(LogoMaxWidth) / float64(width)
+	}
+	if float64(height)*scale > LogoMaxHeight {
+		scale = float64(LogoMaxHeight) / float64(height)
+	}
+
+	// Un PNG déjà aux bonnes dimensions est conservé tel quel
+	if scale == 1.0 && format == "png" {
+		return data, nil
+	}
+
+	var dst draw.Image = image.NewNRGBA(image.Rect(0, 0, width, height))
+	if scale < 1.0 {
+		w := int(float64(width)*scale + 0.5)
+		h := int(float64(height)*scale + 0.5)
+		dst = image.NewNRGBA(image.Rect(0, 0, w, h))
+		draw.CatmullRom.Scale(dst, dst.Bounds(), src, bounds, draw.Src, nil)
+	} else {
+		draw.Draw(dst, dst.Bounds(), src, bounds.Min, draw.Src)
+	}
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, dst); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// ImageSize renvoie les dimensions en pixels d'une image encodée
+func ImageSize(data []byte) (int, int, error) {
+	config, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return 0, 0, err
+	}
+	return config.Width, config.Height, nil
+}
@@ -0,0 +1,246 @@
+package utils
+
+import (
+	"fmt"
+	"math"
+	"outbil/i18n"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// Locale regroupe les conventions d'affichage des nombres, montants et dates.
+// Les espaces insécables sont des U+00A0, imprimables avec les polices standard
+// des PDFs.
+type Locale struct {
+	Code           string
+	Name           string
+	DecimalSep     string
+	GroupSep       string
+	SymbolFirst    bool   // symbole avant le montant (£1,234.50)
+	SymbolSpace    string // séparateur entre le symbole et le montant
+	PercentSpace   string // séparateur entre le nombre et le signe %
+	DateLayout     string
+	DateTimeLayout string
+}
+
+const DefaultLocale = "fr-FR"
+
+const nbsp = "\u00a0"
+
+var locales = map[string]Locale{
+	"fr-FR": {"fr-FR", "Français (France)", ",", nbsp, false, nbsp, nbsp, "02/01/2006", "02/01/2006 15:04"},
+	"en-GB": {"en-GB", "English (United Kingdom)", ".", ",", true, "", "", "02/01/2006", "02/01/2006 15:04"},
+	"en-US": {"en-US", "English (United States)", ".", ",", true, "", "", "01/02/2006", "01/02/2006 3:04 PM"},
+	"de-DE": {"de-DE", "Deutsch (Deutschland)", ",", ".", false, nbsp, nbsp, "02.01.2006", "02.01.2006 15:04"},
+}
+
+var currentLocale = locales[DefaultLocale]
+
+// GetLocale retourne les conventions d'une locale (fr-FR si inconnue ou vide).
+// La casse et le séparateur (fr_FR, FR-fr) sont indifférents.
+func GetLocale(code string) Locale {
+	if locale, ok := locales[NormalizeLocale(code)]; ok {
+		return locale
+	}
+	return locales[DefaultLocale]
+}
+
+// NormalizeLocale met un code de locale sous la forme ll-CC
+func NormalizeLocale(code string) string {
+	code = strings.TrimSpace(strings.ReplaceAll(code, "_", "-"))
+	if code == "" {
+		return DefaultLocale
+	}
+	parts := strings.SplitN(code, "-", 2)
+	if len(parts) == 1 {
+		return strings.ToLower(parts[0])
+	}
+	return strings.ToLower(parts[0]) + "-" + strings.ToUpper(parts[1])
+}
+
+// ValidateLocale vérifie qu'une locale est prise en charge
+func ValidateLocale(code string) error {
+	if _, ok := locales[NormalizeLocale(code)]; !ok {
+		return fmt.Errorf(i18n.T("locale %q non prise en charge (%s)"), strings.TrimSpace(code), strings.Join(LocaleCodes(), ", "))
+	}
+	return nil
+}
+
+// LocaleCodes retourne les locales prises en charge, triées
+func LocaleCodes() []string {
+	codes := make([]string, 0, len(locales))
+	for code := range locales {
+		codes = append(codes, code)
+	}
+	sort.Strings(codes)
+	return codes
+}
+
+// SetLocale choisit la locale utilisée par FormatPrice, FormatDate et FormatNumber
+func SetLocale(code string) {
+	currentLocale = GetLocale(code)
+}
+
+// CurrentLocale retourne la locale choisie avec SetLocale
+func CurrentLocale() Locale {
+	return currentLocale
+}
+
+// FormatNumber formate un nombre avec un nombre fixe de décimales
+func (l Locale) FormatNumber(value float64, decimals int) string {
+	negative := value < 0 && math.Round(math.Abs(value)*math.Pow(10, float64(decimals))) != 0
+	digits := strconv.FormatFloat(math.Abs(value), 'f', decimals, 64)
+
+	integer, fraction := digits, ""
+	if i := strings.IndexByte(digits, '.'); i >= 0 {
+		integer, fraction = digits[:i], digits[i+1:]
+	}
+
+	var b strings.Builder
+	if negative {
+		b.WriteString("-")
+	}
+	for i, r := range integer {
+		if i > 0 && (len(integer)-i)%3 == 0 {
+			b.WriteString(l.GroupSep)
+		}
+		b.WriteRune(r)
+	}
+	if fraction != "" {
+		b.WriteString(l.DecimalSep)
+		b.WriteString(fraction)
+	}
+	return b.String()
+}
+
+// FormatQuantity formate une quantité sans zéros inutiles (2 ; 1,5 ; 0,25)
+func (l Locale) FormatQuantity(value float64) string {
+	formatted := l.FormatNumber(value, 2)
+	if l.DecimalSep == "" || !strings.Contains(formatted, l.DecimalSep) {
+		return formatted
+	}
+	formatted = strings.TrimRight(formatted, "0")
+	return strings.TrimSuffix(formatted, l.DecimalSep)
+}
+
+// FormatPercent formate un taux (20 %, 5,5 %)
+func (l Locale) FormatPercent(rate float64) string {
+	return l.FormatQuantity(rate) + l.PercentSpace + "%"
+}
+
+// FormatAmount formate un montant avec les décimales de la devise, sans symbole
+func (l Locale) FormatAmount(amount float64, currency string) string {
+	return l.FormatNumber(amount, CurrencyDecimals(currency))
+}
+
+// FormatPrice formate un montant avec le symbole de la devise, placé selon la locale
+func (l Locale) FormatPrice(amount float64, currency string) string {
+	number := l.FormatAmount(amount, currency)
+	symbol := CurrencySymbol(currency)
+
+	if !l.SymbolFirst {
+		return number + l.SymbolSpace + symbol
+	}
+
+	// Les symboles alphabétiques (CHF, kr) restent séparés du montant
+	space := l.SymbolSpace
+	if last := []rune(symbol); len(last) > 0 && unicode.IsLetter(last[len(last)-1]) {
+		space = nbsp
+	}
+	if strings.HasPrefix(number, "-") {
+		return "-" + symbol + space + number[1:]
+	}
+	return symbol + space + number
+}
+
+// FormatDate formate une date selon la locale
+func (l Locale) FormatDate(t time.Time) string {
+	return t.Format(l.DateLayout)
+}
+
+// FormatDateTime formate une date et une heure selon la locale
+func (l Locale) FormatDateTime(t time.Time) string {
+	return t.Format(l.DateTimeLayout)
+}
+
+// isoDateLayout est accepté en saisie quelle que soit la locale
+const isoDateLayout = "2006-01-02"
+
+// ParseDate lit une date saisie au format de la locale ou au format ISO (AAAA-MM-JJ)
+func (l Locale) ParseDate(value string) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	for _, layout := range []string{l.DateLayout, isoDateLayout} {
+		if date, err := time.ParseInLocation(layout, value, time.Local); err == nil {
+			return date, nil
+		}
+	}
+	example := time.Date(2025, 12, 31, 0, 0, 0, 0, time.Local)
+	return time.Time{}, fmt.Errorf(i18n.T("date invalide %q (exemple: %s ou %s)"), value, l.FormatDate(example), example.Format(isoDateLayout))
+}
+
+// FormatAmount formate un montant dans la locale courante, sans symbole
+func FormatAmount(amount float64, currency string) string {
+	return currentLocale.FormatAmount(amount, currency)
+}
+
+// FormatNumber formate un nombre dans la locale courante
+func FormatNumber(value float64, decimals int) string {
+	return currentLocale.FormatNumber(value, decimals)
+}
+
+// FormatDate formate une date dans la locale courante
+func FormatDate(t time.Time) string {
+	return currentLocale.FormatDate(t)
+}
+
+// FormatQuantity formate une quantité dans la locale courante
+func FormatQuantity(value float64) string {
+	return currentLocale.FormatQuantity(value)
+}
+
+// FormatPercent formate un taux dans la locale courante
+func FormatPercent(rate float64) string {
+	return currentLocale.FormatPercent(rate)
+}
+
+// ParseDate lit une date saisie dans la locale courante ou au format ISO
+func ParseDate(value string) (time.Time, error) {
+	return currentLocale.ParseDate(value)
+}
+
+// FormatDateTime formate une date et une heure dans la locale courante
+func FormatDateTime(t time.Time) string {
+	return currentLocale.FormatDateTime(t)
+}
+
+// languageLocales associe à chaque langue de document sa locale par défaut
+var languageLocales = map[string]string{
+	"fr": "fr-FR",
+	"en": "en-GB",
+	"de": "de-DE",
+}
+
+// LocaleForLanguage choisit la locale d'un document rédigé dans une langue :
+// la locale préférée si elle correspond à cette langue, sinon la locale par
+// défaut de la langue
+func LocaleForLanguage(language, preferred string) Locale {
+	preferred = NormalizeLocale(preferred)
+	if language == "" || strings.HasPrefix(preferred, language+"-") {
+		return GetLocale(preferred)
+	}
+	if code, ok := languageLocales[language]; ok {
+		return GetLocale(code)
+	}
+	return GetLocale(preferred)
+}
+
+// Language retourne la langue d'une locale (fr pour fr-FR)
+func (l Locale) Language() string {
+	if i := strings.IndexByte(l.Code, '-'); i > 0 {
+		return l.Code[:i]
+	}
+	return l.Code
+}
@@ -0,0 +1,69 @@
+package utils
+
+import (
+	"testing"
+	"time"
+)
+
+func TestLocaleParseDate(t *testing.T) {
+	christmas := time.Date(2025, 12, 25, 0, 0, 0, 0, time.Local)
+	march := time.Date(2025, 3, 4, 0, 0, 0, 0, time.Local)
+
+	tests := []struct {
+		locale string
+		value  string
+		want   time.Time // zéro si une erreur est attendue
+	}{
+		{locale: "fr-FR", value: "25/12/2025", want: christmas},
+		{locale: "fr-FR", value: "04/03/2025", want: march},
+		{locale: "fr-FR", value: " 2025-12-25 ", want: christmas},
+		{locale: "fr-FR", value: "12/25/2025"},
+		{locale: "fr-FR", value: "25.12.2025"},
+
+		{locale: "en-GB", value: "25/12/2025", want: christmas},
+		{locale: "en-GB", value: "2025-03-04", want: march},
+		{locale: "en-GB", value: "12/25/2025"},
+
+		{locale: "en-US", value: "12/25/2025", want: christmas},
+		{locale: "en-US", value: "03/04/2025", want: march},
+		{locale: "en-US", value: "2025-12-25", want: christmas},
+		{locale: "en-US", value: "25/12/2025"},
+
+		{locale: "de-DE", value: "25.12.2025", want: christmas},
+		{locale: "de-DE", value: "04.03.2025", want: march},
+		{locale: "de-DE", value: "2025-12-25", want: christmas},
+		{locale: "de-DE", value: "25/12/2025"},
+
+		{locale: "fr-FR", value: "31/02/2025"},
+		{locale: "fr-FR", value: ""},
+		{locale: "fr-FR", value: "demain"},
+	}
+
+	for _, test := range tests {
+		got, err := GetLocale(test.locale).ParseDate(test.value)
+		if test.want.IsZero() {
+			if err == nil {
+				t.Errorf("%s %q: erreur attendue, obtenu %v", test.locale, test.value, got)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s %q: %v", test.locale, test.value, err)
+		} else if !got.Equal(test.want) {
+			t.Errorf("%s %q: %v, attendu %v", test.locale, test.value, got, test.want)
+		}
+	}
+}
+
+func TestParseDateUsesCurrentLocale(t *testing.T) {
+	defer SetLocale(DefaultLocale)
+
+	SetLocale("en-US")
+	got, err := ParseDate("12/25/2025")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := time.Date(2025, 12, 25, 0, 0, 0, 0, time.Local); !got.Equal(want) {
+		t.Errorf("%v, attendu %v", got, want)
+	}
+}